@@ -0,0 +1,63 @@
+// Package main is the entry point of the command building offline species catalogs.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/catalog"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/inaturalist"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+)
+
+func main() {
+	out := flag.String("out", "catalog.json", "catalog file to write")
+	place := flag.Int("place", 6753, "iNaturalist place ID, 0 for everywhere (default France)")
+	taxa := flag.String("taxa", "", "comma-separated iconic taxa, e.g. Aves,Mammalia (default all)")
+	pages := flag.Int("pages", 5, "pages of 200 observations crawled per taxon")
+	photos := flag.Int("photos", 5, "photos kept per species")
+	lookalikes := flag.Int("lookalikes", 10, "lookalikes and confused species linked per species")
+	withMedia := flag.Bool("media", true, "download photos and sounds next to the catalog for offline use")
+	flag.Parse()
+
+	opts := []catalog.CrawlerOption{
+		catalog.WithPlace(*place),
+		catalog.WithPages(*pages),
+		catalog.WithPhotosPerSpecies(*photos),
+		catalog.WithLookalikes(*lookalikes),
+		catalog.WithLogf(log.Printf),
+	}
+	if *taxa != "" {
+		names := strings.Split(*taxa, ",")
+		for _, name := range names {
+			if !species.IsValidIconicTaxon(name) {
+				log.Fatalf("Unknown iconic taxon %q", name)
+			}
+		}
+		opts = append(opts, catalog.WithTaxa(names...))
+	}
+
+	// Stop cleanly on interrupt, without writing a partial catalog
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	crawled, err := catalog.NewCrawler(inaturalist.NewClient(), opts...).Crawl(ctx)
+	if err != nil {
+		log.Fatalf("Failed to crawl catalog: %v", err)
+	}
+	if *withMedia {
+		downloader := catalog.NewDownloader(catalog.MediaDir(*out), catalog.WithDownloadLogf(log.Printf))
+		if err := downloader.Download(ctx, crawled); err != nil {
+			log.Fatalf("Failed to download catalog media: %v", err)
+		}
+	}
+	if err := crawled.Save(*out); err != nil {
+		log.Fatalf("Failed to save catalog: %v", err)
+	}
+	log.Printf("Catalog written to %s: %d species, %d ancestors", *out, len(crawled.Species), len(crawled.Ancestors))
+}
@@ -3,23 +3,42 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
+	_ "modernc.org/sqlite" // Embedded SQLite driver
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/cache"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/catalog"
 	httphandler "github.com/Naturieux-fr/Naturieux.fr/internal/adapters/http"
 	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/inaturalist"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/media"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/memory"
+	sqlstore "github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/sql"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/resilience"
+	appleaderboard "github.com/Naturieux-fr/Naturieux.fr/internal/application/leaderboard"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/application/lock"
+	appplayer "github.com/Naturieux-fr/Naturieux.fr/internal/application/player"
 	appquiz "github.com/Naturieux-fr/Naturieux.fr/internal/application/quiz"
 	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
 	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
 )
 
 const (
-	defaultPort = "8080"
+	defaultPort           = "8080"
+	sessionEvictionPeriod = 5 * time.Minute
+	derivativePrunePeriod = time.Hour
+	francePlaceID         = 6753 // Place of sessions without a place filter
+
+	// Creative Commons photos; all rights reserved ones cannot be published
+	defaultPhotoLicenses = "cc0,cc-by,cc-by-sa,cc-by-nc,cc-by-nc-sa,cc-by-nd,cc-by-nc-nd"
 )
 
 func main() {
@@ -28,38 +47,124 @@ func main() {
 		port = defaultPort
 	}
 
-	// Initialize dependencies
-	inatClient := inaturalist.NewClient()
+	// Achievements: JSON catalog when ACHIEVEMENTS_PATH is set, embedded default otherwise
+	if path := os.Getenv("ACHIEVEMENTS_PATH"); path != "" {
+		achievements, err := gamification.LoadCatalog(path)
+		if err != nil {
+			log.Fatalf("Failed to load achievements: %v", err)
+		}
+		gamification.UseCatalog(achievements)
+	}
 
-	// In-memory player repository (use proper database in production)
-	playerRepo := newInMemoryPlayerRepository()
+	// Species: offline catalog when CATALOG_PATH is set, iNaturalist otherwise
+	catalogPath := os.Getenv("CATALOG_PATH")
+	source, err := newSpeciesSource(catalogPath)
+	if err != nil {
+		log.Fatalf("Failed to load species catalog: %v", err)
+	}
 
-	// Create a demo player
-	demoPlayer, err := gamification.NewPlayer("demo", "demo_user")
+	// Persistence: SQLite when DATABASE_PATH is set, in-memory otherwise
+	repoCtx, stopRepos := context.WithCancel(context.Background())
+	defer stopRepos()
+	repos, err := newRepositories(repoCtx, os.Getenv("DATABASE_PATH"))
 	if err != nil {
-		log.Fatalf("Failed to create demo player: %v", err)
+		log.Fatalf("Failed to initialize persistence: %v", err)
 	}
-	if err := playerRepo.Create(context.Background(), demoPlayer); err != nil {
+	defer repos.close()
+
+	// Create a demo player
+	if err := ensureDemoPlayer(repoCtx, repos.players); err != nil {
 		log.Fatalf("Failed to store demo player: %v", err)
 	}
 
+	// Question media and derived images: disk cache in MEDIA_CACHE_DIR, temporary directory otherwise
+	mediaDir := os.Getenv("MEDIA_CACHE_DIR")
+	if mediaDir == "" {
+		mediaDir = filepath.Join(os.TempDir(), "naturieux-media")
+	}
+	var mediaOpts []media.Option
+	if catalogPath != "" {
+		// Photos and sounds of the catalog are served from the directory next to it
+		mediaOpts = append(mediaOpts, media.WithCatalogMedia(catalog.MediaDir(catalogPath)))
+	}
+	mediaService, err := media.NewService(mediaDir, mediaOpts...)
+	if err != nil {
+		log.Fatalf("Failed to open media cache: %v", err)
+	}
+	mediaCtx, stopMedia := context.WithCancel(context.Background())
+	defer stopMedia()
+	mediaService.StartPruning(mediaCtx, derivativePrunePeriod)
+
+	// Media tokens: signed with MEDIA_TOKEN_KEY, a random key lost on restart otherwise
+	mediaTokens, err := media.NewTokens([]byte(os.Getenv("MEDIA_TOKEN_KEY")))
+	if err != nil {
+		log.Fatalf("Failed to create media tokens: %v", err)
+	}
+
+	// Photo licenses: comma-separated ALLOWED_PHOTO_LICENSES, Creative Commons otherwise
+	photoLicenses := os.Getenv("ALLOWED_PHOTO_LICENSES")
+	if photoLicenses == "" {
+		photoLicenses = defaultPhotoLicenses
+	}
+
 	// Create question factory
 	questionFactory := appquiz.NewQuestionFactory(
-		inatClient,
-		appquiz.WithTaxonFilter(""),   // All taxa
-		appquiz.WithPlaceFilter(6753), // France
+		source.species,
+		appquiz.WithMediaDeriver(mediaService),
+		appquiz.WithAllowedLicenses(strings.Split(photoLicenses, ",")...),
 	)
 
-	// Create quiz service
+	// Prefetch questions in the background with the request budget players leave spare
+	poolCtx, stopPool := context.WithCancel(context.Background())
+	defer stopPool()
+	questionPool := appquiz.NewQuestionPool(questionFactory)
+	questionPool.Start(poolCtx)
+
+	// Create leaderboard service, fed by completed sessions
+	leaderboardService := appleaderboard.NewService(repos.players, repos.scores)
+
+	// Players are updated by the quiz and player services under the same locks
+	playerLocks := &lock.KeyedMutex{}
+
+	// Create quiz service, checking session taxa and, when they can be looked up, places
+	quizOpts := []appquiz.ServiceOption{
+		appquiz.WithPlayerLocks(playerLocks),
+		appquiz.WithSessionRecorder(leaderboardService),
+		appquiz.WithQuestionPool(questionPool),
+		appquiz.WithDefaultPlace(francePlaceID),
+		appquiz.WithSpecies(source.species),
+	}
+	if source.places != nil {
+		quizOpts = append(quizOpts, appquiz.WithPlaces(source.places))
+	}
 	quizService := appquiz.NewService(
 		questionFactory,
-		nil, // No session persistence for now
-		playerRepo,
+		repos.sessions,
+		repos.players,
 		nil, // No event publisher for now
+		quizOpts...,
 	)
 
+	// Create player service, checking home places when places can be looked up
+	playerOpts := []appplayer.ServiceOption{appplayer.WithPlayerLocks(playerLocks)}
+	if source.places != nil {
+		playerOpts = append(playerOpts, appplayer.WithPlaces(source.places))
+	}
+	playerService := appplayer.NewService(repos.players, playerOpts...)
+
 	// Create HTTP handler
-	handler := httphandler.NewHandler(quizService)
+	handlerOpts := append([]httphandler.HandlerOption{
+		httphandler.WithPlayerService(playerService),
+		httphandler.WithLeaderboardService(leaderboardService),
+		httphandler.WithMediaProxy(mediaService, mediaTokens),
+		httphandler.WithHealthDetail("media_cache", func() interface{} { return mediaService.Stats() }),
+		httphandler.WithHealthDetail("media_bandwidth", func() interface{} { return mediaService.Bandwidth() }),
+		httphandler.WithHealthDetail("question_pool", func() interface{} { return questionPool.Stats() }),
+	}, source.health...)
+	if source.places != nil {
+		handlerOpts = append(handlerOpts, httphandler.WithPlaces(source.places))
+	}
+	handler := httphandler.NewHandler(quizService, handlerOpts...)
 
 	// Create HTTP server
 	mux := http.NewServeMux()
@@ -106,69 +211,131 @@ func main() {
 	log.Println("Server stopped")
 }
 
-// corsMiddleware adds CORS headers for development.
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+// speciesSource groups the species and place adapters used by the server.
+type speciesSource struct {
+	species ports.SpeciesRepository
+	places  ports.PlaceRepository // Nil when serving a catalog
+	health  []httphandler.HandlerOption
+}
 
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
+// newSpeciesSource creates the species and place repositories and their health
+// details. An empty catalogPath selects the iNaturalist API.
+func newSpeciesSource(catalogPath string) (*speciesSource, error) {
+	if catalogPath != "" {
+		speciesCatalog, err := catalog.Load(catalogPath)
+		if err != nil {
+			return nil, err
+		}
+		repo, err := catalog.NewSpeciesRepository(speciesCatalog)
+		if err != nil {
+			return nil, err
 		}
+		log.Printf("Serving %d species from catalog %s", len(speciesCatalog.Species), catalogPath)
+		return &speciesSource{species: repo}, nil
+	}
 
-		next.ServeHTTP(w, r)
-	})
-}
+	inatClient := inaturalist.NewClient()
+
+	// Fail fast while iNaturalist is down; missing species and quota refusals are not outages
+	breaker := resilience.NewCircuitBreaker(
+		inatClient,
+		resilience.WithIgnoredErrors(
+			inaturalist.ErrNotFound,
+			inaturalist.ErrQuotaExhausted,
+			inaturalist.ErrQuotaReserved,
+		),
+	)
+
+	// Cache taxa and random species pools in front of the breaker
+	speciesCache := cache.NewSpeciesRepository(breaker)
+	placeCache := cache.NewPlaceRepository(inatClient)
 
-// inMemoryPlayerRepository is a simple in-memory player repository for demo.
-type inMemoryPlayerRepository struct {
-	players map[string]*gamification.Player
+	return &speciesSource{
+		species: speciesCache,
+		places:  placeCache,
+		health: []httphandler.HandlerOption{
+			httphandler.WithHealthDetail("inaturalist_quota", func() interface{} { return inatClient.Quota() }),
+			httphandler.WithHealthDetail("inaturalist_breaker", func() interface{} { return breaker.Status() }),
+			httphandler.WithHealthDetail("species_cache", func() interface{} { return speciesCache.Stats() }),
+			httphandler.WithHealthDetail("places_cache", func() interface{} { return placeCache.Stats() }),
+		},
+	}, nil
 }
 
-func newInMemoryPlayerRepository() *inMemoryPlayerRepository {
-	return &inMemoryPlayerRepository{
-		players: make(map[string]*gamification.Player),
-	}
+// playerStore persists players and ranks them by XP.
+type playerStore interface {
+	ports.PlayerRepository
+	ports.XPLeaderboard
 }
 
-func (r *inMemoryPlayerRepository) Create(_ context.Context, player *gamification.Player) error {
-	r.players[player.ID()] = player
-	return nil
+// repositories groups the persistence adapters used by the server.
+type repositories struct {
+	players  playerStore
+	sessions ports.QuizSessionRepository
+	scores   ports.ScoreLeaderboard
+	close    func()
 }
 
-func (r *inMemoryPlayerRepository) GetByID(_ context.Context, id string) (*gamification.Player, error) {
-	if p, ok := r.players[id]; ok {
-		return p, nil
+// newRepositories creates the persistence adapters.
+// An empty dbPath selects the in-memory implementations.
+func newRepositories(ctx context.Context, dbPath string) (*repositories, error) {
+	if dbPath == "" {
+		playerRepo := memory.NewPlayerRepository()
+		sessionRepo := memory.NewSessionRepository()
+		sessionRepo.StartEviction(ctx, sessionEvictionPeriod)
+		return &repositories{
+			players:  playerRepo,
+			sessions: sessionRepo,
+			scores:   memory.NewScoreLeaderboard(playerRepo),
+			close:    func() {},
+		}, nil
 	}
-	return nil, fmt.Errorf("player not found: %s", id)
-}
 
-func (r *inMemoryPlayerRepository) GetByUsername(_ context.Context, username string) (*gamification.Player, error) {
-	for _, p := range r.players {
-		if p.Username() == username {
-			return p, nil
-		}
+	db, err := sqlstore.Open(ctx, "sqlite", dbPath+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("player not found: %s", username)
+	return &repositories{
+		players:  sqlstore.NewPlayerRepository(db),
+		sessions: sqlstore.NewSessionRepository(db),
+		scores:   sqlstore.NewScoreLeaderboard(db),
+		close: func() {
+			if err := db.Close(); err != nil {
+				log.Printf("Failed to close database: %v", err)
+			}
+		},
+	}, nil
 }
 
-func (r *inMemoryPlayerRepository) Update(_ context.Context, player *gamification.Player) error {
-	r.players[player.ID()] = player
-	return nil
-}
+// ensureDemoPlayer creates the demo player unless it already exists.
+func ensureDemoPlayer(ctx context.Context, repo ports.PlayerRepository) error {
+	_, err := repo.GetByID(ctx, "demo")
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ports.ErrPlayerNotFound) {
+		return err
+	}
 
-func (r *inMemoryPlayerRepository) GetLeaderboard(_ context.Context, limit int) ([]*gamification.Player, error) {
-	result := make([]*gamification.Player, 0, limit)
-	for _, p := range r.players {
-		result = append(result, p)
-		if len(result) >= limit {
-			break
-		}
+	demoPlayer, err := gamification.NewPlayer("demo", "demo_user")
+	if err != nil {
+		return err
 	}
-	return result, nil
+	return repo.Create(ctx, demoPlayer)
 }
 
-// Ensure interface compliance
-var _ ports.PlayerRepository = (*inMemoryPlayerRepository)(nil)
+// corsMiddleware adds CORS headers for development.
+func corsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
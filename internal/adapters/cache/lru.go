@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"container/list"
+	"time"
+)
+
+// lru is a bounded map evicting the least recently used entry. It is not safe
+// for concurrent use.
+type lru[V any] struct {
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List // Front is the most recently used
+}
+
+// lruEntry is a cached value and its expiry.
+type lruEntry[V any] struct {
+	key     string
+	value   V
+	expires time.Time
+}
+
+// newLRU creates a cache holding at most capacity entries.
+func newLRU[V any](capacity int) *lru[V] {
+	return &lru[V]{
+		capacity: max(capacity, 1),
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// get returns the value of key unless it is missing or expired at now.
+func (c *lru[V]) get(key string, now time.Time) (V, bool) {
+	elem, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	entry := entryOf[V](elem)
+	if !now.Before(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.items, key)
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// add stores value under key until expires, evicting the oldest entry when full.
+func (c *lru[V]) add(key string, value V, expires time.Time) {
+	if elem, ok := c.items[key]; ok {
+		entry := entryOf[V](elem)
+		entry.value = value
+		entry.expires = expires
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry[V]{key: key, value: value, expires: expires})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, entryOf[V](oldest).key)
+	}
+}
+
+// len returns the number of entries, expired ones included.
+func (c *lru[V]) len() int {
+	return c.order.Len()
+}
+
+// entryOf returns the entry held by a list element.
+func entryOf[V any](elem *list.Element) *lruEntry[V] {
+	entry, _ := elem.Value.(*lruEntry[V]) // Only lruEntry values are stored
+	return entry
+}
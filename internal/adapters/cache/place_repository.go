@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/place"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Cached PlaceRepository methods.
+const (
+	MethodSearchPlaces Method = "search_places"
+	MethodGetPlace     Method = "get_place"
+)
+
+// Places and their boundaries hardly ever change.
+const (
+	defaultSearchPlacesTTL = 24 * time.Hour
+	defaultGetPlaceTTL     = 24 * time.Hour
+)
+
+// PlaceRepository is a PlaceRepository decorator caching results like
+// SpeciesRepository does. Cached places are shared between callers and must
+// not be modified.
+type PlaceRepository struct {
+	next ports.PlaceRepository
+
+	search *store[[]place.Place]
+	byID   *store[*place.Place]
+}
+
+// NewPlaceRepository wraps a repository with a cache.
+func NewPlaceRepository(next ports.PlaceRepository) *PlaceRepository {
+	return &PlaceRepository{
+		next:   next,
+		search: newStore[[]place.Place](defaultCapacity, defaultSearchPlacesTTL, time.Now),
+		byID:   newStore[*place.Place](defaultCapacity, defaultGetPlaceTTL, time.Now),
+	}
+}
+
+// SearchPlaces searches for places by name, ignoring case and surrounding spaces.
+func (r *PlaceRepository) SearchPlaces(ctx context.Context, query string, limit int) ([]place.Place, error) {
+	key := strings.ToLower(strings.TrimSpace(query)) + ":" + strconv.Itoa(limit)
+	return r.search.get(ctx, key, func() ([]place.Place, error) {
+		return r.next.SearchPlaces(ctx, query, limit)
+	})
+}
+
+// GetPlace retrieves a place by its ID.
+func (r *PlaceRepository) GetPlace(ctx context.Context, id int) (*place.Place, error) {
+	return r.byID.get(ctx, strconv.Itoa(id), func() (*place.Place, error) {
+		return r.next.GetPlace(ctx, id)
+	})
+}
+
+// Stats returns the hit and miss counters of each method.
+func (r *PlaceRepository) Stats() map[Method]Counters {
+	return map[Method]Counters{
+		MethodSearchPlaces: r.search.counters(),
+		MethodGetPlace:     r.byID.counters(),
+	}
+}
+
+// Ensure interface compliance
+var _ ports.PlaceRepository = (*PlaceRepository)(nil)
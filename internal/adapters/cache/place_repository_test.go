@@ -0,0 +1,70 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/cache"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/place"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// countingPlaces returns places built from the call arguments and counts calls.
+type countingPlaces struct {
+	calls atomic.Int32
+}
+
+func (c *countingPlaces) SearchPlaces(_ context.Context, _ string, limit int) ([]place.Place, error) {
+	c.calls.Add(1)
+	return make([]place.Place, limit), nil
+}
+
+func (c *countingPlaces) GetPlace(_ context.Context, id int) (*place.Place, error) {
+	c.calls.Add(1)
+	if id == 0 {
+		return nil, ports.ErrPlaceNotFound
+	}
+	return &place.Place{ID: id}, nil
+}
+
+func TestPlaceRepository_Caches(t *testing.T) {
+	next := &countingPlaces{}
+	repo := cache.NewPlaceRepository(next)
+	ctx := context.Background()
+
+	repo.SearchPlaces(ctx, "Isère", 5)
+	repo.SearchPlaces(ctx, " isère ", 5)
+	repo.SearchPlaces(ctx, "isère", 10)
+	for i := 0; i < 2; i++ {
+		if p, err := repo.GetPlace(ctx, 6906); err != nil || p.ID != 6906 {
+			t.Fatalf("GetPlace() = %v, %v", p, err)
+		}
+	}
+
+	if next.calls.Load() != 3 {
+		t.Errorf("repository calls = %d, want 3 (limit is part of the key)", next.calls.Load())
+	}
+	stats := repo.Stats()
+	if got := stats[cache.MethodSearchPlaces]; got.Hits != 1 || got.Misses != 2 {
+		t.Errorf("Stats()[search_places] = %+v, want 1 hit, 2 misses", got)
+	}
+	if got := stats[cache.MethodGetPlace]; got.Hits != 1 || got.Misses != 1 {
+		t.Errorf("Stats()[get_place] = %+v, want 1 hit, 1 miss", got)
+	}
+}
+
+func TestPlaceRepository_NotFoundIsNotCached(t *testing.T) {
+	next := &countingPlaces{}
+	repo := cache.NewPlaceRepository(next)
+
+	for i := 0; i < 2; i++ {
+		if _, err := repo.GetPlace(context.Background(), 0); !errors.Is(err, ports.ErrPlaceNotFound) {
+			t.Fatalf("GetPlace() error = %v, want ErrPlaceNotFound", err)
+		}
+	}
+	if next.calls.Load() != 2 {
+		t.Errorf("repository calls = %d, want 2", next.calls.Load())
+	}
+}
@@ -0,0 +1,249 @@
+// Package cache provides caching decorators for species and place repositories.
+package cache
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Method identifies a cached SpeciesRepository method.
+type Method string
+
+// Cached methods.
+const (
+	MethodGetByID       Method = "get_by_id"
+	MethodGetRandom     Method = "get_random"
+	MethodGetSimilar    Method = "get_similar"
+	MethodSearch        Method = "search"
+	MethodGetTaxa       Method = "get_taxa"
+	MethodSearchTaxa    Method = "search_taxa"
+	MethodGetLookalikes Method = "get_lookalikes"
+)
+
+// Default cache settings. Taxa barely change, random pools are renewed often
+// enough for players to meet new species.
+const (
+	defaultCapacity      = 1000
+	defaultPoolSize      = 100
+	defaultByIDTTL       = 24 * time.Hour
+	defaultSimilarTTL    = 24 * time.Hour
+	defaultSearchTTL     = time.Hour
+	defaultRandomTTL     = 10 * time.Minute
+	defaultTaxaTTL       = 24 * time.Hour
+	defaultLookalikesTTL = 24 * time.Hour
+)
+
+// SpeciesRepository is a SpeciesRepository decorator caching results in
+// bounded LRU stores with a TTL per method. Concurrent identical calls share a
+// single call to the wrapped repository. GetRandom fetches a pool of species
+// per filter and samples each answer from it. Cached species are shared
+// between callers and must not be modified.
+type SpeciesRepository struct {
+	next ports.SpeciesRepository
+
+	capacity int
+	poolSize int
+	ttls     map[Method]time.Duration
+	now      func() time.Time
+
+	byID       *store[*species.Species]
+	random     *store[[]*species.Species]
+	similar    *store[[]*species.Species]
+	search     *store[[]*species.Species]
+	taxa       *store[[]species.Taxon]
+	searchTaxa *store[[]species.Taxon]
+	lookalikes *store[[]*species.Species]
+}
+
+// Option configures the cache.
+type Option func(*SpeciesRepository)
+
+// WithCapacity sets the maximum number of entries cached per method.
+func WithCapacity(n int) Option {
+	return func(r *SpeciesRepository) {
+		if n > 0 {
+			r.capacity = n
+		}
+	}
+}
+
+// WithTTL sets how long results of method are cached. A non-positive TTL
+// disables caching of the method; identical calls are still shared.
+func WithTTL(method Method, ttl time.Duration) Option {
+	return func(r *SpeciesRepository) {
+		r.ttls[method] = ttl
+	}
+}
+
+// WithPoolSize sets how many species are fetched per GetRandom pool.
+func WithPoolSize(n int) Option {
+	return func(r *SpeciesRepository) {
+		if n > 0 {
+			r.poolSize = n
+		}
+	}
+}
+
+// WithClock sets the time source, mainly for tests.
+func WithClock(now func() time.Time) Option {
+	return func(r *SpeciesRepository) {
+		r.now = now
+	}
+}
+
+// NewSpeciesRepository wraps a repository with a cache.
+func NewSpeciesRepository(next ports.SpeciesRepository, opts ...Option) *SpeciesRepository {
+	r := &SpeciesRepository{
+		next:     next,
+		capacity: defaultCapacity,
+		poolSize: defaultPoolSize,
+		ttls: map[Method]time.Duration{
+			MethodGetByID:       defaultByIDTTL,
+			MethodGetRandom:     defaultRandomTTL,
+			MethodGetSimilar:    defaultSimilarTTL,
+			MethodSearch:        defaultSearchTTL,
+			MethodGetTaxa:       defaultTaxaTTL,
+			MethodSearchTaxa:    defaultSearchTTL,
+			MethodGetLookalikes: defaultLookalikesTTL,
+		},
+		now: time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	r.byID = newStore[*species.Species](r.capacity, r.ttls[MethodGetByID], r.now)
+	r.random = newStore[[]*species.Species](r.capacity, r.ttls[MethodGetRandom], r.now)
+	r.similar = newStore[[]*species.Species](r.capacity, r.ttls[MethodGetSimilar], r.now)
+	r.search = newStore[[]*species.Species](r.capacity, r.ttls[MethodSearch], r.now)
+	r.taxa = newStore[[]species.Taxon](r.capacity, r.ttls[MethodGetTaxa], r.now)
+	r.searchTaxa = newStore[[]species.Taxon](r.capacity, r.ttls[MethodSearchTaxa], r.now)
+	r.lookalikes = newStore[[]*species.Species](r.capacity, r.ttls[MethodGetLookalikes], r.now)
+	return r
+}
+
+// GetByID retrieves a species by its ID.
+func (r *SpeciesRepository) GetByID(ctx context.Context, id int) (*species.Species, error) {
+	return r.byID.get(ctx, strconv.Itoa(id), func() (*species.Species, error) {
+		return r.next.GetByID(ctx, id)
+	})
+}
+
+// GetRandom samples random species matching the filter from a cached pool.
+// Requests larger than the pool, or excluding the whole pool, go to the
+// wrapped repository.
+func (r *SpeciesRepository) GetRandom(ctx context.Context, filter ports.SpeciesFilter) ([]*species.Species, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = r.poolSize
+	}
+	if limit > r.poolSize {
+		return r.next.GetRandom(ctx, filter)
+	}
+
+	pool, err := r.random.get(ctx, poolKey(filter), func() ([]*species.Species, error) {
+		poolFilter := filter
+		poolFilter.Limit = r.poolSize
+		poolFilter.ExcludeIDs = nil
+		return r.next.GetRandom(ctx, poolFilter)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sample := samplePool(pool, filter.ExcludeIDs, limit)
+	if len(sample) == 0 {
+		return r.next.GetRandom(ctx, filter)
+	}
+	return sample, nil
+}
+
+// GetSimilar retrieves species similar to the given one.
+func (r *SpeciesRepository) GetSimilar(ctx context.Context, speciesID int, limit int) ([]*species.Species, error) {
+	key := strconv.Itoa(speciesID) + ":" + strconv.Itoa(limit)
+	return r.similar.get(ctx, key, func() ([]*species.Species, error) {
+		return r.next.GetSimilar(ctx, speciesID, limit)
+	})
+}
+
+// Search searches for species by name, ignoring case and surrounding spaces.
+func (r *SpeciesRepository) Search(ctx context.Context, query string, limit int) ([]*species.Species, error) {
+	key := strings.ToLower(strings.TrimSpace(query)) + ":" + strconv.Itoa(limit)
+	return r.search.get(ctx, key, func() ([]*species.Species, error) {
+		return r.next.Search(ctx, query, limit)
+	})
+}
+
+// GetTaxa retrieves taxa by ID.
+func (r *SpeciesRepository) GetTaxa(ctx context.Context, ids []int) ([]species.Taxon, error) {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = strconv.Itoa(id)
+	}
+	return r.taxa.get(ctx, strings.Join(keys, ","), func() ([]species.Taxon, error) {
+		return r.next.GetTaxa(ctx, ids)
+	})
+}
+
+// SearchTaxa searches for taxa by name, ignoring case and surrounding spaces.
+func (r *SpeciesRepository) SearchTaxa(ctx context.Context, query string, limit int) ([]species.Taxon, error) {
+	key := strings.ToLower(strings.TrimSpace(query)) + ":" + strconv.Itoa(limit)
+	return r.searchTaxa.get(ctx, key, func() ([]species.Taxon, error) {
+		return r.next.SearchTaxa(ctx, query, limit)
+	})
+}
+
+// GetLookalikes retrieves the species most often confused with the given one.
+func (r *SpeciesRepository) GetLookalikes(ctx context.Context, speciesID int, limit int) ([]*species.Species, error) {
+	key := strconv.Itoa(speciesID) + ":" + strconv.Itoa(limit)
+	return r.lookalikes.get(ctx, key, func() ([]*species.Species, error) {
+		return r.next.GetLookalikes(ctx, speciesID, limit)
+	})
+}
+
+// Stats returns the hit and miss counters of each method.
+func (r *SpeciesRepository) Stats() map[Method]Counters {
+	return map[Method]Counters{
+		MethodGetByID:       r.byID.counters(),
+		MethodGetRandom:     r.random.counters(),
+		MethodGetSimilar:    r.similar.counters(),
+		MethodSearch:        r.search.counters(),
+		MethodGetTaxa:       r.taxa.counters(),
+		MethodSearchTaxa:    r.searchTaxa.counters(),
+		MethodGetLookalikes: r.lookalikes.counters(),
+	}
+}
+
+// poolKey identifies the pool of a filter: every criterion but the limit and
+// the exclusions, which are applied when sampling.
+func poolKey(filter ports.SpeciesFilter) string {
+	filter.Limit = 0
+	filter.ExcludeIDs = nil
+	return fmt.Sprintf("%+v", filter)
+}
+
+// samplePool returns up to limit species of the pool in random order, without
+// the excluded taxa and their descendants.
+func samplePool(pool []*species.Species, excludeIDs []int, limit int) []*species.Species {
+	candidates := make([]*species.Species, 0, len(pool))
+	for _, sp := range pool {
+		if !slices.ContainsFunc(excludeIDs, sp.HasAncestor) {
+			candidates = append(candidates, sp)
+		}
+	}
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	return candidates[:min(limit, len(candidates))]
+}
+
+// Ensure interface compliance
+var _ ports.SpeciesRepository = (*SpeciesRepository)(nil)
@@ -0,0 +1,441 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/cache"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// countingRepository returns species built from the call arguments and counts calls.
+type countingRepository struct {
+	calls   atomic.Int32
+	err     error
+	release chan struct{} // Blocks calls until closed when set
+	filters chan ports.SpeciesFilter
+}
+
+func (c *countingRepository) call() error {
+	c.calls.Add(1)
+	if c.release != nil {
+		<-c.release
+	}
+	return c.err
+}
+
+// newSpecies builds a species of the genus 1000 when id is even, 1001 otherwise.
+func newSpecies(id int) *species.Species {
+	sp, _ := species.New(id, "Species", "Espece", "Aves")
+	sp.SetAncestorIDs([]int{1000 + id%2, id})
+	return sp
+}
+
+func speciesRange(from, to int) []*species.Species {
+	list := make([]*species.Species, 0, to-from)
+	for id := from; id < to; id++ {
+		list = append(list, newSpecies(id))
+	}
+	return list
+}
+
+func (c *countingRepository) GetByID(_ context.Context, id int) (*species.Species, error) {
+	if err := c.call(); err != nil {
+		return nil, err
+	}
+	return newSpecies(id), nil
+}
+
+func (c *countingRepository) GetRandom(_ context.Context, filter ports.SpeciesFilter) ([]*species.Species, error) {
+	if c.filters != nil {
+		c.filters <- filter
+	}
+	if err := c.call(); err != nil {
+		return nil, err
+	}
+	return speciesRange(1, filter.Limit+1), nil
+}
+
+func (c *countingRepository) GetSimilar(_ context.Context, speciesID int, limit int) ([]*species.Species, error) {
+	if err := c.call(); err != nil {
+		return nil, err
+	}
+	return speciesRange(speciesID+1, speciesID+1+limit), nil
+}
+
+func (c *countingRepository) Search(_ context.Context, _ string, limit int) ([]*species.Species, error) {
+	if err := c.call(); err != nil {
+		return nil, err
+	}
+	return speciesRange(1, limit+1), nil
+}
+
+func (c *countingRepository) GetTaxa(_ context.Context, ids []int) ([]species.Taxon, error) {
+	if err := c.call(); err != nil {
+		return nil, err
+	}
+	taxa := make([]species.Taxon, len(ids))
+	for i, id := range ids {
+		taxa[i] = species.Taxon{ID: id, Name: "Taxon", Rank: species.RankGenus}
+	}
+	return taxa, nil
+}
+
+func (c *countingRepository) SearchTaxa(_ context.Context, query string, _ int) ([]species.Taxon, error) {
+	if err := c.call(); err != nil {
+		return nil, err
+	}
+	return []species.Taxon{{ID: 47217, Name: query, Rank: species.RankFamily}}, nil
+}
+
+func (c *countingRepository) GetLookalikes(_ context.Context, speciesID int, limit int) ([]*species.Species, error) {
+	if err := c.call(); err != nil {
+		return nil, err
+	}
+	return speciesRange(speciesID+100, speciesID+100+limit), nil
+}
+
+// fakeClock is a manually advanced time source.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func TestSpeciesRepository_CachesByMethod(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		method cache.Method
+		call   func(r *cache.SpeciesRepository) error
+	}{
+		{"GetByID", cache.MethodGetByID, func(r *cache.SpeciesRepository) error {
+			_, err := r.GetByID(ctx, 42)
+			return err
+		}},
+		{"GetSimilar", cache.MethodGetSimilar, func(r *cache.SpeciesRepository) error {
+			_, err := r.GetSimilar(ctx, 42, 5)
+			return err
+		}},
+		{"Search", cache.MethodSearch, func(r *cache.SpeciesRepository) error {
+			_, err := r.Search(ctx, "renard", 5)
+			return err
+		}},
+		{"GetTaxa", cache.MethodGetTaxa, func(r *cache.SpeciesRepository) error {
+			_, err := r.GetTaxa(ctx, []int{1, 42})
+			return err
+		}},
+		{"SearchTaxa", cache.MethodSearchTaxa, func(r *cache.SpeciesRepository) error {
+			_, err := r.SearchTaxa(ctx, "Orchidaceae", 5)
+			return err
+		}},
+		{"GetLookalikes", cache.MethodGetLookalikes, func(r *cache.SpeciesRepository) error {
+			_, err := r.GetLookalikes(ctx, 42, 5)
+			return err
+		}},
+		{"GetRandom", cache.MethodGetRandom, func(r *cache.SpeciesRepository) error {
+			_, err := r.GetRandom(ctx, ports.SpeciesFilter{IconicTaxon: "Aves", Limit: 1})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &countingRepository{}
+			clock := newClock()
+			repo := cache.NewSpeciesRepository(next, cache.WithClock(clock.Now), cache.WithTTL(tt.method, time.Hour))
+
+			for i := 0; i < 3; i++ {
+				if err := tt.call(repo); err != nil {
+					t.Fatalf("call %d error = %v", i, err)
+				}
+			}
+			if next.calls.Load() != 1 {
+				t.Errorf("repository calls = %d, want 1", next.calls.Load())
+			}
+
+			clock.Advance(time.Hour)
+			if err := tt.call(repo); err != nil {
+				t.Fatalf("call after expiry error = %v", err)
+			}
+			if next.calls.Load() != 2 {
+				t.Errorf("repository calls after expiry = %d, want 2", next.calls.Load())
+			}
+
+			counters := repo.Stats()[tt.method]
+			if counters.Hits != 2 || counters.Misses != 2 || counters.Entries != 1 {
+				t.Errorf("Stats() = %+v, want 2 hits, 2 misses, 1 entry", counters)
+			}
+		})
+	}
+}
+
+func TestSpeciesRepository_SearchKeyIgnoresCase(t *testing.T) {
+	next := &countingRepository{}
+	repo := cache.NewSpeciesRepository(next)
+
+	repo.Search(context.Background(), "Renard", 5)
+	repo.Search(context.Background(), " renard ", 5)
+	repo.Search(context.Background(), "renard", 10)
+
+	if next.calls.Load() != 2 {
+		t.Errorf("repository calls = %d, want 2 (limit is part of the key)", next.calls.Load())
+	}
+}
+
+func TestSpeciesRepository_EvictsLeastRecentlyUsed(t *testing.T) {
+	next := &countingRepository{}
+	repo := cache.NewSpeciesRepository(next, cache.WithCapacity(2))
+	ctx := context.Background()
+
+	repo.GetByID(ctx, 1)
+	repo.GetByID(ctx, 2)
+	repo.GetByID(ctx, 1) // 2 is now the least recently used
+	repo.GetByID(ctx, 3) // Evicts 2
+	repo.GetByID(ctx, 1)
+	repo.GetByID(ctx, 2)
+
+	if next.calls.Load() != 4 {
+		t.Errorf("repository calls = %d, want 4", next.calls.Load())
+	}
+	if entries := repo.Stats()[cache.MethodGetByID].Entries; entries != 2 {
+		t.Errorf("entries = %d, want 2", entries)
+	}
+}
+
+func TestSpeciesRepository_ErrorsAreNotCached(t *testing.T) {
+	next := &countingRepository{err: errors.New("upstream down")}
+	repo := cache.NewSpeciesRepository(next)
+
+	for i := 0; i < 2; i++ {
+		if _, err := repo.GetByID(context.Background(), 1); err == nil {
+			t.Fatal("GetByID() error = nil, want upstream error")
+		}
+	}
+	if next.calls.Load() != 2 {
+		t.Errorf("repository calls = %d, want 2", next.calls.Load())
+	}
+}
+
+func TestSpeciesRepository_DisabledTTL(t *testing.T) {
+	next := &countingRepository{}
+	repo := cache.NewSpeciesRepository(next, cache.WithTTL(cache.MethodGetByID, 0))
+
+	repo.GetByID(context.Background(), 1)
+	repo.GetByID(context.Background(), 1)
+
+	if next.calls.Load() != 2 {
+		t.Errorf("repository calls = %d, want 2 with caching disabled", next.calls.Load())
+	}
+}
+
+func TestSpeciesRepository_SharesConcurrentCalls(t *testing.T) {
+	next := &countingRepository{release: make(chan struct{})}
+	repo := cache.NewSpeciesRepository(next)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make([]*species.Species, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = repo.GetByID(context.Background(), 7)
+		}(i)
+	}
+
+	// Release the single upstream call once every caller waits for it
+	for repo.Stats()[cache.MethodGetByID].Shared < callers-1 {
+		time.Sleep(time.Millisecond)
+	}
+	close(next.release)
+	wg.Wait()
+
+	if next.calls.Load() != 1 {
+		t.Errorf("repository calls = %d, want 1", next.calls.Load())
+	}
+	for i, sp := range results {
+		if sp == nil || sp.ID() != 7 {
+			t.Errorf("result %d = %v, want species 7", i, sp)
+		}
+	}
+}
+
+// reservingRepository refuses low-priority lookups once released, as a
+// repository keeping its remaining quota for players does.
+type reservingRepository struct {
+	countingRepository
+}
+
+var errReserved = errors.New("quota reserved")
+
+func (r *reservingRepository) GetByID(ctx context.Context, id int) (*species.Species, error) {
+	if ports.PriorityFrom(ctx) == ports.PriorityLow {
+		r.call()
+		return nil, errReserved
+	}
+	return r.countingRepository.GetByID(ctx, id)
+}
+
+func TestSpeciesRepository_CallsSharedPerPriority(t *testing.T) {
+	next := &reservingRepository{countingRepository{release: make(chan struct{})}}
+	repo := cache.NewSpeciesRepository(next)
+
+	lowDone := make(chan error)
+	go func() {
+		_, err := repo.GetByID(ports.WithPriority(context.Background(), ports.PriorityLow), 7)
+		lowDone <- err
+	}()
+	for next.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// A player does not wait for, nor inherit, the refusal of background work
+	highDone := make(chan error)
+	go func() {
+		_, err := repo.GetByID(context.Background(), 7)
+		highDone <- err
+	}()
+	for deadline := time.Now().Add(time.Second); next.calls.Load() < 2 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	close(next.release)
+
+	if err := <-lowDone; !errors.Is(err, errReserved) {
+		t.Errorf("low priority GetByID() error = %v, want the refusal", err)
+	}
+	if err := <-highDone; err != nil {
+		t.Errorf("high priority GetByID() error = %v, want none", err)
+	}
+	if next.calls.Load() != 2 {
+		t.Errorf("repository calls = %d, want one per priority", next.calls.Load())
+	}
+}
+
+func TestSpeciesRepository_SharedCallerCancellation(t *testing.T) {
+	next := &countingRepository{release: make(chan struct{})}
+	repo := cache.NewSpeciesRepository(next)
+
+	leaderDone := make(chan struct{})
+	go func() {
+		defer close(leaderDone)
+		repo.GetByID(context.Background(), 7)
+	}()
+	for next.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := repo.GetByID(ctx, 7); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetByID() error = %v, want context.Canceled", err)
+	}
+	close(next.release)
+	<-leaderDone
+}
+
+func TestSpeciesRepository_GetRandomSamplesPool(t *testing.T) {
+	next := &countingRepository{filters: make(chan ports.SpeciesFilter, 10)}
+	repo := cache.NewSpeciesRepository(next, cache.WithPoolSize(20))
+	ctx := context.Background()
+	filter := ports.SpeciesFilter{IconicTaxon: "Aves", Limit: 5, ExcludeIDs: []int{1, 2}}
+
+	seen := make(map[int]bool)
+	for i := 0; i < 20; i++ {
+		got, err := repo.GetRandom(ctx, filter)
+		if err != nil {
+			t.Fatalf("GetRandom() error = %v", err)
+		}
+		if len(got) != 5 {
+			t.Fatalf("GetRandom() returned %d species, want 5", len(got))
+		}
+		for _, sp := range got {
+			if sp.ID() == 1 || sp.ID() == 2 {
+				t.Fatalf("GetRandom() returned excluded species %d", sp.ID())
+			}
+			seen[sp.ID()] = true
+		}
+	}
+
+	if next.calls.Load() != 1 {
+		t.Errorf("repository calls = %d, want 1", next.calls.Load())
+	}
+	if poolFilter := <-next.filters; poolFilter.Limit != 20 || len(poolFilter.ExcludeIDs) != 0 {
+		t.Errorf("pool filter = %+v, want limit 20 without exclusions", poolFilter)
+	}
+	if len(seen) <= 5 {
+		t.Errorf("sampled %d distinct species, want answers drawn from the whole pool", len(seen))
+	}
+}
+
+func TestSpeciesRepository_GetRandomExcludesDescendants(t *testing.T) {
+	repo := cache.NewSpeciesRepository(&countingRepository{}, cache.WithPoolSize(20))
+
+	got, err := repo.GetRandom(context.Background(), ports.SpeciesFilter{Limit: 20, ExcludeIDs: []int{1000}})
+	if err != nil {
+		t.Fatalf("GetRandom() error = %v", err)
+	}
+	if len(got) != 10 {
+		t.Errorf("GetRandom() returned %d species, want the 10 outside the excluded genus", len(got))
+	}
+	for _, sp := range got {
+		if sp.ID()%2 == 0 {
+			t.Errorf("GetRandom() returned species %d of the excluded genus", sp.ID())
+		}
+	}
+}
+
+func TestSpeciesRepository_GetRandomPoolPerFilter(t *testing.T) {
+	next := &countingRepository{}
+	repo := cache.NewSpeciesRepository(next)
+	ctx := context.Background()
+
+	repo.GetRandom(ctx, ports.SpeciesFilter{IconicTaxon: "Aves", Limit: 1})
+	repo.GetRandom(ctx, ports.SpeciesFilter{IconicTaxon: "Aves", Limit: 3, ExcludeIDs: []int{4}})
+	repo.GetRandom(ctx, ports.SpeciesFilter{IconicTaxon: "Mammalia", Limit: 1})
+	repo.GetRandom(ctx, ports.SpeciesFilter{IconicTaxon: "Aves", PlaceID: 6753, Limit: 1})
+
+	if next.calls.Load() != 3 {
+		t.Errorf("repository calls = %d, want one pool per taxon and place", next.calls.Load())
+	}
+}
+
+func TestSpeciesRepository_GetRandomBypassesPool(t *testing.T) {
+	next := &countingRepository{}
+	repo := cache.NewSpeciesRepository(next, cache.WithPoolSize(3))
+	ctx := context.Background()
+
+	// Larger than the pool
+	if got, _ := repo.GetRandom(ctx, ports.SpeciesFilter{Limit: 10}); len(got) != 10 {
+		t.Errorf("GetRandom() returned %d species, want 10", len(got))
+	}
+	// Every pooled species excluded
+	got, _ := repo.GetRandom(ctx, ports.SpeciesFilter{Limit: 1, ExcludeIDs: []int{1, 2, 3}})
+	if len(got) != 1 {
+		t.Errorf("GetRandom() returned %d species, want 1", len(got))
+	}
+
+	if next.calls.Load() != 3 {
+		t.Errorf("repository calls = %d, want 3 (bypass, pool, fallback)", next.calls.Load())
+	}
+}
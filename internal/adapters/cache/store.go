@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Counters reports the activity of one cached method.
+type Counters struct {
+	Hits    uint64 `json:"hits"`    // Calls answered from the cache
+	Misses  uint64 `json:"misses"`  // Calls that went to the repository
+	Shared  uint64 `json:"shared"`  // Calls that waited for an identical call in flight
+	Entries int    `json:"entries"` // Cached entries, expired ones included
+}
+
+// store caches the results of one method, loading each missing key once even
+// when it is requested concurrently. Calls only share a load of their own
+// priority, so a refusal meant for background work never reaches a player.
+type store[V any] struct {
+	ttl time.Duration // Non-positive disables caching
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries *lru[V]
+	flights map[string]*flight[V] // By key and priority
+	stats   Counters
+}
+
+// flight is a load in progress, shared by identical calls.
+type flight[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// newStore creates a store of at most capacity entries kept for ttl.
+func newStore[V any](capacity int, ttl time.Duration, now func() time.Time) *store[V] {
+	return &store[V]{
+		ttl:     ttl,
+		now:     now,
+		entries: newLRU[V](capacity),
+		flights: make(map[string]*flight[V]),
+	}
+}
+
+// get returns the cached value of key, or loads it. Errors are not cached.
+func (s *store[V]) get(ctx context.Context, key string, load func() (V, error)) (V, error) {
+	flightKey := key + "#" + strconv.Itoa(int(ports.PriorityFrom(ctx)))
+	for {
+		f, leader, value, ok := s.lookup(key, flightKey)
+		if ok {
+			return value, nil
+		}
+		if leader {
+			return s.run(key, flightKey, f, load)
+		}
+
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			var zero V
+			return zero, ctx.Err()
+		}
+		// The leader gave up: the key is still worth loading for this caller
+		if isContextError(f.err) && ctx.Err() == nil {
+			continue
+		}
+		return f.value, f.err
+	}
+}
+
+// lookup returns the cached value of key, or the flight loading it and whether
+// the caller leads it.
+func (s *store[V]) lookup(key, flightKey string) (f *flight[V], leader bool, value V, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ttl > 0 {
+		if value, ok := s.entries.get(key, s.now()); ok {
+			s.stats.Hits++
+			return nil, false, value, true
+		}
+	}
+	if f, ok := s.flights[flightKey]; ok {
+		s.stats.Shared++
+		return f, false, value, false
+	}
+
+	s.stats.Misses++
+	f = &flight[V]{done: make(chan struct{})}
+	s.flights[flightKey] = f
+	return f, true, value, false
+}
+
+// run loads key for the flight f and caches the result.
+func (s *store[V]) run(key, flightKey string, f *flight[V], load func() (V, error)) (V, error) {
+	defer close(f.done)
+	f.value, f.err = load()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.flights, flightKey)
+	if f.err == nil && s.ttl > 0 {
+		s.entries.add(key, f.value, s.now().Add(s.ttl))
+	}
+	return f.value, f.err
+}
+
+// counters returns the activity of the store.
+func (s *store[V]) counters() Counters {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.stats
+	stats.Entries = s.entries.len()
+	return stats
+}
+
+// isContextError reports whether err comes from a canceled or expired context.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,115 @@
+// Package catalog provides an offline species catalog: a versioned JSON file
+// crawled from iNaturalist, and a SpeciesRepository serving it.
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+)
+
+// Version is the catalog format written by this package.
+const Version = 1
+
+// ErrInvalidCatalog is returned when a catalog file cannot be used.
+var ErrInvalidCatalog = errors.New("invalid species catalog")
+
+// Catalog is the content of a catalog file.
+type Catalog struct {
+	Version   int             `json:"version"`
+	CreatedAt time.Time       `json:"created_at"`
+	PlaceID   int             `json:"place_id,omitempty"` // Place the observations were crawled in
+	Taxa      []string        `json:"taxa,omitempty"`     // Iconic taxa crawled, all when empty
+	Species   []Entry         `json:"species"`
+	Ancestors []species.Taxon `json:"ancestors,omitempty"` // Higher taxa, such as genera and orders
+}
+
+// Entry is a species of the catalog.
+type Entry struct {
+	species.Snapshot
+	Observed     bool  `json:"observed"` // Seen in crawled observations, so usable as an answer
+	LookalikeIDs []int `json:"lookalike_ids,omitempty"`
+	ConfusedIDs  []int `json:"confused_ids,omitempty"` // Most often confused with it by identifiers
+}
+
+// Load reads and validates a catalog file.
+func Load(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading catalog: %w", err)
+	}
+
+	var c Catalog
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCatalog, err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Save writes the catalog to path, replacing any previous file only once
+// the new one is complete.
+func (c *Catalog) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding catalog: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating catalog: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }() // Error ignored: gone once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close() // Error ignored: we're already returning an error
+		return fmt.Errorf("writing catalog: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing catalog: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replacing catalog: %w", err)
+	}
+	return nil
+}
+
+// Validate checks the version, that species are valid and unique, and that
+// lookalikes and confused species link to catalog species.
+func (c *Catalog) Validate() error {
+	if c.Version != Version {
+		return fmt.Errorf("%w: unsupported version %d, want %d", ErrInvalidCatalog, c.Version, Version)
+	}
+
+	ids := make(map[int]bool, len(c.Species))
+	for _, entry := range c.Species {
+		if _, err := species.Restore(entry.Snapshot); err != nil {
+			return fmt.Errorf("%w: species %d: %w", ErrInvalidCatalog, entry.ID, err)
+		}
+		if ids[entry.ID] {
+			return fmt.Errorf("%w: duplicate species %d", ErrInvalidCatalog, entry.ID)
+		}
+		ids[entry.ID] = true
+	}
+
+	for _, entry := range c.Species {
+		for _, id := range entry.LookalikeIDs {
+			if !ids[id] || id == entry.ID {
+				return fmt.Errorf("%w: species %d: invalid lookalike %d", ErrInvalidCatalog, entry.ID, id)
+			}
+		}
+		for _, id := range entry.ConfusedIDs {
+			if !ids[id] || id == entry.ID {
+				return fmt.Errorf("%w: species %d: invalid confused species %d", ErrInvalidCatalog, entry.ID, id)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package catalog_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/catalog"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+)
+
+// entry builds a catalog entry of the genus parent with one photo when observed.
+func entry(id int, name, common, taxon string, parent int, observed bool, lookalikes ...int) catalog.Entry {
+	e := catalog.Entry{
+		Snapshot: species.Snapshot{
+			ID:             id,
+			ScientificName: name,
+			CommonName:     common,
+			IconicTaxon:    taxon,
+			Rank:           "species",
+			AncestorIDs:    []int{1, parent, id},
+		},
+		Observed:     observed,
+		LookalikeIDs: lookalikes,
+	}
+	if observed {
+		e.Photos = []species.Photo{{ID: id, MediumURL: "https://example.com/photo.jpg"}}
+	}
+	return e
+}
+
+// testCatalog has three Vulpes, two observed, and an observed bird.
+// Vulpes lagopus is mostly confused with Vulpes zerda; only the bird has a song.
+func testCatalog() *catalog.Catalog {
+	c := &catalog.Catalog{
+		Version:   catalog.Version,
+		CreatedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		PlaceID:   6753,
+		Species: []catalog.Entry{
+			entry(10, "Vulpes vulpes", "Renard roux", "Mammalia", 100, true, 12),
+			entry(11, "Vulpes lagopus", "Renard polaire", "Mammalia", 100, true),
+			entry(12, "Vulpes zerda", "Fennec", "Mammalia", 100, false),
+			entry(20, "Erithacus rubecula", "Rougegorge familier", "Aves", 200, true),
+		},
+		Ancestors: []species.Taxon{{ID: 100, Name: "Vulpes", Rank: species.RankGenus}},
+	}
+	c.Species[1].ConfusedIDs = []int{12, 10}
+	c.Species[3].Sounds = []species.Sound{{ID: 20, FileURL: "https://example.com/song.mp3"}}
+	c.Species[3].Photos[0].LicenseCode = "cc-by"
+	return c
+}
+
+func TestCatalog_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	if err := testCatalog().Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := catalog.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.PlaceID != 6753 || len(loaded.Species) != 4 || len(loaded.Ancestors) != 1 {
+		t.Errorf("Load() = place %d, %d species, %d ancestors, want 6753, 4, 1",
+			loaded.PlaceID, len(loaded.Species), len(loaded.Ancestors))
+	}
+	fox := loaded.Species[0]
+	if fox.ScientificName != "Vulpes vulpes" || !fox.Observed || len(fox.Photos) != 1 || fox.LookalikeIDs[0] != 12 {
+		t.Errorf("Load() first species = %+v", fox)
+	}
+
+	files, _ := os.ReadDir(filepath.Dir(path))
+	if len(files) != 1 {
+		t.Errorf("directory holds %d files, want only the catalog", len(files))
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *catalog.Catalog)
+	}{
+		{"unsupported version", func(c *catalog.Catalog) { c.Version = 99 }},
+		{"invalid species", func(c *catalog.Catalog) { c.Species[0].ScientificName = "" }},
+		{"duplicate species", func(c *catalog.Catalog) { c.Species[1].ID = 10 }},
+		{"unknown lookalike", func(c *catalog.Catalog) { c.Species[0].LookalikeIDs = []int{99} }},
+		{"self lookalike", func(c *catalog.Catalog) { c.Species[0].LookalikeIDs = []int{10} }},
+		{"unknown confused species", func(c *catalog.Catalog) { c.Species[1].ConfusedIDs = []int{99} }},
+		{"self confused species", func(c *catalog.Catalog) { c.Species[1].ConfusedIDs = []int{11} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCatalog()
+			tt.modify(c)
+			path := filepath.Join(t.TempDir(), "catalog.json")
+			if err := c.Save(path); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			if _, err := catalog.Load(path); !errors.Is(err, catalog.ErrInvalidCatalog) {
+				t.Errorf("Load() error = %v, want ErrInvalidCatalog", err)
+			}
+		})
+	}
+}
+
+func TestLoad_Malformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := catalog.Load(path); !errors.Is(err, catalog.ErrInvalidCatalog) {
+		t.Errorf("Load() error = %v, want ErrInvalidCatalog", err)
+	}
+	if _, err := catalog.Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Load() of a missing file error = nil")
+	}
+}
@@ -0,0 +1,343 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Default crawl settings.
+const (
+	defaultPages            = 5
+	defaultPhotosPerSpecies = 5
+	defaultLookalikes       = 10
+	observationsPerPage     = 200
+)
+
+// Source is the upstream a catalog is crawled from, implemented by
+// inaturalist.Client.
+type Source interface {
+	// ListObservedSpecies returns the species of a page of observations with photos.
+	ListObservedSpecies(ctx context.Context, filter ports.SpeciesFilter, page int) ([]*species.Species, error)
+
+	// GetTaxa retrieves taxa of any rank by ID.
+	GetTaxa(ctx context.Context, ids []int) ([]species.Taxon, error)
+
+	// GetSpeciesInTaxon retrieves species descending from a taxon.
+	GetSpeciesInTaxon(ctx context.Context, taxonID int, limit int) ([]*species.Species, error)
+
+	// GetLookalikes retrieves the species most often confused with the given one.
+	GetLookalikes(ctx context.Context, speciesID int, limit int) ([]*species.Species, error)
+}
+
+// Crawler builds a catalog from observations of a place.
+type Crawler struct {
+	source           Source
+	placeID          int
+	taxa             []string
+	pages            int
+	photosPerSpecies int
+	lookalikes       int
+	logf             func(format string, args ...interface{})
+
+	entries map[int]*Entry
+	parents map[int]int // Closest ancestor of each species
+}
+
+// CrawlerOption configures the crawler.
+type CrawlerOption func(*Crawler)
+
+// WithPlace restricts observations to a place.
+func WithPlace(placeID int) CrawlerOption {
+	return func(c *Crawler) {
+		c.placeID = placeID
+	}
+}
+
+// WithTaxa restricts observations to iconic taxa, crawled one after the other.
+func WithTaxa(taxa ...string) CrawlerOption {
+	return func(c *Crawler) {
+		c.taxa = taxa
+	}
+}
+
+// WithPages sets how many pages of 200 observations are crawled per taxon.
+func WithPages(n int) CrawlerOption {
+	return func(c *Crawler) {
+		if n > 0 {
+			c.pages = n
+		}
+	}
+}
+
+// WithPhotosPerSpecies sets how many photos, and how many sounds, are kept per species.
+func WithPhotosPerSpecies(n int) CrawlerOption {
+	return func(c *Crawler) {
+		if n > 0 {
+			c.photosPerSpecies = n
+		}
+	}
+}
+
+// WithLookalikes sets how many lookalikes, and how many confused species, are
+// linked per species.
+func WithLookalikes(n int) CrawlerOption {
+	return func(c *Crawler) {
+		if n >= 0 {
+			c.lookalikes = n
+		}
+	}
+}
+
+// WithLogf sets where crawl progress is reported.
+func WithLogf(logf func(format string, args ...interface{})) CrawlerOption {
+	return func(c *Crawler) {
+		c.logf = logf
+	}
+}
+
+// NewCrawler creates a crawler reading from source.
+func NewCrawler(source Source, opts ...CrawlerOption) *Crawler {
+	c := &Crawler{
+		source:           source,
+		pages:            defaultPages,
+		photosPerSpecies: defaultPhotosPerSpecies,
+		lookalikes:       defaultLookalikes,
+		logf:             func(string, ...interface{}) {},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Crawl collects observed species with their photos, links lookalikes from
+// their genus and the species they are confused with, and fetches the
+// ancestors of every species.
+func (c *Crawler) Crawl(ctx context.Context) (*Catalog, error) {
+	c.entries = make(map[int]*Entry)
+	c.parents = make(map[int]int)
+
+	taxa := c.taxa
+	if len(taxa) == 0 {
+		taxa = []string{""} // All taxa
+	}
+	for _, taxon := range taxa {
+		if err := c.crawlObservations(ctx, taxon); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.linkLookalikes(ctx); err != nil {
+		return nil, err
+	}
+	if err := c.linkConfused(ctx); err != nil {
+		return nil, err
+	}
+	ancestors, err := c.fetchAncestors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := &Catalog{
+		Version:   Version,
+		CreatedAt: time.Now().UTC(),
+		PlaceID:   c.placeID,
+		Taxa:      c.taxa,
+		Species:   make([]Entry, 0, len(c.entries)),
+		Ancestors: ancestors,
+	}
+	for _, entry := range c.entries {
+		catalog.Species = append(catalog.Species, *entry)
+	}
+	sort.Slice(catalog.Species, func(i, j int) bool { return catalog.Species[i].ID < catalog.Species[j].ID })
+	return catalog, nil
+}
+
+// crawlObservations adds the species observed in the pages of a taxon.
+func (c *Crawler) crawlObservations(ctx context.Context, taxon string) error {
+	filter := ports.SpeciesFilter{IconicTaxon: taxon, PlaceID: c.placeID, Limit: observationsPerPage, HasPhotos: true}
+	for page := 1; page <= c.pages; page++ {
+		observed, err := c.source.ListObservedSpecies(ctx, filter, page)
+		if err != nil {
+			return fmt.Errorf("crawling observations of %q, page %d: %w", taxon, page, err)
+		}
+		if len(observed) == 0 {
+			break
+		}
+		for _, sp := range observed {
+			c.add(sp, true)
+		}
+		c.logf("observations of %q, page %d: %d species in catalog", taxon, page, len(c.entries))
+	}
+	return nil
+}
+
+// add stores a species, merging its photos and sounds with those already known.
+func (c *Crawler) add(sp *species.Species, observed bool) {
+	entry, ok := c.entries[sp.ID()]
+	if !ok {
+		snap := sp.Snapshot()
+		snap.Photos = nil
+		snap.Sounds = nil
+		entry = &Entry{Snapshot: snap}
+		c.entries[sp.ID()] = entry
+		c.parents[sp.ID()] = sp.ParentID()
+	}
+	entry.Observed = entry.Observed || observed
+
+	for _, photo := range sp.Photos() {
+		if len(entry.Photos) >= c.photosPerSpecies {
+			break
+		}
+		if !hasPhoto(entry.Photos, photo.ID) {
+			entry.Photos = append(entry.Photos, photo)
+		}
+	}
+	for _, sound := range sp.Sounds() {
+		if len(entry.Sounds) >= c.photosPerSpecies {
+			break
+		}
+		if !hasSound(entry.Sounds, sound.ID) {
+			entry.Sounds = append(entry.Sounds, sound)
+		}
+	}
+}
+
+// linkLookalikes links each observed species to other species of its genus,
+// observed ones first. Missing species of the genus are added unobserved.
+func (c *Crawler) linkLookalikes(ctx context.Context) error {
+	if c.lookalikes == 0 {
+		return nil
+	}
+
+	byParent := make(map[int][]int)
+	for id, entry := range c.entries {
+		if parent := c.parents[id]; entry.Observed && parent != 0 {
+			byParent[parent] = append(byParent[parent], id)
+		}
+	}
+
+	for parent, observedIDs := range byParent {
+		relatives, err := c.source.GetSpeciesInTaxon(ctx, parent, c.lookalikes+1)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			c.logf("skipping lookalikes of taxon %d: %v", parent, err)
+		}
+
+		sort.Ints(observedIDs)
+		group := append([]int(nil), observedIDs...)
+		for _, sp := range relatives {
+			if _, known := c.entries[sp.ID()]; !known {
+				group = append(group, sp.ID())
+			}
+			c.add(sp, false)
+		}
+		for _, id := range observedIDs {
+			c.entries[id].LookalikeIDs = others(group, id, c.lookalikes)
+		}
+	}
+	c.logf("lookalikes linked for %d genera", len(byParent))
+	return nil
+}
+
+// linkConfused links each observed species to the species identifiers most
+// often confuse it with, one request per species. Missing species are added
+// unobserved.
+func (c *Crawler) linkConfused(ctx context.Context) error {
+	if c.lookalikes == 0 {
+		return nil
+	}
+
+	var observedIDs []int
+	for id, entry := range c.entries {
+		if entry.Observed {
+			observedIDs = append(observedIDs, id)
+		}
+	}
+	sort.Ints(observedIDs)
+
+	for _, id := range observedIDs {
+		confused, err := c.source.GetLookalikes(ctx, id, c.lookalikes)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			c.logf("skipping confused species of %d: %v", id, err)
+			continue
+		}
+
+		ids := make([]int, 0, len(confused))
+		for _, sp := range confused {
+			if sp.ID() != id {
+				c.add(sp, false)
+				ids = append(ids, sp.ID())
+			}
+		}
+		c.entries[id].ConfusedIDs = ids
+	}
+	c.logf("confused species linked for %d species", len(observedIDs))
+	return nil
+}
+
+// fetchAncestors retrieves the higher taxa of every species.
+func (c *Crawler) fetchAncestors(ctx context.Context) ([]species.Taxon, error) {
+	seen := make(map[int]bool)
+	var ids []int
+	for _, entry := range c.entries {
+		for _, id := range entry.AncestorIDs {
+			if _, isSpecies := c.entries[id]; !isSpecies && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Ints(ids)
+
+	ancestors, err := c.source.GetTaxa(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("fetching ancestors: %w", err)
+	}
+	c.logf("%d ancestors fetched", len(ancestors))
+	return ancestors, nil
+}
+
+// others returns up to limit IDs of group other than id.
+func others(group []int, id, limit int) []int {
+	result := make([]int, 0, min(limit, len(group)))
+	for _, other := range group {
+		if len(result) >= limit {
+			break
+		}
+		if other != id {
+			result = append(result, other)
+		}
+	}
+	return result
+}
+
+// hasPhoto reports whether photos contains the photo id.
+func hasPhoto(photos []species.Photo, id int) bool {
+	for _, p := range photos {
+		if p.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSound reports whether sounds contains the sound id.
+func hasSound(sounds []species.Sound, id int) bool {
+	for _, s := range sounds {
+		if s.ID == id {
+			return true
+		}
+	}
+	return false
+}
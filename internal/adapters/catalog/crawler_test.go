@@ -0,0 +1,192 @@
+package catalog_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/catalog"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// fakeSource serves observation pages per iconic taxon and taxa by ID.
+type fakeSource struct {
+	pages    map[string][][]*species.Species
+	taxa     map[int]species.Taxon
+	genera   map[int][]*species.Species
+	filters  []ports.SpeciesFilter
+	taxaIDs  []int
+	genusErr error
+	confused map[int][]*species.Species
+}
+
+func (f *fakeSource) ListObservedSpecies(
+	_ context.Context,
+	filter ports.SpeciesFilter,
+	page int,
+) ([]*species.Species, error) {
+	f.filters = append(f.filters, filter)
+	pages := f.pages[filter.IconicTaxon]
+	if page > len(pages) {
+		return nil, nil
+	}
+	return pages[page-1], nil
+}
+
+func (f *fakeSource) GetTaxa(_ context.Context, ids []int) ([]species.Taxon, error) {
+	f.taxaIDs = ids
+	result := make([]species.Taxon, 0, len(ids))
+	for _, id := range ids {
+		if t, ok := f.taxa[id]; ok {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeSource) GetSpeciesInTaxon(_ context.Context, taxonID int, _ int) ([]*species.Species, error) {
+	if f.genusErr != nil {
+		return nil, f.genusErr
+	}
+	return f.genera[taxonID], nil
+}
+
+func (f *fakeSource) GetLookalikes(_ context.Context, speciesID int, limit int) ([]*species.Species, error) {
+	if f.genusErr != nil {
+		return nil, f.genusErr
+	}
+	confused := f.confused[speciesID]
+	return confused[:min(limit, len(confused))], nil
+}
+
+// observed builds a species of the genus parent with the given photo IDs.
+func observed(id int, name, taxon string, parent int, photoIDs ...int) *species.Species {
+	sp, _ := species.New(id, name, "", taxon)
+	sp.SetRank("species")
+	sp.SetAncestorIDs([]int{1, parent, id})
+	for _, photoID := range photoIDs {
+		sp.AddPhoto(species.Photo{ID: photoID, MediumURL: "https://example.com/photo.jpg"})
+	}
+	return sp
+}
+
+// singing adds recordings with the given IDs to sp.
+func singing(sp *species.Species, soundIDs ...int) *species.Species {
+	for _, soundID := range soundIDs {
+		sp.AddSound(species.Sound{ID: soundID, FileURL: "https://example.com/song.mp3"})
+	}
+	return sp
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{
+		pages: map[string][][]*species.Species{
+			"Mammalia": {
+				{observed(10, "Vulpes vulpes", "Mammalia", 100, 1, 2), observed(11, "Vulpes lagopus", "Mammalia", 100, 3)},
+				{observed(10, "Vulpes vulpes", "Mammalia", 100, 2, 4, 5)},
+			},
+			"Aves": {
+				{singing(observed(20, "Erithacus rubecula", "Aves", 200, 6), 1)},
+				{singing(observed(20, "Erithacus rubecula", "Aves", 200), 1, 2)},
+			},
+		},
+		taxa: map[int]species.Taxon{
+			1:   {ID: 1, Name: "Life", Rank: "stateofmatter"},
+			100: {ID: 100, Name: "Vulpes", Rank: species.RankGenus},
+			200: {ID: 200, Name: "Erithacus", Rank: species.RankGenus},
+			300: {ID: 300, Name: "Canis", Rank: species.RankGenus},
+		},
+		genera: map[int][]*species.Species{
+			100: {observed(10, "Vulpes vulpes", "Mammalia", 100), observed(12, "Vulpes zerda", "Mammalia", 100, 7)},
+		},
+		confused: map[int][]*species.Species{
+			10: {observed(11, "Vulpes lagopus", "Mammalia", 100), observed(30, "Canis aureus", "Mammalia", 300, 8)},
+		},
+	}
+}
+
+func TestCrawler_Crawl(t *testing.T) {
+	source := newFakeSource()
+	crawler := catalog.NewCrawler(source,
+		catalog.WithPlace(6753),
+		catalog.WithTaxa("Mammalia", "Aves"),
+		catalog.WithPhotosPerSpecies(3),
+	)
+
+	crawled, err := crawler.Crawl(context.Background())
+	if err != nil {
+		t.Fatalf("Crawl() error = %v", err)
+	}
+	if err := crawled.Validate(); err != nil {
+		t.Fatalf("crawled catalog is invalid: %v", err)
+	}
+
+	byID := make(map[int]catalog.Entry)
+	for _, e := range crawled.Species {
+		byID[e.ID] = e
+	}
+	if len(byID) != 5 {
+		t.Fatalf("crawled %d species, want 5", len(byID))
+	}
+
+	// Photos merged across pages, without duplicates, up to the limit
+	if photos := byID[10].Photos; len(photos) != 3 || photos[2].ID != 4 {
+		t.Errorf("Vulpes vulpes photos = %+v, want 1, 2, 4", photos)
+	}
+	// Sounds merged across pages, without duplicates
+	if sounds := byID[20].Sounds; len(sounds) != 2 {
+		t.Errorf("Erithacus rubecula sounds = %+v, want 1, 2", sounds)
+	}
+	// Lookalikes: observed species of the genus first, then the crawled relatives
+	if got := byID[10].LookalikeIDs; len(got) != 2 || got[0] != 11 || got[1] != 12 {
+		t.Errorf("Vulpes vulpes lookalikes = %v, want [11 12]", got)
+	}
+	if zerda := byID[12]; zerda.Observed || len(zerda.LookalikeIDs) != 0 {
+		t.Errorf("Vulpes zerda = %+v, want an unobserved relative", zerda)
+	}
+	// Confused species, whatever their genus, added unobserved when missing
+	if got := byID[10].ConfusedIDs; len(got) != 2 || got[0] != 11 || got[1] != 30 {
+		t.Errorf("Vulpes vulpes confused species = %v, want [11 30]", got)
+	}
+	if jackal, ok := byID[30]; !ok || jackal.Observed {
+		t.Errorf("Canis aureus = %+v, want an unobserved confused species", jackal)
+	}
+
+	// Ancestors fetched once, species excluded
+	if len(source.taxaIDs) != 4 || len(crawled.Ancestors) != 4 {
+		t.Errorf("ancestors requested %v, got %d, want 4", source.taxaIDs, len(crawled.Ancestors))
+	}
+	for _, filter := range source.filters {
+		if filter.PlaceID != 6753 {
+			t.Errorf("observations filter place = %d, want 6753", filter.PlaceID)
+		}
+	}
+	if crawled.Version != catalog.Version || crawled.PlaceID != 6753 || len(crawled.Taxa) != 2 {
+		t.Errorf("catalog header = version %d, place %d, taxa %v", crawled.Version, crawled.PlaceID, crawled.Taxa)
+	}
+}
+
+func TestCrawler_LookalikeFailuresAreSkipped(t *testing.T) {
+	source := newFakeSource()
+	source.genusErr = errors.New("upstream down")
+
+	crawled, err := catalog.NewCrawler(source, catalog.WithTaxa("Mammalia")).Crawl(context.Background())
+	if err != nil {
+		t.Fatalf("Crawl() error = %v", err)
+	}
+	if len(crawled.Species) != 2 {
+		t.Errorf("crawled %d species, want the 2 observed", len(crawled.Species))
+	}
+}
+
+func TestCrawler_CrawlCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	source := newFakeSource()
+	source.genusErr = context.Canceled
+
+	if _, err := catalog.NewCrawler(source, catalog.WithTaxa("Mammalia")).Crawl(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Crawl() error = %v, want context.Canceled", err)
+	}
+}
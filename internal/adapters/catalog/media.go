@@ -0,0 +1,214 @@
+package catalog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Default download settings.
+const (
+	defaultDownloadTimeout = 30 * time.Second
+	defaultUserAgent       = "Naturieux/1.0 (https://naturieux.fr)"
+	maxMediaBytes          = 20 << 20
+	mediaIDLength          = 32
+	maxExtensionLength     = 5
+)
+
+// MediaDir returns the directory the media of the catalog at path is stored
+// in, next to it: catalog-media for catalog.json.
+func MediaDir(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + "-media"
+}
+
+// Downloader stores the photos and sounds of a catalog in a directory and
+// points the catalog to them, so it can be served without network access.
+type Downloader struct {
+	dir        string
+	httpClient *http.Client
+	userAgent  string
+	logf       func(format string, args ...interface{})
+}
+
+// DownloaderOption configures the downloader.
+type DownloaderOption func(*Downloader)
+
+// WithDownloadClient sets the client fetching media.
+func WithDownloadClient(client *http.Client) DownloaderOption {
+	return func(d *Downloader) {
+		d.httpClient = client
+	}
+}
+
+// WithDownloadLogf sets where download progress is reported.
+func WithDownloadLogf(logf func(format string, args ...interface{})) DownloaderOption {
+	return func(d *Downloader) {
+		d.logf = logf
+	}
+}
+
+// NewDownloader creates a downloader storing media in dir.
+func NewDownloader(dir string, opts ...DownloaderOption) *Downloader {
+	d := &Downloader{
+		dir:        dir,
+		httpClient: &http.Client{Timeout: defaultDownloadTimeout},
+		userAgent:  defaultUserAgent,
+		logf:       func(string, ...interface{}) {},
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Download stores one file per photo, its largest size, and per sound, and
+// replaces their URLs with catalog media references. Files already in the
+// directory are kept. Media that cannot be downloaded is dropped from the catalog.
+func (d *Downloader) Download(ctx context.Context, c *Catalog) error {
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return fmt.Errorf("creating media directory: %w", err)
+	}
+
+	stored, dropped := 0, 0
+	for i := range c.Species {
+		entry := &c.Species[i]
+
+		photos := make([]species.Photo, 0, len(entry.Photos))
+		for _, photo := range entry.Photos {
+			ref, err := d.store(ctx, largestPhotoURL(photo))
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				d.logf("dropping photo %d of species %d: %v", photo.ID, entry.ID, err)
+				dropped++
+				continue
+			}
+			photo.URL, photo.SquareURL, photo.MediumURL, photo.LargeURL, photo.OriginalURL = ref, ref, ref, ref, ref
+			photos = append(photos, photo)
+			stored++
+		}
+		entry.Photos = photos
+
+		sounds := make([]species.Sound, 0, len(entry.Sounds))
+		for _, sound := range entry.Sounds {
+			ref, err := d.store(ctx, sound.FileURL)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				d.logf("dropping sound %d of species %d: %v", sound.ID, entry.ID, err)
+				dropped++
+				continue
+			}
+			sound.FileURL = ref
+			sounds = append(sounds, sound)
+			stored++
+		}
+		entry.Sounds = sounds
+
+		if (i+1)%100 == 0 {
+			d.logf("media of %d/%d species stored", i+1, len(c.Species))
+		}
+	}
+	d.logf("%d media files stored in %s, %d dropped", stored, d.dir, dropped)
+	return nil
+}
+
+// store downloads the media at sourceURL unless already stored, and returns
+// its catalog media reference.
+func (d *Downloader) store(ctx context.Context, sourceURL string) (string, error) {
+	if strings.HasPrefix(sourceURL, ports.CatalogMediaScheme) {
+		return sourceURL, nil
+	}
+	u, err := url.Parse(sourceURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("invalid media URL %q", sourceURL)
+	}
+
+	name := mediaName(u)
+	target := filepath.Join(d.dir, name)
+	if _, err := os.Stat(target); err == nil {
+		return ports.CatalogMediaScheme + name, nil
+	}
+
+	data, err := d.fetch(ctx, sourceURL)
+	if err != nil {
+		return "", err
+	}
+	tmp := target + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return "", fmt.Errorf("writing media: %w", err)
+	}
+	if err := os.Rename(tmp, target); err != nil {
+		return "", fmt.Errorf("writing media: %w", err)
+	}
+	return ports.CatalogMediaScheme + name, nil
+}
+
+// fetch downloads the media at sourceURL.
+func (d *Downloader) fetch(ctx context.Context, sourceURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", d.userAgent)
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching media: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching media: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMediaBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading media: %w", err)
+	}
+	if len(data) > maxMediaBytes {
+		return nil, fmt.Errorf("media larger than %d bytes", maxMediaBytes)
+	}
+	return data, nil
+}
+
+// largestPhotoURL returns the URL of the largest size of photo the quiz shows.
+func largestPhotoURL(photo species.Photo) string {
+	for _, u := range []string{photo.LargeURL, photo.MediumURL, photo.URL} {
+		if u != "" {
+			return u
+		}
+	}
+	return ""
+}
+
+// mediaName returns the file name of the media at u: a hash of the URL, with
+// the extension of its path when it has a short alphanumeric one.
+func mediaName(u *url.URL) string {
+	sum := sha256.Sum256([]byte(u.String()))
+	name := hex.EncodeToString(sum[:])[:mediaIDLength]
+
+	ext := strings.ToLower(path.Ext(u.Path))
+	if len(ext) < 2 || len(ext) > maxExtensionLength+1 {
+		return name
+	}
+	for _, r := range ext[1:] {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return name
+		}
+	}
+	return name + ext
+}
@@ -0,0 +1,82 @@
+package catalog_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/catalog"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+func TestMediaDir(t *testing.T) {
+	if got := catalog.MediaDir(filepath.Join("data", "catalog.json")); got != filepath.Join("data", "catalog-media") {
+		t.Errorf("MediaDir() = %s, want data/catalog-media", got)
+	}
+}
+
+func TestDownloader_Download(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/missing.jpg" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("content of " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	newCatalog := func() *catalog.Catalog {
+		c := testCatalog()
+		c.Species[0].Photos = []species.Photo{
+			{ID: 1, MediumURL: server.URL + "/fox/medium.jpg", LargeURL: server.URL + "/fox/large.jpg"},
+			{ID: 2, MediumURL: server.URL + "/missing.jpg"},
+		}
+		c.Species[3].Sounds = []species.Sound{{ID: 20, FileURL: server.URL + "/song.mp3"}}
+		return c
+	}
+
+	dir := filepath.Join(t.TempDir(), "catalog-media")
+	c := newCatalog()
+	if err := catalog.NewDownloader(dir).Download(context.Background(), c); err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+
+	photos := c.Species[0].Photos
+	if len(photos) != 1 || photos[0].ID != 1 {
+		t.Fatalf("photos = %+v, want the missing photo dropped", photos)
+	}
+	ref := photos[0].LargeURL
+	name, ok := strings.CutPrefix(ref, ports.CatalogMediaScheme)
+	if !ok || !strings.HasSuffix(name, ".jpg") || photos[0].MediumURL != ref || photos[0].OriginalURL != ref {
+		t.Fatalf("photo = %+v, want every size pointing to one catalog media file", photos[0])
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil || string(data) != "content of /fox/large.jpg" {
+		t.Errorf("stored photo = %q, %v, want the large size", data, err)
+	}
+	sound := c.Species[3].Sounds[0].FileURL
+	if !strings.HasPrefix(sound, ports.CatalogMediaScheme) || !strings.HasSuffix(sound, ".mp3") {
+		t.Errorf("sound FileURL = %s, want a catalog media reference", sound)
+	}
+
+	// Stored files are kept when the catalog is crawled again
+	before := requests.Load()
+	again := newCatalog()
+	if err := catalog.NewDownloader(dir).Download(context.Background(), again); err != nil {
+		t.Fatalf("Download() again error = %v", err)
+	}
+	if got := requests.Load() - before; got != 1 {
+		t.Errorf("requests on second download = %d, want 1 for the missing photo", got)
+	}
+	if again.Species[0].Photos[0].LargeURL != ref {
+		t.Errorf("second download reference = %s, want %s", again.Species[0].Photos[0].LargeURL, ref)
+	}
+}
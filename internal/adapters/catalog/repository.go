@@ -0,0 +1,252 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Default number of results when a query sets no limit.
+const (
+	defaultRandomLimit = 20
+	defaultSearchLimit = 10
+)
+
+// ErrSpeciesNotFound is returned for species missing from the catalog.
+var ErrSpeciesNotFound = errors.New("species not in catalog")
+
+// SpeciesRepository serves species from a catalog, without network access.
+// Observations were crawled for a single place, without their dates and
+// coordinates, so the place, area and month filters are ignored.
+type SpeciesRepository struct {
+	byID     map[int]*species.Species
+	observed []*species.Species // Answer candidates
+	sorted   []*species.Species // All species by scientific name
+	similar  map[int][]int      // Lookalikes, then species of the same parent taxon
+	confused map[int][]int      // Most confused species first
+	taxa     map[int]species.Taxon
+}
+
+// NewSpeciesRepository creates a repository serving the catalog.
+func NewSpeciesRepository(c *Catalog) (*SpeciesRepository, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	r := &SpeciesRepository{
+		byID:     make(map[int]*species.Species, len(c.Species)),
+		similar:  make(map[int][]int, len(c.Species)),
+		confused: make(map[int][]int, len(c.Species)),
+		taxa:     make(map[int]species.Taxon, len(c.Species)+len(c.Ancestors)),
+	}
+	for _, ancestor := range c.Ancestors {
+		r.taxa[ancestor.ID] = ancestor
+	}
+	for _, entry := range c.Species {
+		sp, _ := species.Restore(entry.Snapshot) // Validated above
+		r.byID[sp.ID()] = sp
+		r.confused[sp.ID()] = entry.ConfusedIDs
+		r.taxa[sp.ID()] = species.Taxon{
+			ID:         sp.ID(),
+			Name:       sp.ScientificName(),
+			CommonName: sp.CommonName(),
+			Rank:       sp.Rank(),
+		}
+		r.sorted = append(r.sorted, sp)
+		if entry.Observed && sp.HasPhotos() {
+			r.observed = append(r.observed, sp)
+		}
+	}
+	sort.Slice(r.sorted, func(i, j int) bool {
+		return r.sorted[i].ScientificName() < r.sorted[j].ScientificName()
+	})
+	r.indexSimilar(c.Species)
+	return r, nil
+}
+
+// indexSimilar lists the similar species of each entry: its lookalikes first,
+// then the other species of its parent taxon.
+func (r *SpeciesRepository) indexSimilar(entries []Entry) {
+	siblings := make(map[int][]int)
+	for _, sp := range r.sorted {
+		if parent := sp.ParentID(); parent != 0 {
+			siblings[parent] = append(siblings[parent], sp.ID())
+		}
+	}
+
+	for _, entry := range entries {
+		seen := map[int]bool{entry.ID: true}
+		ids := make([]int, 0, len(entry.LookalikeIDs))
+		candidates := siblings[r.byID[entry.ID].ParentID()]
+		for _, id := range append(append([]int(nil), entry.LookalikeIDs...), candidates...) {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		r.similar[entry.ID] = ids
+	}
+}
+
+// GetByID retrieves a species by its ID.
+func (r *SpeciesRepository) GetByID(_ context.Context, id int) (*species.Species, error) {
+	sp, ok := r.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: species %d", ErrSpeciesNotFound, id)
+	}
+	return sp, nil
+}
+
+// GetRandom retrieves random observed species matching the filter.
+func (r *SpeciesRepository) GetRandom(_ context.Context, filter ports.SpeciesFilter) ([]*species.Species, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultRandomLimit
+	}
+	candidates := make([]*species.Species, 0, len(r.observed))
+	for _, sp := range r.observed {
+		if matches(sp, filter) {
+			candidates = append(candidates, sp)
+		}
+	}
+
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	return candidates[:min(limit, len(candidates))], nil
+}
+
+// matches reports whether a species passes the media and taxonomic criteria of filter.
+func matches(sp *species.Species, filter ports.SpeciesFilter) bool {
+	if filter.IconicTaxon != "" && sp.IconicTaxon() != filter.IconicTaxon {
+		return false
+	}
+	if filter.HasSounds && !sp.HasSounds() {
+		return false
+	}
+	if len(filter.PhotoLicenses) > 0 && len(sp.LicensedPhotos(filter.PhotoLicenses)) == 0 {
+		return false
+	}
+	if filter.TaxonID > 0 && !sp.HasAncestor(filter.TaxonID) {
+		return false
+	}
+	for _, id := range filter.ExcludeIDs {
+		if sp.HasAncestor(id) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetSimilar retrieves lookalikes of a species, then species of the same genus.
+func (r *SpeciesRepository) GetSimilar(_ context.Context, speciesID int, limit int) ([]*species.Species, error) {
+	ids, ok := r.similar[speciesID]
+	if !ok {
+		return nil, fmt.Errorf("%w: species %d", ErrSpeciesNotFound, speciesID)
+	}
+
+	return r.listSpecies(ids, limit), nil
+}
+
+// GetLookalikes retrieves the species most often confused with the given one,
+// as crawled from identifications.
+func (r *SpeciesRepository) GetLookalikes(_ context.Context, speciesID int, limit int) ([]*species.Species, error) {
+	ids, ok := r.confused[speciesID]
+	if !ok {
+		return nil, fmt.Errorf("%w: species %d", ErrSpeciesNotFound, speciesID)
+	}
+
+	return r.listSpecies(ids, limit), nil
+}
+
+// listSpecies returns the species of up to limit IDs.
+func (r *SpeciesRepository) listSpecies(ids []int, limit int) []*species.Species {
+	ids = ids[:min(max(limit, 0), len(ids))]
+	speciesList := make([]*species.Species, 0, len(ids))
+	for _, id := range ids {
+		speciesList = append(speciesList, r.byID[id])
+	}
+	return speciesList
+}
+
+// Search searches for species whose scientific or common name contains the
+// query, ignoring case. Names starting with the query come first.
+func (r *SpeciesRepository) Search(_ context.Context, query string, limit int) ([]*species.Species, error) {
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return []*species.Species{}, nil
+	}
+
+	var prefixed, contained []*species.Species
+	for _, sp := range r.sorted {
+		scientific := strings.ToLower(sp.ScientificName())
+		common := strings.ToLower(sp.CommonName())
+		switch {
+		case strings.HasPrefix(scientific, query) || strings.HasPrefix(common, query):
+			prefixed = append(prefixed, sp)
+		case strings.Contains(scientific, query) || strings.Contains(common, query):
+			contained = append(contained, sp)
+		}
+	}
+
+	results := make([]*species.Species, 0, len(prefixed)+len(contained))
+	results = append(results, prefixed...)
+	results = append(results, contained...)
+	return results[:min(limit, len(results))], nil
+}
+
+// GetTaxa retrieves the species and ancestors of the catalog by ID.
+func (r *SpeciesRepository) GetTaxa(_ context.Context, ids []int) ([]species.Taxon, error) {
+	taxa := make([]species.Taxon, 0, len(ids))
+	for _, id := range ids {
+		if t, ok := r.taxa[id]; ok {
+			taxa = append(taxa, t)
+		}
+	}
+	return taxa, nil
+}
+
+// SearchTaxa searches for taxa of the catalog whose scientific or common name
+// contains the query, ignoring case. Names starting with the query come first.
+func (r *SpeciesRepository) SearchTaxa(_ context.Context, query string, limit int) ([]species.Taxon, error) {
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return []species.Taxon{}, nil
+	}
+
+	var prefixed, contained []species.Taxon
+	for _, t := range r.taxa {
+		name, common := strings.ToLower(t.Name), strings.ToLower(t.CommonName)
+		switch {
+		case strings.HasPrefix(name, query) || strings.HasPrefix(common, query):
+			prefixed = append(prefixed, t)
+		case strings.Contains(name, query) || strings.Contains(common, query):
+			contained = append(contained, t)
+		}
+	}
+	byName := func(taxa []species.Taxon) {
+		sort.Slice(taxa, func(i, j int) bool { return taxa[i].Name < taxa[j].Name })
+	}
+	byName(prefixed)
+	byName(contained)
+
+	results := make([]species.Taxon, 0, len(prefixed)+len(contained))
+	results = append(results, prefixed...)
+	results = append(results, contained...)
+	return results[:min(limit, len(results))], nil
+}
+
+// Ensure interface compliance
+var _ ports.SpeciesRepository = (*SpeciesRepository)(nil)
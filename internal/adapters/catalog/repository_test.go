@@ -0,0 +1,205 @@
+package catalog_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/catalog"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+func newTestRepository(t *testing.T) *catalog.SpeciesRepository {
+	t.Helper()
+	repo, err := catalog.NewSpeciesRepository(testCatalog())
+	if err != nil {
+		t.Fatalf("NewSpeciesRepository() error = %v", err)
+	}
+	return repo
+}
+
+func ids(list []*species.Species) []int {
+	result := make([]int, len(list))
+	for i, sp := range list {
+		result[i] = sp.ID()
+	}
+	return result
+}
+
+func TestSpeciesRepository_GetByID(t *testing.T) {
+	repo := newTestRepository(t)
+
+	sp, err := repo.GetByID(context.Background(), 12)
+	if err != nil || sp.ScientificName() != "Vulpes zerda" {
+		t.Errorf("GetByID(12) = %v, %v, want Vulpes zerda", sp, err)
+	}
+	if _, err := repo.GetByID(context.Background(), 99); !errors.Is(err, catalog.ErrSpeciesNotFound) {
+		t.Errorf("GetByID(99) error = %v, want ErrSpeciesNotFound", err)
+	}
+}
+
+func TestSpeciesRepository_GetRandom(t *testing.T) {
+	repo := newTestRepository(t)
+	tests := []struct {
+		name   string
+		filter ports.SpeciesFilter
+		want   map[int]bool
+	}{
+		{"observed species only", ports.SpeciesFilter{}, map[int]bool{10: true, 11: true, 20: true}},
+		{"iconic taxon", ports.SpeciesFilter{IconicTaxon: "Mammalia"}, map[int]bool{10: true, 11: true}},
+		{"excluded species", ports.SpeciesFilter{IconicTaxon: "Mammalia", ExcludeIDs: []int{10}}, map[int]bool{11: true}},
+		{"unknown taxon", ports.SpeciesFilter{IconicTaxon: "Fungi"}, map[int]bool{}},
+		{"descendants of a taxon", ports.SpeciesFilter{TaxonID: 100}, map[int]bool{10: true, 11: true}},
+		{"excluded taxon", ports.SpeciesFilter{ExcludeIDs: []int{100}}, map[int]bool{20: true}},
+		{"with sounds", ports.SpeciesFilter{HasSounds: true}, map[int]bool{20: true}},
+		{"photo licenses", ports.SpeciesFilter{PhotoLicenses: []string{"cc-by", "cc0"}}, map[int]bool{20: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetRandom(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("GetRandom() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetRandom() = %v, want %d species", ids(got), len(tt.want))
+			}
+			for _, sp := range got {
+				if !tt.want[sp.ID()] || !sp.HasPhotos() {
+					t.Errorf("GetRandom() returned species %d", sp.ID())
+				}
+			}
+		})
+	}
+
+	limited, _ := repo.GetRandom(context.Background(), ports.SpeciesFilter{Limit: 1})
+	if len(limited) != 1 {
+		t.Errorf("GetRandom() with limit 1 returned %d species", len(limited))
+	}
+}
+
+func TestSpeciesRepository_GetSimilar(t *testing.T) {
+	repo := newTestRepository(t)
+
+	// Lookalike first, then the other species of the genus
+	got, err := repo.GetSimilar(context.Background(), 10, 5)
+	if err != nil {
+		t.Fatalf("GetSimilar() error = %v", err)
+	}
+	if want := []int{12, 11}; len(got) != 2 || got[0].ID() != want[0] || got[1].ID() != want[1] {
+		t.Errorf("GetSimilar(10) = %v, want %v", ids(got), want)
+	}
+
+	if got, _ := repo.GetSimilar(context.Background(), 10, 1); len(got) != 1 {
+		t.Errorf("GetSimilar() with limit 1 returned %d species", len(got))
+	}
+	if got, _ := repo.GetSimilar(context.Background(), 20, 5); len(got) != 0 {
+		t.Errorf("GetSimilar(20) = %v, want none", ids(got))
+	}
+	if _, err := repo.GetSimilar(context.Background(), 99, 5); !errors.Is(err, catalog.ErrSpeciesNotFound) {
+		t.Errorf("GetSimilar(99) error = %v, want ErrSpeciesNotFound", err)
+	}
+}
+
+func TestSpeciesRepository_GetLookalikes(t *testing.T) {
+	repo := newTestRepository(t)
+
+	got, err := repo.GetLookalikes(context.Background(), 11, 5)
+	if err != nil {
+		t.Fatalf("GetLookalikes() error = %v", err)
+	}
+	if want := []int{12, 10}; len(got) != 2 || got[0].ID() != want[0] || got[1].ID() != want[1] {
+		t.Errorf("GetLookalikes(11) = %v, want %v", ids(got), want)
+	}
+
+	if got, _ := repo.GetLookalikes(context.Background(), 11, 1); len(got) != 1 {
+		t.Errorf("GetLookalikes() with limit 1 returned %d species", len(got))
+	}
+	if got, _ := repo.GetLookalikes(context.Background(), 10, 5); len(got) != 0 {
+		t.Errorf("GetLookalikes(10) = %v, want none", ids(got))
+	}
+	if _, err := repo.GetLookalikes(context.Background(), 99, 5); !errors.Is(err, catalog.ErrSpeciesNotFound) {
+		t.Errorf("GetLookalikes(99) error = %v, want ErrSpeciesNotFound", err)
+	}
+}
+
+func TestSpeciesRepository_GetTaxa(t *testing.T) {
+	repo := newTestRepository(t)
+
+	taxa, err := repo.GetTaxa(context.Background(), []int{100, 10, 99})
+	if err != nil {
+		t.Fatalf("GetTaxa() error = %v", err)
+	}
+	if len(taxa) != 2 {
+		t.Fatalf("GetTaxa() = %+v, want the genus and the species", taxa)
+	}
+	if taxa[0].Name != "Vulpes" || taxa[0].Rank != species.RankGenus {
+		t.Errorf("GetTaxa()[0] = %+v, want the Vulpes genus", taxa[0])
+	}
+	if taxa[1].CommonName != "Renard roux" || taxa[1].Rank != species.RankSpecies {
+		t.Errorf("GetTaxa()[1] = %+v, want Vulpes vulpes", taxa[1])
+	}
+}
+
+func TestSpeciesRepository_SearchTaxa(t *testing.T) {
+	repo := newTestRepository(t)
+
+	// The genus starts with the query and comes before its species
+	taxa, err := repo.SearchTaxa(context.Background(), "VULPES", 10)
+	if err != nil {
+		t.Fatalf("SearchTaxa() error = %v", err)
+	}
+	if len(taxa) != 4 || taxa[0].ID != 100 || taxa[0].Rank != species.RankGenus {
+		t.Errorf("SearchTaxa(VULPES) = %+v, want the genus then its three species", taxa)
+	}
+	if taxa, _ := repo.SearchTaxa(context.Background(), "loup", 10); len(taxa) != 0 {
+		t.Errorf("SearchTaxa(loup) = %+v, want none", taxa)
+	}
+}
+
+func TestSpeciesRepository_Search(t *testing.T) {
+	repo := newTestRepository(t)
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"vulpes", []int{11, 10, 12}},
+		{"RENARD", []int{11, 10}},
+		{"fennec", []int{12}},
+		{"rouge", []int{20}},
+		{"roux", []int{10}},
+		{"  ", []int{}},
+		{"loup", []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := repo.Search(context.Background(), tt.query, 10)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			gotIDs := ids(got)
+			if len(gotIDs) != len(tt.want) {
+				t.Fatalf("Search(%q) = %v, want %v", tt.query, gotIDs, tt.want)
+			}
+			for i := range gotIDs {
+				if gotIDs[i] != tt.want[i] {
+					t.Errorf("Search(%q) = %v, want %v", tt.query, gotIDs, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSpeciesRepository_Search_PrefixFirst(t *testing.T) {
+	c := testCatalog()
+	c.Species = append(c.Species, entry(30, "Aquila chrysaetos", "Aigle royal", "Aves", 300, true))
+	repo, _ := catalog.NewSpeciesRepository(c)
+
+	// "Rougegorge" starts with the query, "Aigle royal" only contains it
+	got, _ := repo.Search(context.Background(), "ro", 10)
+	if len(got) == 0 || got[0].ID() != 20 {
+		t.Errorf("Search(ro) = %v, want prefix matches first", ids(got))
+	}
+}
@@ -2,28 +2,88 @@
 package http
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
+	appleaderboard "github.com/Naturieux-fr/Naturieux.fr/internal/application/leaderboard"
+	appplayer "github.com/Naturieux-fr/Naturieux.fr/internal/application/player"
 	appquiz "github.com/Naturieux-fr/Naturieux.fr/internal/application/quiz"
 	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
 )
 
 // Handler contains all HTTP handlers.
 type Handler struct {
-	quizService *appquiz.Service
-	sessions    map[string]*quiz.Session // In-memory session store (use proper storage in production)
+	quizService        *appquiz.Service
+	playerService      *appplayer.Service
+	leaderboardService *appleaderboard.Service
+	places             ports.PlaceRepository
+	mediaServer        ports.MediaServer
+	mediaTokens        ports.MediaTokens
+	healthDetails      map[string]func() interface{}
+}
+
+// HandlerOption configures the handler.
+type HandlerOption func(*Handler)
+
+// WithPlayerService enables the player endpoints.
+func WithPlayerService(service *appplayer.Service) HandlerOption {
+	return func(h *Handler) {
+		h.playerService = service
+	}
+}
+
+// WithLeaderboardService enables the leaderboard endpoints.
+func WithLeaderboardService(service *appleaderboard.Service) HandlerOption {
+	return func(h *Handler) {
+		h.leaderboardService = service
+	}
+}
+
+// WithPlaces enables the place endpoints.
+func WithPlaces(places ports.PlaceRepository) HandlerOption {
+	return func(h *Handler) {
+		h.places = places
+	}
+}
+
+// WithMediaProxy serves question media under expiring tokens instead of
+// exposing its source URL.
+func WithMediaProxy(server ports.MediaServer, tokens ports.MediaTokens) HandlerOption {
+	return func(h *Handler) {
+		h.mediaServer = server
+		h.mediaTokens = tokens
+	}
+}
+
+// WithHealthDetail adds a named detail, such as an upstream quota, to the health check.
+func WithHealthDetail(name string, detail func() interface{}) HandlerOption {
+	return func(h *Handler) {
+		if h.healthDetails == nil {
+			h.healthDetails = make(map[string]func() interface{})
+		}
+		h.healthDetails[name] = detail
+	}
 }
 
 // NewHandler creates a new Handler.
-func NewHandler(quizService *appquiz.Service) *Handler {
-	return &Handler{
+func NewHandler(quizService *appquiz.Service, opts ...HandlerOption) *Handler {
+	h := &Handler{
 		quizService: quizService,
-		sessions:    make(map[string]*quiz.Session),
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
+// mediaPath prefixes the URLs question media is served at through the proxy.
+const mediaPath = "/api/v1/media/"
+
 // Response represents a standard API response.
 type Response struct {
 	Success bool        `json:"success"`
@@ -59,10 +119,47 @@ type StartSessionRequest struct {
 	UserID        string   `json:"user_id"`
 	Difficulty    string   `json:"difficulty"`
 	QuizTypes     []string `json:"quiz_types"`
-	TaxonFilter   string   `json:"taxon_filter"`
+	TaxonFilter   string   `json:"taxon_filter"`       // Iconic taxon, such as Aves
+	TaxonID       int      `json:"taxon_id,omitempty"` // Any taxon, such as a family
+	Taxon         string   `json:"taxon,omitempty"`    // Name of any taxon, such as Orchidaceae
+	PlaceID       int      `json:"place_id,omitempty"` // iNaturalist place
+	Lat           *float64 `json:"lat,omitempty"`      // Centre of the area, with lng
+	Lng           *float64 `json:"lng,omitempty"`
+	RadiusKm      float64  `json:"radius_km,omitempty"` // 10 km by default
+	Months        []int    `json:"months,omitempty"`    // 1 to 12
+	Season        string   `json:"season,omitempty"`    // spring, summer, autumn or winter
 	QuestionCount int      `json:"question_count"`
 }
 
+// filter returns the species filter of the request.
+func (req *StartSessionRequest) filter() (quiz.Filter, error) {
+	filter := quiz.Filter{
+		IconicTaxon: req.TaxonFilter,
+		TaxonID:     req.TaxonID,
+		PlaceID:     req.PlaceID,
+	}
+	if req.Lat != nil || req.Lng != nil || req.RadiusKm != 0 {
+		if req.Lat == nil || req.Lng == nil {
+			return quiz.Filter{}, fmt.Errorf("%w: lat and lng go together", quiz.ErrInvalidFilter)
+		}
+		filter.Area = quiz.Area{Lat: *req.Lat, Lng: *req.Lng, RadiusKm: req.RadiusKm}
+		if filter.Area.RadiusKm == 0 {
+			filter.Area.RadiusKm = quiz.DefaultAreaRadiusKm
+		}
+	}
+	for _, month := range req.Months {
+		filter.Months |= quiz.NewMonths(time.Month(month))
+	}
+	if req.Season != "" {
+		season := quiz.Season(req.Season)
+		if !quiz.IsValidSeason(season) {
+			return quiz.Filter{}, fmt.Errorf("%w: unknown season %q", quiz.ErrInvalidFilter, req.Season)
+		}
+		filter.Months |= season.Months()
+	}
+	return filter, filter.Validate()
+}
+
 // StartSessionResponse represents the response for starting a session.
 type StartSessionResponse struct {
 	SessionID      string      `json:"session_id"`
@@ -76,11 +173,18 @@ type QuestionDTO struct {
 	QuizType      string      `json:"quiz_type"`
 	Difficulty    string      `json:"difficulty"`
 	MediaURL      string      `json:"media_url"`
+	Audio         *AudioDTO   `json:"audio,omitempty"`
 	TimeLimit     int         `json:"time_limit_seconds"`
 	FlashDuration int         `json:"flash_duration_ms,omitempty"`
 	Choices       []ChoiceDTO `json:"choices"`
 }
 
+// AudioDTO describes the recording played by a sound question.
+type AudioDTO struct {
+	ContentType string `json:"content_type,omitempty"`
+	Attribution string `json:"attribution,omitempty"`
+}
+
 // ChoiceDTO represents a choice for API responses.
 type ChoiceDTO struct {
 	SpeciesID   int    `json:"species_id"`
@@ -100,6 +204,7 @@ type SubmitAnswerResponse struct {
 	Score            int          `json:"score"`
 	CorrectSpeciesID int          `json:"correct_species_id"`
 	CorrectName      string       `json:"correct_name"`
+	Credit           *CreditDTO   `json:"credit,omitempty"`
 	CurrentStreak    int          `json:"current_streak"`
 	TotalScore       int          `json:"total_score"`
 	Accuracy         float64      `json:"accuracy"`
@@ -107,6 +212,13 @@ type SubmitAnswerResponse struct {
 	NextQuestion     *QuestionDTO `json:"next_question,omitempty"`
 }
 
+// CreditDTO is the attribution owed for the media of an answered question.
+type CreditDTO struct {
+	Attribution    string `json:"attribution,omitempty"`
+	LicenseCode    string `json:"license_code,omitempty"`
+	ObservationURL string `json:"observation_url,omitempty"`
+}
+
 // HandleStartSession handles POST /api/v1/quiz/start
 func (h *Handler) HandleStartSession(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -131,26 +243,35 @@ func (h *Handler) HandleStartSession(w http.ResponseWriter, r *http.Request) {
 		quizTypes = append(quizTypes, quiz.QuizType(qt))
 	}
 
+	filter, err := req.filter()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	serviceReq := appquiz.StartSessionRequest{
 		UserID:        req.UserID,
 		Difficulty:    quiz.Difficulty(req.Difficulty),
 		QuizTypes:     quizTypes,
-		TaxonFilter:   req.TaxonFilter,
+		Filter:        filter,
+		TaxonName:     req.Taxon,
 		QuestionCount: req.QuestionCount,
 	}
 
 	result, err := h.quizService.StartSession(r.Context(), serviceReq)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		status := http.StatusInternalServerError
+		if errors.Is(err, quiz.ErrInvalidFilter) {
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err.Error())
 		return
 	}
 
-	// Store session (in production, use proper storage)
-	// Note: This is simplified for the example
 	response := StartSessionResponse{
 		SessionID:      result.SessionID,
 		TotalQuestions: result.TotalQuestions,
-		Question:       questionToDTO(result.FirstQuestion),
+		Question:       h.questionToDTO(result.SessionID, result.FirstQuestion),
 	}
 
 	writeSuccess(w, response)
@@ -174,20 +295,17 @@ func (h *Handler) HandleSubmitAnswer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get session (in production, use proper storage)
-	session, ok := h.sessions[req.SessionID]
-	if !ok {
-		writeError(w, http.StatusNotFound, "session not found")
-		return
-	}
-
 	serviceReq := appquiz.SubmitAnswerRequest{
 		SessionID: req.SessionID,
 		SpeciesID: req.SpeciesID,
 		TimeTaken: time.Duration(req.TimeTakenMs) * time.Millisecond,
 	}
 
-	result, err := h.quizService.SubmitAnswer(r.Context(), session, serviceReq)
+	result, err := h.quizService.SubmitAnswer(r.Context(), serviceReq)
+	if errors.Is(err, ports.ErrSessionNotFound) {
+		writeError(w, http.StatusNotFound, "session not found")
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -204,8 +322,16 @@ func (h *Handler) HandleSubmitAnswer(w http.ResponseWriter, r *http.Request) {
 		SessionComplete:  result.SessionComplete,
 	}
 
+	if result.Credit != nil {
+		response.Credit = &CreditDTO{
+			Attribution:    result.Credit.Attribution,
+			LicenseCode:    result.Credit.LicenseCode,
+			ObservationURL: result.Credit.ObservationURL,
+		}
+	}
+
 	if result.NextQuestion != nil {
-		dto := questionToDTO(result.NextQuestion)
+		dto := h.questionToDTO(req.SessionID, result.NextQuestion)
 		response.NextQuestion = &dto
 	}
 
@@ -227,21 +353,92 @@ func (h *Handler) HandleAbandonSession(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session, ok := h.sessions[req.SessionID]
-	if !ok {
+	err := h.quizService.AbandonSession(r.Context(), req.SessionID)
+	if errors.Is(err, ports.ErrSessionNotFound) {
 		writeError(w, http.StatusNotFound, "session not found")
 		return
 	}
-
-	if err := h.quizService.AbandonSession(r.Context(), session); err != nil {
+	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	delete(h.sessions, req.SessionID)
 	writeSuccess(w, map[string]string{"message": "session abandoned"})
 }
 
+// loadSession fetches a session through the quiz service, writing the error response on failure.
+func (h *Handler) loadSession(w http.ResponseWriter, r *http.Request, sessionID string) (*quiz.Session, bool) {
+	session, err := h.quizService.GetSession(r.Context(), sessionID)
+	if errors.Is(err, ports.ErrSessionNotFound) {
+		writeError(w, http.StatusNotFound, "session not found")
+		return nil, false
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	return session, true
+}
+
+// HandleGetMedia handles GET /api/v1/media/{token}
+func (h *Handler) HandleGetMedia(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	token := r.PathValue("token")
+	grant, err := h.mediaTokens.Redeem(token)
+	if err != nil {
+		writeError(w, http.StatusForbidden, "invalid or expired media token")
+		return
+	}
+	session, ok := h.loadSession(w, r, grant.SessionID)
+	if !ok {
+		return
+	}
+	question := findQuestion(session, grant.QuestionID)
+	if question == nil {
+		writeError(w, http.StatusNotFound, "media not found")
+		return
+	}
+
+	media, err := h.mediaServer.Open(r.Context(), question.MediaURL())
+	if err != nil && grant.SingleUse {
+		h.mediaTokens.Release(token)
+	}
+	switch {
+	case errors.Is(err, ports.ErrMediaNotFound):
+		writeError(w, http.StatusNotFound, "media not found")
+		return
+	case errors.Is(err, ports.ErrMediaBandwidthExhausted):
+		writeError(w, http.StatusServiceUnavailable, "media temporarily unavailable")
+		return
+	case err != nil:
+		writeError(w, http.StatusBadGateway, "media unavailable")
+		return
+	}
+
+	w.Header().Set("Content-Type", media.ContentType)
+	if grant.SingleUse {
+		w.Header().Set("Cache-Control", "private, no-store")
+	} else {
+		maxAge := max(int(time.Until(grant.ExpiresAt).Seconds()), 0)
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", maxAge))
+	}
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(media.Data))
+}
+
+// findQuestion returns the question of a session with the given ID, or nil.
+func findQuestion(session *quiz.Session, questionID string) *quiz.Question {
+	for _, q := range session.Questions() {
+		if q.ID() == questionID {
+			return q
+		}
+	}
+	return nil
+}
+
 // HandleHealthCheck handles GET /health
 func (h *Handler) HandleHealthCheck(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -249,14 +446,19 @@ func (h *Handler) HandleHealthCheck(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeSuccess(w, map[string]string{
+	health := map[string]interface{}{
 		"status":  "healthy",
 		"service": "naturieux-api",
-	})
+	}
+	for name, detail := range h.healthDetails {
+		health[name] = detail()
+	}
+	writeSuccess(w, health)
 }
 
-// questionToDTO converts a domain Question to a DTO.
-func questionToDTO(q *quiz.Question) QuestionDTO {
+// questionToDTO converts a domain Question of a session to a DTO. With a media
+// proxy, the media URL is a token rather than the source URL.
+func (h *Handler) questionToDTO(sessionID string, q *quiz.Question) QuestionDTO {
 	choices := make([]ChoiceDTO, len(q.Choices()))
 	for i, c := range q.Choices() {
 		choices[i] = ChoiceDTO{
@@ -274,9 +476,18 @@ func questionToDTO(q *quiz.Question) QuestionDTO {
 		Choices:    choices,
 	}
 
+	if h.mediaTokens != nil && dto.MediaURL != "" {
+		dto.MediaURL = mediaPath + h.mediaTokens.Issue(sessionID, q)
+	}
 	if q.QuizType() == quiz.FlashQuiz {
 		dto.FlashDuration = int(q.FlashDuration().Milliseconds())
 	}
+	if sound, ok := q.Sound(); ok {
+		dto.Audio = &AudioDTO{
+			ContentType: sound.FileContentType,
+			Attribution: sound.Attribution,
+		}
+	}
 
 	return dto
 }
@@ -287,4 +498,27 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/v1/quiz/start", h.HandleStartSession)
 	mux.HandleFunc("/api/v1/quiz/answer", h.HandleSubmitAnswer)
 	mux.HandleFunc("/api/v1/quiz/abandon", h.HandleAbandonSession)
+
+	if h.playerService != nil {
+		mux.HandleFunc("/api/v1/players", h.HandleCreatePlayer)
+		mux.HandleFunc("/api/v1/players/{id}", h.HandleGetPlayer)
+		mux.HandleFunc("/api/v1/players/{id}/achievements", h.HandleGetPlayerAchievements)
+		mux.HandleFunc("/api/v1/players/{id}/home-place", h.HandleSetHomePlace)
+	}
+
+	if h.places != nil {
+		mux.HandleFunc("/api/v1/places", h.HandleSearchPlaces)
+		mux.HandleFunc("/api/v1/places/{id}", h.HandleGetPlace)
+	}
+
+	if h.leaderboardService != nil {
+		mux.HandleFunc("/api/v1/leaderboard", h.HandleGetXPLeaderboard)
+		mux.HandleFunc("/api/v1/leaderboard/players/{id}", h.HandleGetXPStanding)
+		mux.HandleFunc("/api/v1/leaderboard/scores", h.HandleGetScoreLeaderboard)
+		mux.HandleFunc("/api/v1/leaderboard/scores/players/{id}", h.HandleGetScoreStanding)
+	}
+
+	if h.mediaServer != nil && h.mediaTokens != nil {
+		mux.HandleFunc(mediaPath+"{token}", h.HandleGetMedia)
+	}
 }
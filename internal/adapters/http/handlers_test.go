@@ -2,14 +2,32 @@ package http_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/catalog"
 	httphandler "github.com/Naturieux-fr/Naturieux.fr/internal/adapters/http"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/media"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/memory"
+	appquiz "github.com/Naturieux-fr/Naturieux.fr/internal/application/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
 )
 
+// newSessionHandler creates a handler backed by an empty in-memory session store.
+func newSessionHandler() *httphandler.Handler {
+	service := appquiz.NewService(nil, memory.NewSessionRepository(), nil, nil)
+	return httphandler.NewHandler(service)
+}
+
 func TestHandler_HandleHealthCheck(t *testing.T) {
 	handler := httphandler.NewHandler(nil)
 
@@ -32,6 +50,28 @@ func TestHandler_HandleHealthCheck(t *testing.T) {
 	}
 }
 
+func TestHandler_HandleHealthCheck_Details(t *testing.T) {
+	handler := httphandler.NewHandler(nil, httphandler.WithHealthDetail("upstream_quota", func() interface{} {
+		return map[string]int{"remaining": 42}
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	rec := httptest.NewRecorder()
+	handler.HandleHealthCheck(rec, req)
+
+	var response struct {
+		Data struct {
+			Status        string         `json:"status"`
+			UpstreamQuota map[string]int `json:"upstream_quota"`
+		} `json:"data"`
+	}
+	json.NewDecoder(rec.Body).Decode(&response)
+
+	if response.Data.Status != "healthy" || response.Data.UpstreamQuota["remaining"] != 42 {
+		t.Errorf("HandleHealthCheck() data = %+v, want status and upstream_quota", response.Data)
+	}
+}
+
 func TestHandler_HandleHealthCheck_WrongMethod(t *testing.T) {
 	handler := httphandler.NewHandler(nil)
 
@@ -104,7 +144,7 @@ func TestHandler_HandleSubmitAnswer_WrongMethod(t *testing.T) {
 }
 
 func TestHandler_HandleSubmitAnswer_SessionNotFound(t *testing.T) {
-	handler := httphandler.NewHandler(nil)
+	handler := newSessionHandler()
 
 	reqBody := httphandler.SubmitAnswerRequest{
 		SessionID:   "nonexistent",
@@ -136,7 +176,7 @@ func TestHandler_HandleAbandonSession_WrongMethod(t *testing.T) {
 }
 
 func TestHandler_HandleAbandonSession_SessionNotFound(t *testing.T) {
-	handler := httphandler.NewHandler(nil)
+	handler := newSessionHandler()
 
 	reqBody := map[string]string{"session_id": "nonexistent"}
 	body, _ := json.Marshal(reqBody)
@@ -212,3 +252,389 @@ func TestHandler_HandleSubmitAnswer_InvalidJSON(t *testing.T) {
 		t.Errorf("HandleSubmitAnswer() status = %d, want %d", rec.Code, http.StatusBadRequest)
 	}
 }
+
+// soundFactory creates sound questions about a singing robin.
+type soundFactory struct{}
+
+func (soundFactory) CreateQuestion(
+	_ context.Context,
+	quizType quiz.QuizType,
+	difficulty quiz.Difficulty,
+	_ quiz.Filter,
+) (*quiz.Question, error) {
+	robin, _ := species.New(13094, "Erithacus rubecula", "Rougegorge familier", "Aves")
+	robin.AddSound(species.Sound{
+		ID:              5,
+		FileURL:         "https://example.com/song.mp3",
+		FileContentType: "audio/mpeg",
+		Attribution:     "(c) someone, some rights reserved (CC BY)",
+	})
+	wren, _ := species.New(7, "Troglodytes troglodytes", "Troglodyte mignon", "Aves")
+	choices := []quiz.Choice{{Species: robin, IsCorrect: true}, {Species: wren}}
+	return quiz.NewQuestion("q-sound", quizType, difficulty, robin, choices, robin.Sounds()[0].FileURL)
+}
+
+// ptr returns a pointer to v, for optional request fields.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestHandler_HandleStartSession_Filter(t *testing.T) {
+	players := memory.NewPlayerRepository()
+	player, _ := gamification.NewPlayer("botanist", "botanist")
+	if err := players.Create(context.Background(), player); err != nil {
+		t.Fatal(err)
+	}
+	sessions := memory.NewSessionRepository()
+	service := appquiz.NewService(photoFactory{}, sessions, players, nil)
+	handler := httphandler.NewHandler(service)
+
+	tests := []struct {
+		name       string
+		request    httphandler.StartSessionRequest
+		wantStatus int
+		want       quiz.Filter
+	}{
+		{
+			name: "orchids in spring",
+			request: httphandler.StartSessionRequest{
+				TaxonID: 47217, PlaceID: 6753, Months: []int{6}, Season: "spring",
+			},
+			wantStatus: http.StatusOK,
+			want: quiz.Filter{
+				TaxonID: 47217, PlaceID: 6753,
+				Months: quiz.NewMonths(time.March, time.April, time.May, time.June),
+			},
+		},
+		{
+			name: "around a trailhead",
+			request: httphandler.StartSessionRequest{
+				Lat: ptr(45.832622), Lng: ptr(6.865174),
+			},
+			wantStatus: http.StatusOK,
+			want:       quiz.Filter{Area: quiz.Area{Lat: 45.83, Lng: 6.87, RadiusKm: quiz.DefaultAreaRadiusKm}},
+		},
+		{
+			name:       "latitude without longitude",
+			request:    httphandler.StartSessionRequest{Lat: ptr(45.8), RadiusKm: 5},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "radius too large",
+			request:    httphandler.StartSessionRequest{Lat: ptr(45.8), Lng: ptr(6.8), RadiusKm: 500},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown season",
+			request:    httphandler.StartSessionRequest{Season: "monsoon"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "month out of range",
+			request:    httphandler.StartSessionRequest{Months: []int{13}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown iconic taxon",
+			request:    httphandler.StartSessionRequest{TaxonFilter: "Dragons"},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.request.UserID = "botanist"
+			body, _ := json.Marshal(tt.request)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/quiz/start", bytes.NewReader(body))
+			rec := httptest.NewRecorder()
+			handler.HandleStartSession(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("HandleStartSession() status = %d, want %d, body %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			var response struct {
+				Data httphandler.StartSessionResponse `json:"data"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			session, err := sessions.GetByID(context.Background(), response.Data.SessionID)
+			if err != nil {
+				t.Fatalf("GetByID() error = %v", err)
+			}
+			if session.Filter() != tt.want {
+				t.Errorf("session Filter() = %+v, want %+v", session.Filter(), tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler_HandleStartSession_SoundQuestion(t *testing.T) {
+	players := memory.NewPlayerRepository()
+	player, _ := gamification.NewPlayer("birder", "birder")
+	if err := players.Create(context.Background(), player); err != nil {
+		t.Fatal(err)
+	}
+	service := appquiz.NewService(soundFactory{}, memory.NewSessionRepository(), players, nil)
+	handler := httphandler.NewHandler(service)
+
+	body, _ := json.Marshal(httphandler.StartSessionRequest{UserID: "birder", QuizTypes: []string{"sound"}})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/quiz/start", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.HandleStartSession(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleStartSession() status = %d, body %s", rec.Code, rec.Body)
+	}
+	var response struct {
+		Data httphandler.StartSessionResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	question := response.Data.Question
+	if question.QuizType != "sound" || question.MediaURL != "https://example.com/song.mp3" {
+		t.Errorf("question = %+v, want a sound question playing the song", question)
+	}
+	if question.Audio == nil || question.Audio.ContentType != "audio/mpeg" || question.Audio.Attribution == "" {
+		t.Errorf("question audio = %+v, want the recording metadata", question.Audio)
+	}
+}
+
+func TestHandler_HandleStartSession_CheckedFilter(t *testing.T) {
+	players := memory.NewPlayerRepository()
+	player, _ := gamification.NewPlayer("botanist", "botanist")
+	if err := players.Create(context.Background(), player); err != nil {
+		t.Fatal(err)
+	}
+	orchids, err := catalog.NewSpeciesRepository(&catalog.Catalog{
+		Version: catalog.Version,
+		Species: []catalog.Entry{{Snapshot: species.Snapshot{
+			ID: 47585, ScientificName: "Ophrys apifera", Rank: species.RankSpecies, AncestorIDs: []int{47217, 47585},
+		}}},
+		Ancestors: []species.Taxon{{ID: 47217, Name: "Orchidaceae", Rank: species.RankFamily}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessions := memory.NewSessionRepository()
+	service := appquiz.NewService(photoFactory{}, sessions, players, nil,
+		appquiz.WithSpecies(orchids),
+		appquiz.WithPlaces(&stubPlaces{}),
+	)
+	handler := httphandler.NewHandler(service)
+
+	tests := []struct {
+		name       string
+		request    httphandler.StartSessionRequest
+		wantStatus int
+	}{
+		{"taxon name", httphandler.StartSessionRequest{Taxon: "Orchidaceae", PlaceID: 6906}, http.StatusOK},
+		{"unknown taxon name", httphandler.StartSessionRequest{Taxon: "Dragons"}, http.StatusBadRequest},
+		{"unknown taxon", httphandler.StartSessionRequest{TaxonID: 999999}, http.StatusBadRequest},
+		{"unknown place", httphandler.StartSessionRequest{PlaceID: 1}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.request.UserID = "botanist"
+			body, _ := json.Marshal(tt.request)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/quiz/start", bytes.NewReader(body))
+			rec := httptest.NewRecorder()
+			handler.HandleStartSession(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("HandleStartSession() status = %d, want %d, body %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+		})
+	}
+}
+
+// photoFactory creates questions showing a fox photo.
+type photoFactory struct{}
+
+func (photoFactory) CreateQuestion(
+	_ context.Context,
+	quizType quiz.QuizType,
+	difficulty quiz.Difficulty,
+	_ quiz.Filter,
+) (*quiz.Question, error) {
+	fox, _ := species.New(42069, "Vulpes vulpes", "Renard roux", "Mammalia")
+	fox.AddPhoto(species.Photo{
+		ID:             3,
+		LargeURL:       "https://example.com/fox.jpg",
+		Attribution:    "(c) someone, some rights reserved (CC BY)",
+		LicenseCode:    "cc-by",
+		ObservationURL: "https://www.inaturalist.org/observations/7",
+	})
+	badger, _ := species.New(41709, "Meles meles", "Blaireau europeen", "Mammalia")
+	choices := []quiz.Choice{{Species: fox, IsCorrect: true}, {Species: badger}}
+	q, err := quiz.NewQuestion("q-"+string(quizType), quizType, difficulty, fox, choices, "https://example.com/fox.jpg")
+	if err != nil {
+		return nil, err
+	}
+	q.SetPhotoID(3)
+	return q, nil
+}
+
+// stubMediaServer serves the fox photo.
+type stubMediaServer struct{}
+
+func (stubMediaServer) Open(_ context.Context, ref string) (*ports.Media, error) {
+	if ref == "https://example.com/fox.jpg" {
+		return &ports.Media{ContentType: "image/jpeg", Data: []byte("jpeg")}, nil
+	}
+	return nil, ports.ErrMediaNotFound
+}
+
+func TestHandler_HandleGetMedia(t *testing.T) {
+	tests := []struct {
+		quizType       string
+		wantSecondCode int
+	}{
+		{quizType: "image", wantSecondCode: http.StatusOK},
+		{quizType: "flash", wantSecondCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.quizType, func(t *testing.T) {
+			players := memory.NewPlayerRepository()
+			player, _ := gamification.NewPlayer("player", "player")
+			if err := players.Create(context.Background(), player); err != nil {
+				t.Fatal(err)
+			}
+			tokens, err := media.NewTokens([]byte("secret"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			service := appquiz.NewService(photoFactory{}, memory.NewSessionRepository(), players, nil)
+			handler := httphandler.NewHandler(service, httphandler.WithMediaProxy(stubMediaServer{}, tokens))
+			mux := http.NewServeMux()
+			handler.RegisterRoutes(mux)
+
+			body, _ := json.Marshal(httphandler.StartSessionRequest{UserID: "player", QuizTypes: []string{tt.quizType}})
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/quiz/start", bytes.NewReader(body)))
+			var response struct {
+				Data httphandler.StartSessionResponse `json:"data"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			mediaURL := response.Data.Question.MediaURL
+			if !strings.HasPrefix(mediaURL, "/api/v1/media/") || strings.Contains(mediaURL, "example.com") {
+				t.Fatalf("MediaURL = %s, want a media token", mediaURL)
+			}
+
+			rec = httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, mediaURL, nil))
+			if rec.Code != http.StatusOK || rec.Body.String() != "jpeg" {
+				t.Fatalf("first fetch status = %d body %q, want the photo", rec.Code, rec.Body)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "image/jpeg" {
+				t.Errorf("Content-Type = %q, want image/jpeg", got)
+			}
+
+			rec = httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, mediaURL, nil))
+			if rec.Code != tt.wantSecondCode {
+				t.Errorf("second fetch status = %d, want %d", rec.Code, tt.wantSecondCode)
+			}
+
+			rec = httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, mediaURL+"x", nil))
+			if rec.Code != http.StatusForbidden {
+				t.Errorf("forged token status = %d, want %d", rec.Code, http.StatusForbidden)
+			}
+		})
+	}
+}
+
+// failingMediaServer fails its first Open, then serves the fox photo.
+type failingMediaServer struct {
+	failed bool
+}
+
+func (s *failingMediaServer) Open(ctx context.Context, ref string) (*ports.Media, error) {
+	if !s.failed {
+		s.failed = true
+		return nil, errors.New("upstream unavailable")
+	}
+	return stubMediaServer{}.Open(ctx, ref)
+}
+
+func TestHandler_HandleGetMedia_RetryAfterFailure(t *testing.T) {
+	players := memory.NewPlayerRepository()
+	player, _ := gamification.NewPlayer("player", "player")
+	if err := players.Create(context.Background(), player); err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := media.NewTokens([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := appquiz.NewService(photoFactory{}, memory.NewSessionRepository(), players, nil)
+	handler := httphandler.NewHandler(service, httphandler.WithMediaProxy(&failingMediaServer{}, tokens))
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	body, _ := json.Marshal(httphandler.StartSessionRequest{UserID: "player", QuizTypes: []string{"flash"}})
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/quiz/start", bytes.NewReader(body)))
+	var response struct {
+		Data httphandler.StartSessionResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	mediaURL := response.Data.Question.MediaURL
+
+	for i, want := range []int{http.StatusBadGateway, http.StatusOK, http.StatusForbidden} {
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, mediaURL, nil))
+		if rec.Code != want {
+			t.Errorf("fetch %d status = %d, want %d", i+1, rec.Code, want)
+		}
+	}
+}
+
+func TestHandler_HandleSubmitAnswer_Credit(t *testing.T) {
+	players := memory.NewPlayerRepository()
+	player, _ := gamification.NewPlayer("player", "player")
+	if err := players.Create(context.Background(), player); err != nil {
+		t.Fatal(err)
+	}
+	sessions := memory.NewSessionRepository()
+	service := appquiz.NewService(photoFactory{}, sessions, players, nil)
+	handler := httphandler.NewHandler(service)
+
+	started, err := service.StartSession(context.Background(), appquiz.StartSessionRequest{UserID: "player"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := json.Marshal(httphandler.SubmitAnswerRequest{SessionID: started.SessionID, SpeciesID: 41709})
+	rec := httptest.NewRecorder()
+	handler.HandleSubmitAnswer(rec, httptest.NewRequest(http.MethodPost, "/api/v1/quiz/answer", bytes.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleSubmitAnswer() status = %d, body %s", rec.Code, rec.Body)
+	}
+	var response struct {
+		Data httphandler.SubmitAnswerResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := httphandler.CreditDTO{
+		Attribution:    "(c) someone, some rights reserved (CC BY)",
+		LicenseCode:    "cc-by",
+		ObservationURL: "https://www.inaturalist.org/observations/7",
+	}
+	if response.Data.Credit == nil || *response.Data.Credit != want {
+		t.Errorf("Credit = %+v, want %+v", response.Data.Credit, want)
+	}
+}
@@ -0,0 +1,246 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	appleaderboard "github.com/Naturieux-fr/Naturieux.fr/internal/application/leaderboard"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// LeaderboardEntryDTO represents a ranked player for API responses.
+type LeaderboardEntryDTO struct {
+	Rank     int    `json:"rank"`
+	PlayerID string `json:"player_id"`
+	Username string `json:"username"`
+	Level    int    `json:"level"`
+	Score    int    `json:"score"`
+}
+
+// LeaderboardPageDTO represents a leaderboard page for API responses.
+type LeaderboardPageDTO struct {
+	Entries    []LeaderboardEntryDTO `json:"entries"`
+	Total      int                   `json:"total"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+}
+
+// LeaderboardStandingDTO represents a player's rank and neighbors for API responses.
+type LeaderboardStandingDTO struct {
+	Player    LeaderboardEntryDTO   `json:"player"`
+	Neighbors []LeaderboardEntryDTO `json:"neighbors"`
+}
+
+// LeaderboardScopeDTO describes the scope and period of a scoped leaderboard.
+type LeaderboardScopeDTO struct {
+	Window      string `json:"window"`
+	Period      string `json:"period"`
+	IconicTaxon string `json:"iconic_taxon,omitempty"`
+	Difficulty  string `json:"difficulty,omitempty"`
+}
+
+// ScoreLeaderboardDTO represents a scoped leaderboard page for API responses.
+type ScoreLeaderboardDTO struct {
+	Scope LeaderboardScopeDTO `json:"scope"`
+	LeaderboardPageDTO
+}
+
+// ScoreStandingDTO represents a player's rank on a scoped leaderboard for API responses.
+type ScoreStandingDTO struct {
+	Scope LeaderboardScopeDTO `json:"scope"`
+	LeaderboardStandingDTO
+}
+
+// HandleGetXPLeaderboard handles GET /api/v1/leaderboard?limit=&offset=&cursor=
+func (h *Handler) HandleGetXPLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	pageReq, err := pageFromQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.leaderboardService.GetXPLeaderboard(r.Context(), pageReq)
+	if err != nil {
+		writeLeaderboardError(w, err)
+		return
+	}
+
+	writeSuccess(w, LeaderboardPageDTO{
+		Entries:    entriesToDTO(page.Entries),
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// HandleGetXPStanding handles GET /api/v1/leaderboard/players/{id}?radius=
+func (h *Handler) HandleGetXPStanding(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	radius, err := queryInt(r.URL.Query().Get("radius"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid radius")
+		return
+	}
+
+	standing, err := h.leaderboardService.GetXPStanding(r.Context(), r.PathValue("id"), radius)
+	if err != nil {
+		writeLeaderboardError(w, err)
+		return
+	}
+
+	writeSuccess(w, LeaderboardStandingDTO{
+		Player:    entryToDTO(standing.Player),
+		Neighbors: entriesToDTO(standing.Neighbors),
+	})
+}
+
+// HandleGetScoreLeaderboard handles
+// GET /api/v1/leaderboard/scores?window=&taxon=&difficulty=&limit=&offset=&cursor=
+func (h *Handler) HandleGetScoreLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	pageReq, err := pageFromQuery(query)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	scope := scopeFromQuery(query)
+	page, err := h.leaderboardService.GetScoreLeaderboard(r.Context(), scope, pageReq)
+	if err != nil {
+		writeLeaderboardError(w, err)
+		return
+	}
+
+	writeSuccess(w, ScoreLeaderboardDTO{
+		Scope: scopeToDTO(scope),
+		LeaderboardPageDTO: LeaderboardPageDTO{
+			Entries:    entriesToDTO(page.Entries),
+			Total:      page.Total,
+			NextCursor: page.NextCursor,
+		},
+	})
+}
+
+// HandleGetScoreStanding handles
+// GET /api/v1/leaderboard/scores/players/{id}?window=&taxon=&difficulty=&radius=
+func (h *Handler) HandleGetScoreStanding(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	radius, err := queryInt(query.Get("radius"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid radius")
+		return
+	}
+
+	scope := scopeFromQuery(query)
+	standing, err := h.leaderboardService.GetScoreStanding(r.Context(), scope, r.PathValue("id"), radius)
+	if err != nil {
+		writeLeaderboardError(w, err)
+		return
+	}
+
+	writeSuccess(w, ScoreStandingDTO{
+		Scope: scopeToDTO(scope),
+		LeaderboardStandingDTO: LeaderboardStandingDTO{
+			Player:    entryToDTO(standing.Player),
+			Neighbors: entriesToDTO(standing.Neighbors),
+		},
+	})
+}
+
+// pageFromQuery reads the limit, offset and cursor query parameters.
+func pageFromQuery(query url.Values) (ports.PageRequest, error) {
+	limit, err := queryInt(query.Get("limit"))
+	if err != nil {
+		return ports.PageRequest{}, errors.New("invalid limit")
+	}
+	offset, err := queryInt(query.Get("offset"))
+	if err != nil {
+		return ports.PageRequest{}, errors.New("invalid offset")
+	}
+	return ports.PageRequest{Offset: offset, Cursor: query.Get("cursor"), Limit: limit}, nil
+}
+
+// scopeFromQuery reads the window, taxon and difficulty query parameters.
+// The window defaults to all time; validation is left to the service.
+func scopeFromQuery(query url.Values) ports.LeaderboardScope {
+	window := ports.TimeWindow(query.Get("window"))
+	if window == "" {
+		window = ports.AllTime
+	}
+	return ports.LeaderboardScope{
+		Window:      window,
+		IconicTaxon: query.Get("taxon"),
+		Difficulty:  quiz.Difficulty(query.Get("difficulty")),
+	}
+}
+
+// scopeToDTO converts a scope to a DTO for the current period.
+func scopeToDTO(scope ports.LeaderboardScope) LeaderboardScopeDTO {
+	return LeaderboardScopeDTO{
+		Window:      string(scope.Window),
+		Period:      scope.Window.Period(time.Now()),
+		IconicTaxon: scope.IconicTaxon,
+		Difficulty:  string(scope.Difficulty),
+	}
+}
+
+// queryInt parses an optional integer query parameter, defaulting to zero.
+func queryInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// writeLeaderboardError maps leaderboard errors to HTTP responses.
+func writeLeaderboardError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ports.ErrInvalidCursor),
+		errors.Is(err, appleaderboard.ErrInvalidPage),
+		errors.Is(err, appleaderboard.ErrInvalidScope):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writePlayerError(w, err)
+	}
+}
+
+// entriesToDTO converts leaderboard entries to DTOs.
+func entriesToDTO(entries []ports.LeaderboardEntry) []LeaderboardEntryDTO {
+	dtos := make([]LeaderboardEntryDTO, len(entries))
+	for i, e := range entries {
+		dtos[i] = entryToDTO(e)
+	}
+	return dtos
+}
+
+// entryToDTO converts a leaderboard entry to a DTO.
+func entryToDTO(e ports.LeaderboardEntry) LeaderboardEntryDTO {
+	return LeaderboardEntryDTO{
+		Rank:     e.Rank,
+		PlayerID: e.PlayerID,
+		Username: e.Username,
+		Level:    e.Level,
+		Score:    e.Score,
+	}
+}
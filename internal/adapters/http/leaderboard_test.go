@@ -0,0 +1,155 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httphandler "github.com/Naturieux-fr/Naturieux.fr/internal/adapters/http"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/memory"
+	appleaderboard "github.com/Naturieux-fr/Naturieux.fr/internal/application/leaderboard"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// newLeaderboardMux creates a mux serving the leaderboards over players a..e
+// with decreasing XP, along with the scoped board it reads.
+func newLeaderboardMux(t *testing.T) (*http.ServeMux, *memory.ScoreLeaderboard) {
+	t.Helper()
+	repo := memory.NewPlayerRepository()
+	for i, id := range []string{"a", "b", "c", "d", "e"} {
+		player, _ := gamification.NewPlayer(id, "user_"+id)
+		player.AddXP(500 - i*100)
+		repo.Create(context.Background(), player)
+	}
+
+	scores := memory.NewScoreLeaderboard(repo)
+	service := appleaderboard.NewService(repo, scores)
+	handler := httphandler.NewHandler(nil, httphandler.WithLeaderboardService(service))
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+	return mux, scores
+}
+
+func getJSON(mux *http.ServeMux, path string, data any) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	json.NewDecoder(rec.Body).Decode(&struct {
+		Data any `json:"data"`
+	}{Data: data})
+	return rec
+}
+
+func TestHandler_HandleGetXPLeaderboard(t *testing.T) {
+	mux, _ := newLeaderboardMux(t)
+
+	var first httphandler.LeaderboardPageDTO
+	rec := getJSON(mux, "/api/v1/leaderboard?limit=2", &first)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleGetXPLeaderboard() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if len(first.Entries) != 2 || first.Entries[0].Username != "user_a" || first.Total != 5 || first.NextCursor == "" {
+		t.Fatalf("first page = %+v", first)
+	}
+
+	var second httphandler.LeaderboardPageDTO
+	getJSON(mux, "/api/v1/leaderboard?limit=2&cursor="+first.NextCursor, &second)
+	if len(second.Entries) != 2 || second.Entries[0].PlayerID != "c" || second.Entries[0].Rank != 3 {
+		t.Errorf("second page = %+v", second)
+	}
+
+	var byOffset httphandler.LeaderboardPageDTO
+	getJSON(mux, "/api/v1/leaderboard?offset=4", &byOffset)
+	if len(byOffset.Entries) != 1 || byOffset.Entries[0].PlayerID != "e" || byOffset.NextCursor != "" {
+		t.Errorf("offset page = %+v", byOffset)
+	}
+}
+
+func TestHandler_HandleGetXPStanding(t *testing.T) {
+	mux, _ := newLeaderboardMux(t)
+
+	var standing httphandler.LeaderboardStandingDTO
+	rec := getJSON(mux, "/api/v1/leaderboard/players/b?radius=1", &standing)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleGetXPStanding() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if standing.Player.PlayerID != "b" || standing.Player.Rank != 2 || standing.Player.Score != 400 {
+		t.Errorf("HandleGetXPStanding() player = %+v", standing.Player)
+	}
+	if len(standing.Neighbors) != 3 || standing.Neighbors[0].PlayerID != "a" || standing.Neighbors[2].PlayerID != "c" {
+		t.Errorf("HandleGetXPStanding() neighbors = %+v", standing.Neighbors)
+	}
+}
+
+func TestHandler_LeaderboardErrors(t *testing.T) {
+	mux, _ := newLeaderboardMux(t)
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"invalid cursor", "/api/v1/leaderboard?cursor=%25%25", http.StatusBadRequest},
+		{"invalid limit", "/api/v1/leaderboard?limit=ten", http.StatusBadRequest},
+		{"negative offset", "/api/v1/leaderboard?offset=-1", http.StatusBadRequest},
+		{"invalid radius", "/api/v1/leaderboard/players/a?radius=x", http.StatusBadRequest},
+		{"unknown player", "/api/v1/leaderboard/players/missing", http.StatusNotFound},
+		{"invalid window", "/api/v1/leaderboard/scores?window=yearly", http.StatusBadRequest},
+		{"invalid taxon", "/api/v1/leaderboard/scores?taxon=Dinosauria", http.StatusBadRequest},
+		{"invalid difficulty", "/api/v1/leaderboard/scores/players/a?difficulty=hard", http.StatusBadRequest},
+		{"unranked player", "/api/v1/leaderboard/scores/players/a?window=daily", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("GET %s status = %d, want %d", tt.path, rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler_ScoreLeaderboard(t *testing.T) {
+	mux, scores := newLeaderboardMux(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	weeklyBirds := ports.LeaderboardScope{Window: ports.Weekly, IconicTaxon: "Aves"}
+	scores.AddScores(ctx, "c", now, map[ports.LeaderboardScope]int{weeklyBirds: 300})
+	scores.AddScores(ctx, "a", now, map[ports.LeaderboardScope]int{weeklyBirds: 100})
+
+	var page httphandler.ScoreLeaderboardDTO
+	rec := getJSON(mux, "/api/v1/leaderboard/scores?window=weekly&taxon=Aves", &page)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleGetScoreLeaderboard() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if page.Scope.Window != "weekly" || page.Scope.IconicTaxon != "Aves" || page.Scope.Period != ports.Weekly.Period(now) {
+		t.Errorf("scope = %+v", page.Scope)
+	}
+	if page.Total != 2 || page.Entries[0].PlayerID != "c" || page.Entries[0].Score != 300 {
+		t.Errorf("entries = %+v", page.Entries)
+	}
+
+	var standing httphandler.ScoreStandingDTO
+	rec = getJSON(mux, "/api/v1/leaderboard/scores/players/a?window=weekly&taxon=Aves", &standing)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleGetScoreStanding() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if standing.Player.Rank != 2 || len(standing.Neighbors) != 2 {
+		t.Errorf("standing = %+v", standing)
+	}
+
+	var empty httphandler.ScoreLeaderboardDTO
+	getJSON(mux, "/api/v1/leaderboard/scores", &empty)
+	if empty.Scope.Window != "all_time" || empty.Total != 0 {
+		t.Errorf("all-time page = %+v", empty)
+	}
+}
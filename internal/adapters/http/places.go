@@ -0,0 +1,100 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/place"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Place search limits.
+const (
+	defaultPlaceLimit = 10
+	maxPlaceLimit     = 20
+)
+
+// PlaceDTO represents a place for API responses.
+type PlaceDTO struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	DisplayName string     `json:"display_name,omitempty"`
+	Level       string     `json:"level,omitempty"`
+	Ancestors   []PlaceDTO `json:"ancestors,omitempty"`
+}
+
+// HandleSearchPlaces handles GET /api/v1/places?q=&limit=
+func (h *Handler) HandleSearchPlaces(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		writeError(w, http.StatusBadRequest, "q is required")
+		return
+	}
+	limit, err := queryInt(r.URL.Query().Get("limit"))
+	if err != nil || limit < 0 {
+		writeError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	if limit == 0 {
+		limit = defaultPlaceLimit
+	}
+
+	places, err := h.places.SearchPlaces(r.Context(), query, min(limit, maxPlaceLimit))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	dtos := make([]PlaceDTO, len(places))
+	for i, p := range places {
+		dtos[i] = placeToDTO(p)
+	}
+	writeSuccess(w, dtos)
+}
+
+// HandleGetPlace handles GET /api/v1/places/{id}
+func (h *Handler) HandleGetPlace(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid place id")
+		return
+	}
+
+	p, err := h.places.GetPlace(r.Context(), id)
+	if errors.Is(err, ports.ErrPlaceNotFound) {
+		writeError(w, http.StatusNotFound, "place not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSuccess(w, placeToDTO(*p))
+}
+
+// placeToDTO converts a domain Place to a DTO.
+func placeToDTO(p place.Place) PlaceDTO {
+	dto := PlaceDTO{
+		ID:          p.ID,
+		Name:        p.Name,
+		DisplayName: p.DisplayName,
+		Level:       string(p.Level),
+	}
+	for _, ancestor := range p.Ancestors {
+		dto.Ancestors = append(dto.Ancestors, placeToDTO(ancestor))
+	}
+	return dto
+}
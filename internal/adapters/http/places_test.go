@@ -0,0 +1,183 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httphandler "github.com/Naturieux-fr/Naturieux.fr/internal/adapters/http"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/memory"
+	appplayer "github.com/Naturieux-fr/Naturieux.fr/internal/application/player"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/place"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// isere is the Isere department with its ancestors.
+var isere = place.Place{
+	ID: 6906, Name: "Isère", DisplayName: "Isère, FR", Level: place.Department,
+	Ancestors: []place.Place{
+		{ID: 6753, Name: "France", Level: place.Country},
+		{ID: 190498, Name: "Auvergne-Rhône-Alpes", Level: place.Region},
+	},
+}
+
+// stubPlaces knows the Isere department only and records search limits.
+type stubPlaces struct {
+	limits []int
+}
+
+func (s *stubPlaces) SearchPlaces(_ context.Context, _ string, limit int) ([]place.Place, error) {
+	s.limits = append(s.limits, limit)
+	return []place.Place{isere}, nil
+}
+
+func (s *stubPlaces) GetPlace(_ context.Context, id int) (*place.Place, error) {
+	if id != isere.ID {
+		return nil, ports.ErrPlaceNotFound
+	}
+	p := isere
+	return &p, nil
+}
+
+// newPlaceMux creates a mux serving the place and player endpoints.
+func newPlaceMux(places ports.PlaceRepository, repo *memory.PlayerRepository) *http.ServeMux {
+	handler := httphandler.NewHandler(nil,
+		httphandler.WithPlaces(places),
+		httphandler.WithPlayerService(appplayer.NewService(repo, appplayer.WithPlaces(places))),
+	)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+	return mux
+}
+
+func TestHandler_HandleSearchPlaces(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		want      int
+		wantLimit int
+	}{
+		{"default limit", "?q=Is%C3%A8re", http.StatusOK, 10},
+		{"limit", "?q=Is%C3%A8re&limit=5", http.StatusOK, 5},
+		{"limit capped", "?q=Is%C3%A8re&limit=100", http.StatusOK, 20},
+		{"missing query", "?q=+", http.StatusBadRequest, 0},
+		{"invalid limit", "?q=Is%C3%A8re&limit=-1", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			places := &stubPlaces{}
+			mux := newPlaceMux(places, memory.NewPlayerRepository())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/places"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("HandleSearchPlaces() status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.wantLimit != 0 && (len(places.limits) != 1 || places.limits[0] != tt.wantLimit) {
+				t.Errorf("SearchPlaces() limits = %v, want %d", places.limits, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestHandler_HandleGetPlace(t *testing.T) {
+	mux := newPlaceMux(&stubPlaces{}, memory.NewPlayerRepository())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/places/6906", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleGetPlace() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var response struct {
+		Data httphandler.PlaceDTO `json:"data"`
+	}
+	json.NewDecoder(rec.Body).Decode(&response)
+
+	if response.Data.ID != 6906 || response.Data.Level != "department" || len(response.Data.Ancestors) != 2 {
+		t.Fatalf("HandleGetPlace() data = %+v", response.Data)
+	}
+	if region := response.Data.Ancestors[1]; region.ID != 190498 || region.Level != "region" {
+		t.Errorf("region = %+v, want Auvergne-Rhone-Alpes", region)
+	}
+}
+
+func TestHandler_HandleGetPlace_Errors(t *testing.T) {
+	mux := newPlaceMux(&stubPlaces{}, memory.NewPlayerRepository())
+
+	for path, want := range map[string]int{
+		"/api/v1/places/abc": http.StatusBadRequest,
+		"/api/v1/places/0":   http.StatusBadRequest,
+		"/api/v1/places/1":   http.StatusNotFound,
+	} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != want {
+			t.Errorf("GET %s status = %d, want %d", path, rec.Code, want)
+		}
+	}
+}
+
+func TestHandler_PlaceEndpoints_Disabled(t *testing.T) {
+	mux := newPlayerMux(memory.NewPlayerRepository())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/places?q=Is%C3%A8re", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET /api/v1/places status = %d, want %d without places", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandler_HandleSetHomePlace(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	player, _ := gamification.NewPlayer("p1", "naturelover")
+	repo.Create(context.Background(), player)
+	mux := newPlaceMux(&stubPlaces{}, repo)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want int
+	}{
+		{"known place", "/api/v1/players/p1/home-place", `{"place_id": 6906}`, http.StatusOK},
+		{"unknown place", "/api/v1/players/p1/home-place", `{"place_id": 1}`, http.StatusBadRequest},
+		{"invalid body", "/api/v1/players/p1/home-place", `{"place_id": "Isère"}`, http.StatusBadRequest},
+		{"unknown player", "/api/v1/players/missing/home-place", `{"place_id": 6906}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("HandleSetHomePlace() status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/players/p1", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	var response struct {
+		Data httphandler.PlayerDTO `json:"data"`
+	}
+	json.NewDecoder(rec.Body).Decode(&response)
+	if response.Data.HomePlaceID != 6906 {
+		t.Errorf("HomePlaceID = %d, want 6906", response.Data.HomePlaceID)
+	}
+}
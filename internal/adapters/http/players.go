@@ -0,0 +1,232 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	appplayer "github.com/Naturieux-fr/Naturieux.fr/internal/application/player"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// CreatePlayerRequest represents a request to register a player.
+type CreatePlayerRequest struct {
+	Username string `json:"username"`
+}
+
+// SetHomePlaceRequest represents a request to set a player's home place.
+type SetHomePlaceRequest struct {
+	PlaceID int `json:"place_id"` // 0 unsets the home place
+}
+
+// PlayerDTO represents a player profile for API responses.
+type PlayerDTO struct {
+	ID               string         `json:"id"`
+	Username         string         `json:"username"`
+	Level            int            `json:"level"`
+	TotalXP          int            `json:"total_xp"`
+	XPProgress       float64        `json:"xp_progress"`
+	XPToNextLevel    int            `json:"xp_to_next_level"`
+	TotalGames       int            `json:"total_games"`
+	Accuracy         float64        `json:"accuracy"`
+	DailyStreak      int            `json:"daily_streak"`
+	BestStreak       int            `json:"best_streak"`
+	CorrectByTaxon   map[string]int `json:"correct_by_taxon"`
+	AchievementCount int            `json:"achievement_count"`
+	HomePlaceID      int            `json:"home_place_id,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+}
+
+// AchievementDTO represents an achievement for API responses.
+type AchievementDTO struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	XPReward    int    `json:"xp_reward"`
+	Unlocked    bool   `json:"unlocked"`
+	Tier        string `json:"tier,omitempty"`
+
+	Tiers    []AchievementTierDTO    `json:"tiers,omitempty"`
+	Progress *AchievementProgressDTO `json:"progress,omitempty"`
+}
+
+// AchievementTierDTO represents a tier of a tiered achievement for API responses.
+type AchievementTierDTO struct {
+	Tier     string `json:"tier"`
+	Target   int    `json:"target"`
+	XPReward int    `json:"xp_reward"`
+	Unlocked bool   `json:"unlocked"`
+}
+
+// AchievementProgressDTO represents a count towards an achievement, or its next tier,
+// for API responses.
+type AchievementProgressDTO struct {
+	Metric  string `json:"metric"`
+	Taxon   string `json:"taxon,omitempty"`
+	Current int    `json:"current"`
+	Target  int    `json:"target"`
+}
+
+// HandleCreatePlayer handles POST /api/v1/players
+func (h *Handler) HandleCreatePlayer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req CreatePlayerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	player, err := h.playerService.Register(r.Context(), req.Username)
+	switch {
+	case errors.Is(err, appplayer.ErrInvalidUsername):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, appplayer.ErrUsernameTaken):
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, Response{
+		Success: true,
+		Data:    playerToDTO(player),
+	})
+}
+
+// HandleGetPlayer handles GET /api/v1/players/{id}
+func (h *Handler) HandleGetPlayer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	player, err := h.playerService.GetProfile(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writePlayerError(w, err)
+		return
+	}
+
+	writeSuccess(w, playerToDTO(player))
+}
+
+// HandleGetPlayerAchievements handles GET /api/v1/players/{id}/achievements
+func (h *Handler) HandleGetPlayerAchievements(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	statuses, err := h.playerService.GetAchievements(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writePlayerError(w, err)
+		return
+	}
+
+	achievements := make([]AchievementDTO, len(statuses))
+	for i, s := range statuses {
+		achievements[i] = achievementToDTO(s)
+	}
+
+	writeSuccess(w, achievements)
+}
+
+// HandleSetHomePlace handles PUT /api/v1/players/{id}/home-place
+func (h *Handler) HandleSetHomePlace(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req SetHomePlaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	player, err := h.playerService.SetHomePlace(r.Context(), r.PathValue("id"), req.PlaceID)
+	if errors.Is(err, appplayer.ErrInvalidPlace) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writePlayerError(w, err)
+		return
+	}
+
+	writeSuccess(w, playerToDTO(player))
+}
+
+// achievementToDTO converts an achievement status to a DTO.
+func achievementToDTO(s appplayer.AchievementStatus) AchievementDTO {
+	dto := AchievementDTO{
+		ID:          string(s.Info.ID),
+		Name:        s.Info.Name,
+		Description: s.Info.Description,
+		Icon:        s.Info.Icon,
+		XPReward:    s.Info.XPReward,
+		Unlocked:    s.Unlocked,
+		Tier:        string(s.Tier),
+	}
+
+	// Tiers are unlocked in order, up to the highest one reached
+	reached := s.Tier != ""
+	for _, t := range s.Info.Tiers {
+		dto.Tiers = append(dto.Tiers, AchievementTierDTO{
+			Tier:     string(t.Tier),
+			Target:   t.Target,
+			XPReward: t.XPReward,
+			Unlocked: reached,
+		})
+		if t.Tier == s.Tier {
+			reached = false
+		}
+	}
+
+	if s.Progress != nil {
+		dto.Progress = &AchievementProgressDTO{
+			Metric:  string(s.Progress.Metric),
+			Taxon:   s.Progress.Taxon,
+			Current: s.Progress.Current,
+			Target:  s.Progress.Target,
+		}
+	}
+	return dto
+}
+
+// writePlayerError maps player lookup errors to HTTP responses.
+func writePlayerError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ports.ErrPlayerNotFound) {
+		writeError(w, http.StatusNotFound, "player not found")
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
+
+// playerToDTO converts a domain Player to a DTO.
+func playerToDTO(p *gamification.Player) PlayerDTO {
+	return PlayerDTO{
+		ID:               p.ID(),
+		Username:         p.Username(),
+		Level:            p.Level(),
+		TotalXP:          p.TotalXP(),
+		XPProgress:       p.XPProgress(),
+		XPToNextLevel:    p.XPToNextLevel(),
+		TotalGames:       p.TotalGames(),
+		Accuracy:         p.Accuracy(),
+		DailyStreak:      p.DailyStreak(),
+		BestStreak:       p.BestStreak(),
+		CorrectByTaxon:   p.CorrectByTaxon(),
+		AchievementCount: len(p.Achievements()),
+		HomePlaceID:      p.HomePlaceID(),
+		CreatedAt:        p.CreatedAt(),
+	}
+}
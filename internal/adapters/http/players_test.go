@@ -0,0 +1,229 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httphandler "github.com/Naturieux-fr/Naturieux.fr/internal/adapters/http"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/memory"
+	appplayer "github.com/Naturieux-fr/Naturieux.fr/internal/application/player"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+)
+
+// newPlayerMux creates a mux serving the player endpoints on the given repository.
+func newPlayerMux(repo *memory.PlayerRepository) *http.ServeMux {
+	handler := httphandler.NewHandler(nil, httphandler.WithPlayerService(appplayer.NewService(repo)))
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+	return mux
+}
+
+func postPlayer(mux *http.ServeMux, username string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(httphandler.CreatePlayerRequest{Username: username})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/players", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_HandleCreatePlayer(t *testing.T) {
+	mux := newPlayerMux(memory.NewPlayerRepository())
+
+	rec := postPlayer(mux, "naturelover")
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("HandleCreatePlayer() status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+
+	var response struct {
+		Success bool                  `json:"success"`
+		Data    httphandler.PlayerDTO `json:"data"`
+	}
+	json.NewDecoder(rec.Body).Decode(&response)
+
+	if response.Data.ID == "" || response.Data.Username != "naturelover" || response.Data.Level != 1 {
+		t.Errorf("HandleCreatePlayer() data = %+v", response.Data)
+	}
+}
+
+func TestHandler_HandleCreatePlayer_Errors(t *testing.T) {
+	mux := newPlayerMux(memory.NewPlayerRepository())
+	postPlayer(mux, "naturelover")
+
+	tests := []struct {
+		name     string
+		username string
+		want     int
+	}{
+		{"duplicate username", "naturelover", http.StatusConflict},
+		{"invalid username", "a", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := postPlayer(mux, tt.username); rec.Code != tt.want {
+				t.Errorf("HandleCreatePlayer() status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler_HandleCreatePlayer_InvalidJSON(t *testing.T) {
+	mux := newPlayerMux(memory.NewPlayerRepository())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/players", bytes.NewBufferString("invalid json"))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("HandleCreatePlayer() status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandler_HandleGetPlayer(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	player, _ := gamification.NewPlayer("p1", "naturelover")
+	player.AddXP(150)
+	repo.Create(context.Background(), player)
+	mux := newPlayerMux(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/players/p1", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleGetPlayer() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var response struct {
+		Data httphandler.PlayerDTO `json:"data"`
+	}
+	json.NewDecoder(rec.Body).Decode(&response)
+
+	if response.Data.Level != 2 || response.Data.TotalXP != 150 {
+		t.Errorf("HandleGetPlayer() level/xp = %d/%d, want 2/150", response.Data.Level, response.Data.TotalXP)
+	}
+	if response.Data.XPToNextLevel != player.XPToNextLevel() {
+		t.Errorf("XPToNextLevel = %d, want %d", response.Data.XPToNextLevel, player.XPToNextLevel())
+	}
+}
+
+func TestHandler_HandleGetPlayer_NotFound(t *testing.T) {
+	mux := newPlayerMux(memory.NewPlayerRepository())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/players/missing", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("HandleGetPlayer() status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandler_HandleGetPlayerAchievements(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	player, _ := gamification.NewPlayer("p1", "naturelover")
+	answers := make([]gamification.AnswerSummary, 4)
+	for i := range answers {
+		answers[i] = gamification.AnswerSummary{IconicTaxon: "Insecta", Correct: true}
+	}
+	player.RecordGame(gamification.GameSummary{Correct: 5, Total: 10, MaxStreak: 2, Answers: answers})
+	repo.Create(context.Background(), player)
+	mux := newPlayerMux(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/players/p1/achievements", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleGetPlayerAchievements() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var response struct {
+		Data []httphandler.AchievementDTO `json:"data"`
+	}
+	json.NewDecoder(rec.Body).Decode(&response)
+
+	if len(response.Data) != len(gamification.AllAchievements()) {
+		t.Fatalf("achievements len = %d, want %d", len(response.Data), len(gamification.AllAchievements()))
+	}
+	for _, a := range response.Data {
+		if a.ID == string(gamification.FirstGame) && !a.Unlocked {
+			t.Error("first_game should be unlocked")
+		}
+		if a.ID == string(gamification.Veteran) && a.Unlocked {
+			t.Error("veteran should be locked")
+		}
+		if a.ID == string(gamification.BugHunter) {
+			if a.Progress == nil || a.Progress.Current != 4 || a.Progress.Target != 10 || a.Progress.Taxon != "Insecta" {
+				t.Errorf("bug_hunter progress = %+v, want 4/10 Insecta", a.Progress)
+			}
+			if len(a.Tiers) != 3 || a.Tiers[0].Unlocked || a.Tier != "" {
+				t.Errorf("bug_hunter tier = %q, tiers = %+v, want 3 locked tiers", a.Tier, a.Tiers)
+			}
+		}
+	}
+}
+
+func TestHandler_PlayerEndpoints_WrongMethod(t *testing.T) {
+	mux := newPlayerMux(memory.NewPlayerRepository())
+
+	for _, path := range []string{"/api/v1/players/p1", "/api/v1/players/p1/achievements", "/api/v1/players/p1/home-place"} {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("POST %s status = %d, want %d", path, rec.Code, http.StatusMethodNotAllowed)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/players", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /api/v1/players status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHandler_HandleGetPlayerAchievements_Tiers(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	player, _ := gamification.NewPlayer("p1", "naturelover")
+	answers := make([]gamification.AnswerSummary, 120)
+	for i := range answers {
+		answers[i] = gamification.AnswerSummary{IconicTaxon: "Aves", Correct: true}
+	}
+	player.RecordGame(gamification.GameSummary{Correct: 120, Total: 120, MaxStreak: 120, Answers: answers})
+	repo.Create(context.Background(), player)
+	mux := newPlayerMux(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/players/p1/achievements", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	var response struct {
+		Data []httphandler.AchievementDTO `json:"data"`
+	}
+	json.NewDecoder(rec.Body).Decode(&response)
+
+	for _, a := range response.Data {
+		if a.ID != string(gamification.BirdWatcher) {
+			continue
+		}
+		if a.Tier != "silver" || a.Progress == nil || a.Progress.Current != 120 || a.Progress.Target != 1000 {
+			t.Errorf("bird_watcher tier = %q, progress = %+v, want silver with 120/1000", a.Tier, a.Progress)
+		}
+		unlocked := make([]bool, len(a.Tiers))
+		for i, tier := range a.Tiers {
+			unlocked[i] = tier.Unlocked
+		}
+		if len(unlocked) != 3 || !unlocked[0] || !unlocked[1] || unlocked[2] {
+			t.Errorf("bird_watcher tiers unlocked = %v, want bronze and silver", unlocked)
+		}
+		return
+	}
+	t.Error("bird_watcher missing from achievements")
+}
@@ -19,6 +19,8 @@ const (
 	defaultBaseURL   = "https://api.inaturalist.org/v1"
 	defaultUserAgent = "Naturieux/1.0 (https://naturieux.fr)"
 	defaultTimeout   = 10 * time.Second
+
+	observationPageURL = "https://www.inaturalist.org/observations"
 )
 
 // Client is an iNaturalist API client.
@@ -27,6 +29,12 @@ type Client struct {
 	httpClient  *http.Client
 	userAgent   string
 	rateLimiter *rateLimiter
+	retryPolicy RetryPolicy
+
+	rateInterval time.Duration
+	rateBurst    int
+	dailyQuota   int
+	quotaReserve int
 }
 
 // ClientOption configures the client.
@@ -53,6 +61,24 @@ func WithUserAgent(ua string) ClientOption {
 	}
 }
 
+// WithRateLimit sets the minimum interval between requests and how many
+// requests may be sent at once after an idle period.
+func WithRateLimit(interval time.Duration, burst int) ClientOption {
+	return func(c *Client) {
+		c.rateInterval = interval
+		c.rateBurst = burst
+	}
+}
+
+// WithDailyQuota sets the requests allowed per UTC day, reserve of which are
+// kept for high-priority requests (see ports.WithPriority).
+func WithDailyQuota(limit, reserve int) ClientOption {
+	return func(c *Client) {
+		c.dailyQuota = limit
+		c.quotaReserve = reserve
+	}
+}
+
 // NewClient creates a new iNaturalist client.
 func NewClient(opts ...ClientOption) *Client {
 	c := &Client{
@@ -60,33 +86,25 @@ func NewClient(opts ...ClientOption) *Client {
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
-		userAgent:   defaultUserAgent,
-		rateLimiter: newRateLimiter(time.Second),
+		userAgent:    defaultUserAgent,
+		rateInterval: defaultRateInterval,
+		rateBurst:    defaultRateBurst,
+		dailyQuota:   defaultDailyQuota,
+		quotaReserve: defaultQuotaReserve,
+		retryPolicy:  defaultRetryPolicy,
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	c.rateLimiter = newRateLimiter(c.rateInterval, c.rateBurst, c.dailyQuota, c.quotaReserve)
 	return c
 }
 
-// rateLimiter ensures we respect rate limits.
-type rateLimiter struct {
-	interval time.Duration
-	lastCall time.Time
-}
-
-func newRateLimiter(interval time.Duration) *rateLimiter {
-	return &rateLimiter{interval: interval}
-}
-
-func (r *rateLimiter) wait() {
-	elapsed := time.Since(r.lastCall)
-	if elapsed < r.interval {
-		time.Sleep(r.interval - elapsed)
-	}
-	r.lastCall = time.Now()
+// Quota returns the daily request budget.
+func (c *Client) Quota() Quota {
+	return c.rateLimiter.status()
 }
 
 // API Response structures
@@ -98,9 +116,11 @@ type observationsResponse struct {
 
 type observation struct {
 	ID           int     `json:"id"`
+	URI          string  `json:"uri"`
 	SpeciesGuess string  `json:"species_guess"`
 	Taxon        *taxon  `json:"taxon"`
 	Photos       []photo `json:"photos"`
+	Sounds       []sound `json:"sounds"`
 	Location     string  `json:"location"`
 	PlaceGuess   string  `json:"place_guess"`
 }
@@ -123,6 +143,15 @@ type photo struct {
 	OriginalURL string `json:"original_url"`
 	SquareURL   string `json:"square_url"`
 	Attribution string `json:"attribution"`
+	LicenseCode string `json:"license_code"`
+}
+
+type sound struct {
+	ID              int    `json:"id"`
+	FileURL         string `json:"file_url"`
+	FileContentType string `json:"file_content_type"`
+	Attribution     string `json:"attribution"`
+	LicenseCode     string `json:"license_code"`
 }
 
 type taxaResponse struct {
@@ -130,15 +159,35 @@ type taxaResponse struct {
 	Results      []taxon `json:"results"`
 }
 
-// doRequest performs an HTTP request with rate limiting.
+// doRequest performs a GET request with rate limiting, retrying transient failures.
 func (c *Client) doRequest(ctx context.Context, endpoint string, params url.Values) (*http.Response, error) {
-	c.rateLimiter.wait()
-
 	reqURL := fmt.Sprintf("%s%s", c.baseURL, endpoint)
 	if len(params) > 0 {
 		reqURL = fmt.Sprintf("%s?%s", reqURL, params.Encode())
 	}
 
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doAttempt(ctx, reqURL)
+		if err == nil {
+			return resp, nil
+		}
+
+		delay, retry := c.retryPolicy.backoff(attempt, err)
+		if !retry {
+			return nil, err
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, fmt.Errorf("waiting to retry: %w", err)
+		}
+	}
+}
+
+// doAttempt sends a single GET request once the rate limiter allows it.
+func (c *Client) doAttempt(ctx context.Context, reqURL string) (*http.Response, error) {
+	if err := c.rateLimiter.wait(ctx); err != nil {
+		return nil, fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
@@ -154,7 +203,7 @@ func (c *Client) doRequest(ctx context.Context, endpoint string, params url.Valu
 
 	if resp.StatusCode != http.StatusOK {
 		_ = resp.Body.Close() // Error ignored: we're already returning an error
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, newStatusError(resp)
 	}
 
 	return resp, nil
@@ -177,7 +226,7 @@ func (c *Client) GetByID(ctx context.Context, id int) (*species.Species, error)
 	}
 
 	if len(result.Results) == 0 {
-		return nil, fmt.Errorf("species not found: %d", id)
+		return nil, fmt.Errorf("%w: species %d", ErrNotFound, id)
 	}
 
 	return taxonToSpecies(&result.Results[0]), nil
@@ -231,13 +280,39 @@ func (c *Client) applyFilterParams(params url.Values, filter ports.SpeciesFilter
 		params.Set("iconic_taxa", filter.IconicTaxon)
 	}
 
+	if filter.TaxonID > 0 {
+		params.Set("taxon_id", strconv.Itoa(filter.TaxonID))
+	}
+
 	if filter.PlaceID > 0 {
 		params.Set("place_id", strconv.Itoa(filter.PlaceID))
 	}
 
+	if filter.Radius > 0 {
+		params.Set("lat", strconv.FormatFloat(filter.Lat, 'f', -1, 64))
+		params.Set("lng", strconv.FormatFloat(filter.Lng, 'f', -1, 64))
+		params.Set("radius", strconv.FormatFloat(filter.Radius, 'f', -1, 64))
+	}
+
+	if len(filter.Months) > 0 {
+		months := make([]int, len(filter.Months))
+		for i, month := range filter.Months {
+			months[i] = int(month)
+		}
+		params.Set("month", c.formatIDList(months))
+	}
+
+	if filter.HasSounds {
+		params.Set("sounds", "true")
+	}
+
 	if len(filter.ExcludeIDs) > 0 {
 		params.Set("without_taxon_id", c.formatIDList(filter.ExcludeIDs))
 	}
+
+	if len(filter.PhotoLicenses) > 0 {
+		params.Set("photo_license", strings.ToLower(strings.Join(filter.PhotoLicenses, ",")))
+	}
 }
 
 // formatIDList converts a slice of IDs to a comma-separated string.
@@ -265,13 +340,42 @@ func (c *Client) deduplicateObservations(observations []observation) []*species.
 	return speciesList
 }
 
-// observationToSpecies converts an observation to a species with photos.
+// observationToSpecies converts an observation to a species with photos and
+// sounds. The taxon's default photo comes after the observation photos, so
+// questions show and credit the observation.
 func (c *Client) observationToSpecies(obs observation) *species.Species {
-	sp := taxonToSpecies(obs.Taxon)
+	sp := newTaxonSpecies(obs.Taxon)
+	addObservationMedia(sp, obs)
+	addDefaultPhoto(sp, obs.Taxon)
+	return sp
+}
+
+// addObservationMedia adds the photos and playable sounds of an observation to
+// sp, linked to the observation.
+func addObservationMedia(sp *species.Species, obs observation) {
+	observationURL := obs.URI
+	if observationURL == "" {
+		observationURL = fmt.Sprintf("%s/%d", observationPageURL, obs.ID)
+	}
 	for _, p := range obs.Photos {
-		sp.AddPhoto(photoToSpeciesPhoto(&p))
+		photo := photoToSpeciesPhoto(&p)
+		photo.ObservationID = obs.ID
+		photo.ObservationURL = observationURL
+		sp.AddPhoto(photo)
+	}
+	for _, s := range obs.Sounds {
+		if s.FileURL != "" { // Sounds hosted elsewhere, such as SoundCloud, have no file
+			sp.AddSound(species.Sound{
+				ID:              s.ID,
+				FileURL:         s.FileURL,
+				FileContentType: s.FileContentType,
+				Attribution:     s.Attribution,
+				LicenseCode:     s.LicenseCode,
+				ObservationID:   obs.ID,
+				ObservationURL:  observationURL,
+			})
+		}
 	}
-	return sp
 }
 
 // GetSimilar retrieves species in the same genus or family.
@@ -282,36 +386,23 @@ func (c *Client) GetSimilar(ctx context.Context, speciesID int, limit int) ([]*s
 		return nil, err
 	}
 
-	ancestors := sp.AncestorIDs()
-	if len(ancestors) < 2 {
+	// Use the closest ancestor, typically the genus
+	parentID := sp.ParentID()
+	if len(sp.AncestorIDs()) < 2 || parentID == 0 {
 		return nil, fmt.Errorf("not enough ancestor data for species %d", speciesID)
 	}
 
-	// Use the genus (second to last ancestor typically)
-	genusID := ancestors[len(ancestors)-1]
-
-	params := url.Values{}
-	params.Set("taxon_id", strconv.Itoa(genusID))
-	params.Set("rank", "species")
-	params.Set("per_page", strconv.Itoa(min(limit+1, 30))) // +1 to account for excluding target
-
-	resp, err := c.doRequest(ctx, "/taxa", params)
+	candidates, err := c.GetSpeciesInTaxon(ctx, parentID, limit+1) // +1 to account for excluding target
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	var result taxaResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decoding response: %w", err)
-	}
 
 	speciesList := make([]*species.Species, 0, limit)
-	for _, t := range result.Results {
-		if t.ID == speciesID {
+	for _, candidate := range candidates {
+		if candidate.ID() == speciesID {
 			continue // Skip the target species
 		}
-		speciesList = append(speciesList, taxonToSpecies(&t))
+		speciesList = append(speciesList, candidate)
 		if len(speciesList) >= limit {
 			break
 		}
@@ -347,17 +438,61 @@ func (c *Client) Search(ctx context.Context, query string, limit int) ([]*specie
 	return speciesList, nil
 }
 
-// taxonToSpecies converts an API taxon to a domain Species.
+// SearchTaxa searches for active taxa of any rank by name, such as a family.
+func (c *Client) SearchTaxa(ctx context.Context, query string, limit int) ([]species.Taxon, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("is_active", "true")
+	params.Set("per_page", strconv.Itoa(min(limit, 30)))
+
+	resp, err := c.doRequest(ctx, "/taxa/autocomplete", params)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result taxaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	taxa := make([]species.Taxon, 0, len(result.Results))
+	for _, t := range result.Results {
+		taxa = append(taxa, t.toTaxon())
+	}
+	return taxa, nil
+}
+
+// toTaxon converts an API taxon to a domain Taxon.
+func (t taxon) toTaxon() species.Taxon {
+	return species.Taxon{
+		ID:         t.ID,
+		Name:       t.Name,
+		CommonName: t.PreferredCommonName,
+		Rank:       t.Rank,
+	}
+}
+
+// taxonToSpecies converts an API taxon to a domain Species with its default photo.
 func taxonToSpecies(t *taxon) *species.Species {
+	sp := newTaxonSpecies(t)
+	addDefaultPhoto(sp, t)
+	return sp
+}
+
+// newTaxonSpecies converts an API taxon to a domain Species without photos.
+func newTaxonSpecies(t *taxon) *species.Species {
 	sp, _ := species.New(t.ID, t.Name, t.PreferredCommonName, t.IconicTaxonName)
 	sp.SetAncestorIDs(t.AncestorIDs)
 	sp.SetRank(t.Rank)
+	return sp
+}
 
+// addDefaultPhoto adds the default photo of a taxon to sp, if any.
+func addDefaultPhoto(sp *species.Species, t *taxon) {
 	if t.DefaultPhoto != nil {
 		sp.AddPhoto(photoToSpeciesPhoto(t.DefaultPhoto))
 	}
-
-	return sp
 }
 
 // photoToSpeciesPhoto converts an API photo to a domain Photo.
@@ -370,5 +505,6 @@ func photoToSpeciesPhoto(p *photo) species.Photo {
 		OriginalURL: p.OriginalURL,
 		SquareURL:   p.SquareURL,
 		Attribution: p.Attribution,
+		LicenseCode: p.LicenseCode,
 	}
 }
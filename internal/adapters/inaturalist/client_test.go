@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/inaturalist"
 	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
@@ -275,6 +276,43 @@ func TestClient_Search(t *testing.T) {
 	}
 }
 
+func TestClient_SearchTaxa(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/taxa/autocomplete" {
+			t.Errorf("Expected path /taxa/autocomplete, got %s", r.URL.Path)
+		}
+		if rank := r.URL.Query().Get("rank"); rank != "" {
+			t.Errorf("Expected no rank, got %s", rank)
+		}
+
+		response := map[string]interface{}{
+			"total_results": 1,
+			"results": []map[string]interface{}{
+				{
+					"id":                    47217,
+					"name":                  "Orchidaceae",
+					"rank":                  "family",
+					"preferred_common_name": "Orchids",
+				},
+			},
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := inaturalist.NewClient(
+		inaturalist.WithBaseURL(server.URL),
+	)
+
+	taxa, err := client.SearchTaxa(context.Background(), "Orchidaceae", 10)
+	if err != nil {
+		t.Fatalf("SearchTaxa() error = %v", err)
+	}
+	if len(taxa) != 1 || taxa[0].ID != 47217 || taxa[0].Rank != "family" {
+		t.Errorf("SearchTaxa() = %+v, want the Orchidaceae family", taxa)
+	}
+}
+
 func TestClient_HTTPError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -406,11 +444,14 @@ func TestClient_GetSimilar_NotEnoughAncestors(t *testing.T) {
 	}
 }
 
-func TestClient_GetRandom_WithExcludeIDs(t *testing.T) {
+func TestClient_GetRandom_WithTaxonAndExcludeIDs(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		query := r.URL.Query()
-		if query.Get("without_taxon_id") == "" {
-			t.Error("Expected without_taxon_id parameter")
+		if query.Get("without_taxon_id") != "100,200" {
+			t.Errorf("without_taxon_id = %q, want 100,200", query.Get("without_taxon_id"))
+		}
+		if query.Get("taxon_id") != "40151" {
+			t.Errorf("taxon_id = %q, want 40151", query.Get("taxon_id"))
 		}
 
 		response := map[string]interface{}{
@@ -437,12 +478,117 @@ func TestClient_GetRandom_WithExcludeIDs(t *testing.T) {
 	)
 
 	filter := ports.SpeciesFilter{
+		TaxonID:    40151,
 		ExcludeIDs: []int{100, 200},
 	}
 
 	_, err := client.GetRandom(context.Background(), filter)
 	if err != nil {
-		t.Fatalf("GetRandom() with exclude IDs error = %v", err)
+		t.Fatalf("GetRandom() with taxon and exclude IDs error = %v", err)
+	}
+}
+
+func TestClient_GetRandom_WithSounds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sounds") != "true" {
+			t.Errorf("sounds = %q, want true", r.URL.Query().Get("sounds"))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []map[string]interface{}{{
+				"id":    1,
+				"taxon": map[string]interface{}{"id": 13094, "name": "Erithacus rubecula"},
+				"sounds": []map[string]interface{}{
+					{"id": 5, "file_url": "https://example.com/5.mp3", "file_content_type": "audio/mpeg",
+						"attribution": "(c) someone, some rights reserved (CC BY)"},
+					{"id": 6, "native_sound_id": "soundcloud-6"}, // Hosted elsewhere
+				},
+			}},
+		})
+	}))
+	defer server.Close()
+
+	client := inaturalist.NewClient(inaturalist.WithBaseURL(server.URL))
+	got, err := client.GetRandom(context.Background(), ports.SpeciesFilter{HasSounds: true})
+	if err != nil {
+		t.Fatalf("GetRandom() with sounds error = %v", err)
+	}
+	if len(got) != 1 || len(got[0].Sounds()) != 1 {
+		t.Fatalf("GetRandom() = %v, want one species with one playable sound", got)
+	}
+	if sound := got[0].Sounds()[0]; sound.FileContentType != "audio/mpeg" || sound.Attribution == "" {
+		t.Errorf("sound = %+v, want its content type and attribution", sound)
+	}
+}
+
+func TestClient_GetRandom_PhotoLicenses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("photo_license"); got != "cc-by,cc0" {
+			t.Errorf("photo_license = %q, want cc-by,cc0", got)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []map[string]interface{}{{
+				"id":  42,
+				"uri": "https://www.inaturalist.org/observations/42",
+				"taxon": map[string]interface{}{"id": 42069, "name": "Vulpes vulpes",
+					"default_photo": map[string]interface{}{"id": 9, "medium_url": "https://example.com/9.jpg",
+						"license_code": "cc-by"}},
+				"photos": []map[string]interface{}{
+					{"id": 1, "medium_url": "https://example.com/1.jpg", "license_code": "cc-by",
+						"attribution": "(c) someone, some rights reserved (CC BY)"},
+				},
+				"sounds": []map[string]interface{}{
+					{"id": 5, "file_url": "https://example.com/5.mp3", "license_code": "cc0"},
+				},
+			}},
+		})
+	}))
+	defer server.Close()
+
+	client := inaturalist.NewClient(inaturalist.WithBaseURL(server.URL))
+	got, err := client.GetRandom(context.Background(), ports.SpeciesFilter{PhotoLicenses: []string{"CC-BY", "cc0"}})
+	if err != nil {
+		t.Fatalf("GetRandom() error = %v", err)
+	}
+	if len(got) != 1 || len(got[0].Photos()) != 2 {
+		t.Fatalf("GetRandom() = %v, want one species with the observation and default photos", got)
+	}
+	// The observation photo comes first, so questions credit the observation
+	photo := got[0].Photos()[0]
+	if photo.LicenseCode != "cc-by" || photo.ObservationID != 42 ||
+		photo.ObservationURL != "https://www.inaturalist.org/observations/42" {
+		t.Errorf("photo = %+v, want its license and observation", photo)
+	}
+	if sound := got[0].Sounds()[0]; sound.LicenseCode != "cc0" || sound.ObservationID != 42 {
+		t.Errorf("sound = %+v, want its license and observation", sound)
+	}
+}
+
+func TestClient_GetRandom_LocationAndMonths(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("place_id") != "6753" {
+			t.Errorf("place_id = %q, want 6753", query.Get("place_id"))
+		}
+		if query.Get("lat") != "45.83" || query.Get("lng") != "6.86" || query.Get("radius") != "10" {
+			t.Errorf("area = %q, %q, %q, want 45.83, 6.86, 10", query.Get("lat"), query.Get("lng"), query.Get("radius"))
+		}
+		if query.Get("month") != "12,1,2" {
+			t.Errorf("month = %q, want 12,1,2", query.Get("month"))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": []map[string]interface{}{}})
+	}))
+	defer server.Close()
+
+	client := inaturalist.NewClient(inaturalist.WithBaseURL(server.URL))
+	_, err := client.GetRandom(context.Background(), ports.SpeciesFilter{
+		PlaceID: 6753,
+		Lat:     45.83,
+		Lng:     6.86,
+		Radius:  10,
+		Months:  []time.Month{time.December, time.January, time.February},
+	})
+	if err != nil {
+		t.Fatalf("GetRandom() error = %v", err)
 	}
 }
 
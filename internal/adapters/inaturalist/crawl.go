@@ -0,0 +1,124 @@
+package inaturalist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Maximum number of results of the /taxa endpoints per request.
+const maxTaxaPerRequest = 30
+
+// ListObservedSpecies returns the species of one page of research-grade
+// observations with photos matching the filter, with the photos of all their
+// observations on the page. Pages start at 1; an empty result is the last page.
+func (c *Client) ListObservedSpecies(
+	ctx context.Context,
+	filter ports.SpeciesFilter,
+	page int,
+) ([]*species.Species, error) {
+	params := url.Values{}
+	params.Set("photos", "true")
+	params.Set("quality_grade", "research")
+	params.Set("identified", "true")
+	params.Set("order_by", "votes")
+	c.applyFilterParams(params, filter)
+	params.Set("page", strconv.Itoa(max(page, 1)))
+
+	resp, err := c.doRequest(ctx, "/observations", params)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result observationsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return c.mergeObservations(result.Results), nil
+}
+
+// mergeObservations extracts unique species from observations, keeping the
+// photos and sounds of every observation, then the taxon's default photo.
+func (c *Client) mergeObservations(observations []observation) []*species.Species {
+	byID := make(map[int]*species.Species)
+	speciesList := make([]*species.Species, 0, len(observations))
+	taxa := make([]*taxon, 0, len(observations))
+
+	for _, obs := range observations {
+		if obs.Taxon == nil {
+			continue
+		}
+		sp, ok := byID[obs.Taxon.ID]
+		if !ok {
+			sp = newTaxonSpecies(obs.Taxon)
+			byID[obs.Taxon.ID] = sp
+			speciesList = append(speciesList, sp)
+			taxa = append(taxa, obs.Taxon)
+		}
+		addObservationMedia(sp, obs)
+	}
+	for i, sp := range speciesList {
+		addDefaultPhoto(sp, taxa[i])
+	}
+
+	return speciesList
+}
+
+// GetTaxa retrieves taxa of any rank by ID, such as the ancestors of a species.
+// Unknown IDs are left out.
+func (c *Client) GetTaxa(ctx context.Context, ids []int) ([]species.Taxon, error) {
+	taxa := make([]species.Taxon, 0, len(ids))
+	for start := 0; start < len(ids); start += maxTaxaPerRequest {
+		batch := ids[start:min(start+maxTaxaPerRequest, len(ids))]
+
+		resp, err := c.doRequest(ctx, "/taxa/"+c.formatIDList(batch), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result taxaResponse
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		_ = resp.Body.Close() // Error ignored: the body has been read
+		if err != nil {
+			return nil, fmt.Errorf("decoding response: %w", err)
+		}
+
+		for _, t := range result.Results {
+			taxa = append(taxa, t.toTaxon())
+		}
+	}
+	return taxa, nil
+}
+
+// GetSpeciesInTaxon retrieves up to limit species descending from a taxon,
+// such as the species of a genus.
+func (c *Client) GetSpeciesInTaxon(ctx context.Context, taxonID int, limit int) ([]*species.Species, error) {
+	params := url.Values{}
+	params.Set("taxon_id", strconv.Itoa(taxonID))
+	params.Set("rank", "species")
+	params.Set("per_page", strconv.Itoa(min(max(limit, 1), maxTaxaPerRequest)))
+
+	resp, err := c.doRequest(ctx, "/taxa", params)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result taxaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	speciesList := make([]*species.Species, 0, len(result.Results))
+	for i := range result.Results {
+		speciesList = append(speciesList, taxonToSpecies(&result.Results[i]))
+	}
+	return speciesList, nil
+}
@@ -0,0 +1,106 @@
+package inaturalist_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/inaturalist"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// newFastClient creates a client for server without rate limit pauses.
+func newFastClient(server *httptest.Server) *inaturalist.Client {
+	return inaturalist.NewClient(
+		inaturalist.WithBaseURL(server.URL),
+		inaturalist.WithRateLimit(time.Millisecond, 100),
+	)
+}
+
+func TestClient_ListObservedSpecies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/observations" || query.Get("page") != "3" || query.Get("place_id") != "6753" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if query.Get("order_by") == "random" {
+			t.Error("crawled pages must not be random")
+		}
+
+		fox := map[string]interface{}{"id": 100, "name": "Vulpes vulpes", "ancestor_ids": []int{1, 2, 100},
+			"default_photo": map[string]interface{}{"id": 9}}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []map[string]interface{}{
+				{"id": 1, "taxon": fox, "photos": []map[string]interface{}{{"id": 1}}},
+				{"id": 2, "taxon": fox, "photos": []map[string]interface{}{{"id": 2}, {"id": 3}}},
+				{"id": 3, "taxon": nil},
+			},
+		})
+	}))
+	defer server.Close()
+
+	filter := ports.SpeciesFilter{PlaceID: 6753, Limit: 200}
+	got, err := newFastClient(server).ListObservedSpecies(context.Background(), filter, 3)
+	if err != nil {
+		t.Fatalf("ListObservedSpecies() error = %v", err)
+	}
+	if len(got) != 1 || len(got[0].Photos()) != 4 || got[0].ParentID() != 2 {
+		t.Fatalf("ListObservedSpecies() = %d species, want one with 4 photos and parent 2", len(got))
+	}
+	// The default photo of the taxon comes after the observation photos
+	if photos := got[0].Photos(); photos[0].ObservationID != 1 || photos[3].ID != 9 {
+		t.Errorf("Photos() = %+v, want the observation photos first", photos)
+	}
+}
+
+func TestClient_GetTaxa(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		ids := strings.Split(strings.TrimPrefix(r.URL.Path, "/taxa/"), ",")
+		results := make([]map[string]interface{}, 0, len(ids))
+		for _, id := range ids {
+			results = append(results, map[string]interface{}{"id": len(results) + 1, "name": "Taxon " + id, "rank": "genus"})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	}))
+	defer server.Close()
+
+	ids := make([]int, 45)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	taxa, err := newFastClient(server).GetTaxa(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("GetTaxa() error = %v", err)
+	}
+
+	if len(paths) != 2 || !strings.HasPrefix(paths[1], "/taxa/31,") {
+		t.Errorf("requested %v, want two batches of at most 30 IDs", paths)
+	}
+	if len(taxa) != 45 || taxa[0].Rank != "genus" {
+		t.Errorf("GetTaxa() returned %d taxa, want 45 genera", len(taxa))
+	}
+}
+
+func TestClient_GetSpeciesInTaxon(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("taxon_id") != "42" || query.Get("rank") != "species" || query.Get("per_page") != "30" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []map[string]interface{}{{"id": 1, "name": "Vulpes zerda", "rank": "species"}},
+		})
+	}))
+	defer server.Close()
+
+	got, err := newFastClient(server).GetSpeciesInTaxon(context.Background(), 42, 100)
+	if err != nil || len(got) != 1 {
+		t.Errorf("GetSpeciesInTaxon() = %d species, %v, want 1", len(got), err)
+	}
+}
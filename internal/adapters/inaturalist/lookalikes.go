@@ -0,0 +1,56 @@
+package inaturalist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+)
+
+type similarSpeciesResponse struct {
+	TotalResults int              `json:"total_results"`
+	Results      []similarSpecies `json:"results"`
+}
+
+type similarSpecies struct {
+	Count int    `json:"count"` // Identifications confusing the two taxa
+	Taxon *taxon `json:"taxon"`
+}
+
+// GetLookalikes retrieves up to limit species that observations of the given
+// one were misidentified as, most confused first. Confused taxa of other
+// ranks, such as genera, are left out.
+func (c *Client) GetLookalikes(ctx context.Context, speciesID int, limit int) ([]*species.Species, error) {
+	params := url.Values{}
+	params.Set("taxon_id", strconv.Itoa(speciesID))
+
+	resp, err := c.doRequest(ctx, "/identifications/similar_species", params)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result similarSpeciesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	sort.SliceStable(result.Results, func(i, j int) bool { return result.Results[i].Count > result.Results[j].Count })
+
+	speciesList := make([]*species.Species, 0, min(limit, len(result.Results)))
+	for _, similar := range result.Results {
+		if len(speciesList) >= limit {
+			break
+		}
+		t := similar.Taxon
+		if t == nil || t.ID == speciesID || t.Rank != species.RankSpecies {
+			continue
+		}
+		speciesList = append(speciesList, taxonToSpecies(t))
+	}
+	return speciesList, nil
+}
@@ -0,0 +1,51 @@
+package inaturalist_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_GetLookalikes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/identifications/similar_species" || r.URL.Query().Get("taxon_id") != "47219" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []map[string]interface{}{
+				{"count": 12, "taxon": map[string]interface{}{"id": 2, "name": "Eristalis tenax", "rank": "species"}},
+				{"count": 40, "taxon": map[string]interface{}{"id": 3, "name": "Vespula vulgaris", "rank": "species",
+					"default_photo": map[string]interface{}{"id": 9, "medium_url": "https://example.com/9.jpg"}}},
+				{"count": 30, "taxon": map[string]interface{}{"id": 4, "name": "Vespula", "rank": "genus"}},
+				{"count": 5, "taxon": map[string]interface{}{"id": 5, "name": "Polistes dominula", "rank": "species"}},
+				{"count": 3},
+			},
+		})
+	}))
+	defer server.Close()
+
+	got, err := newFastClient(server).GetLookalikes(context.Background(), 47219, 2)
+	if err != nil {
+		t.Fatalf("GetLookalikes() error = %v", err)
+	}
+	// Most confused first, genera left out, up to the limit
+	if len(got) != 2 || got[0].ID() != 3 || got[1].ID() != 2 {
+		t.Fatalf("GetLookalikes() = %v, want species 3 then 2", got)
+	}
+	if !got[0].HasPhotos() {
+		t.Error("GetLookalikes() dropped the default photo")
+	}
+}
+
+func TestClient_GetLookalikes_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	if _, err := newFastClient(server).GetLookalikes(context.Background(), 1, 5); err == nil {
+		t.Error("GetLookalikes() error = nil, want the status error")
+	}
+}
@@ -0,0 +1,135 @@
+package inaturalist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/place"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Place search limits.
+const (
+	maxPlacesPerPage    = 20
+	maxPlacesPerRequest = 100 // IDs of one /places/{ids} request
+)
+
+// adminLevels maps iNaturalist admin levels to the administrative hierarchy.
+var adminLevels = map[int]place.Level{
+	0:  place.Country,
+	10: place.Region,
+	20: place.Department,
+	30: place.Town,
+}
+
+type placesResponse struct {
+	TotalResults int        `json:"total_results"`
+	Results      []apiPlace `json:"results"`
+}
+
+type apiPlace struct {
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	DisplayName      string `json:"display_name"`
+	AdminLevel       *int   `json:"admin_level"`        // Null outside the administrative hierarchy
+	AncestorPlaceIDs []int  `json:"ancestor_place_ids"` // From the largest down, ending with the place
+}
+
+// SearchPlaces returns the places whose name starts with query, with their ancestors.
+func (c *Client) SearchPlaces(ctx context.Context, query string, limit int) ([]place.Place, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("per_page", strconv.Itoa(min(max(limit, 1), maxPlacesPerPage)))
+
+	results, err := c.fetchPlaces(ctx, "/places/autocomplete", params)
+	if err != nil {
+		return nil, err
+	}
+	return c.withAncestors(ctx, results)
+}
+
+// GetPlace retrieves a place with its ancestors.
+func (c *Client) GetPlace(ctx context.Context, id int) (*place.Place, error) {
+	results, err := c.fetchPlaces(ctx, "/places/"+strconv.Itoa(id), nil)
+	if errors.Is(err, ErrNotFound) || (err == nil && len(results) == 0) {
+		return nil, fmt.Errorf("%w: %d", ports.ErrPlaceNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	places, err := c.withAncestors(ctx, results[:1])
+	if err != nil {
+		return nil, err
+	}
+	return &places[0], nil
+}
+
+// fetchPlaces requests an endpoint listing places.
+func (c *Client) fetchPlaces(ctx context.Context, endpoint string, params url.Values) ([]apiPlace, error) {
+	resp, err := c.doRequest(ctx, endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result placesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return result.Results, nil
+}
+
+// withAncestors converts places to domain places, looking up the ancestors of
+// all of them at once. Unknown ancestors are left out.
+func (c *Client) withAncestors(ctx context.Context, results []apiPlace) ([]place.Place, error) {
+	seen := make(map[int]bool)
+	var ids []int
+	for _, p := range results {
+		for _, id := range p.AncestorPlaceIDs {
+			if id != p.ID && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	ancestors := make(map[int]place.Place, len(ids))
+	for start := 0; start < len(ids); start += maxPlacesPerRequest {
+		batch := ids[start:min(start+maxPlacesPerRequest, len(ids))]
+		found, err := c.fetchPlaces(ctx, "/places/"+c.formatIDList(batch), nil)
+		if err != nil {
+			return nil, fmt.Errorf("fetching ancestor places: %w", err)
+		}
+		for _, p := range found {
+			ancestors[p.ID] = toPlace(p)
+		}
+	}
+
+	places := make([]place.Place, len(results))
+	for i, p := range results {
+		places[i] = toPlace(p)
+		for _, id := range p.AncestorPlaceIDs {
+			if ancestor, ok := ancestors[id]; ok && id != p.ID {
+				places[i].Ancestors = append(places[i].Ancestors, ancestor)
+			}
+		}
+	}
+	return places, nil
+}
+
+// toPlace converts an API place to a domain place, without its ancestors.
+func toPlace(p apiPlace) place.Place {
+	result := place.Place{ID: p.ID, Name: p.Name, DisplayName: p.DisplayName}
+	if p.AdminLevel != nil {
+		result.Level = adminLevels[*p.AdminLevel]
+	}
+	return result
+}
+
+// Ensure interface compliance
+var _ ports.PlaceRepository = (*Client)(nil)
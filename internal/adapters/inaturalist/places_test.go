@@ -0,0 +1,88 @@
+package inaturalist_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/place"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// placesServer serves Isere and its ancestors: Europe, France and Auvergne-Rhone-Alpes.
+func placesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	places := map[string]map[string]interface{}{
+		"97391":  {"id": 97391, "name": "Europe", "admin_level": nil, "ancestor_place_ids": []int{97391}},
+		"6753":   {"id": 6753, "name": "France", "admin_level": 0, "ancestor_place_ids": []int{97391, 6753}},
+		"190498": {"id": 190498, "name": "Auvergne-Rhône-Alpes", "admin_level": 10},
+		"6906": {"id": 6906, "name": "Isère", "display_name": "Isère, FR", "admin_level": 20,
+			"ancestor_place_ids": []int{97391, 6753, 190498, 6906}},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var results []map[string]interface{}
+		switch r.URL.Path {
+		case "/places/autocomplete":
+			if r.URL.Query().Get("q") != "Isè" || r.URL.Query().Get("per_page") != "5" {
+				t.Errorf("unexpected request %s", r.URL)
+			}
+			results = append(results, places["6906"])
+		case "/places/6906":
+			results = append(results, places["6906"])
+		case "/places/97391,6753,190498":
+			results = append(results, places["6753"], places["190498"], places["97391"])
+		case "/places/1":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	}))
+}
+
+func TestClient_SearchPlaces(t *testing.T) {
+	server := placesServer(t)
+	defer server.Close()
+
+	got, err := newFastClient(server).SearchPlaces(context.Background(), "Isè", 5)
+	if err != nil {
+		t.Fatalf("SearchPlaces() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 6906 || got[0].DisplayName != "Isère, FR" || got[0].Level != place.Department {
+		t.Fatalf("SearchPlaces() = %+v, want the Isere department", got)
+	}
+	// Ancestors in the order iNaturalist lists them, largest first
+	ancestors := got[0].Ancestors
+	if len(ancestors) != 3 || ancestors[0].ID != 97391 || ancestors[1].ID != 6753 || ancestors[2].ID != 190498 {
+		t.Fatalf("Ancestors = %+v, want Europe, France then Auvergne-Rhone-Alpes", ancestors)
+	}
+	if ancestors[0].Level != "" || ancestors[1].Level != place.Country || ancestors[2].Level != place.Region {
+		t.Errorf("Ancestors levels = %q, %q, %q", ancestors[0].Level, ancestors[1].Level, ancestors[2].Level)
+	}
+}
+
+func TestClient_GetPlace(t *testing.T) {
+	server := placesServer(t)
+	defer server.Close()
+
+	got, err := newFastClient(server).GetPlace(context.Background(), 6906)
+	if err != nil {
+		t.Fatalf("GetPlace() error = %v", err)
+	}
+	if region, ok := got.Ancestor(place.Region); !ok || region.ID != 190498 {
+		t.Errorf("Ancestor(Region) = %+v, %v, want Auvergne-Rhone-Alpes", region, ok)
+	}
+}
+
+func TestClient_GetPlace_NotFound(t *testing.T) {
+	server := placesServer(t)
+	defer server.Close()
+
+	if _, err := newFastClient(server).GetPlace(context.Background(), 1); !errors.Is(err, ports.ErrPlaceNotFound) {
+		t.Errorf("GetPlace() error = %v, want ErrPlaceNotFound", err)
+	}
+}
@@ -0,0 +1,176 @@
+package inaturalist
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Default request budget, following the iNaturalist API recommendations.
+const (
+	defaultRateInterval = time.Second
+	defaultRateBurst    = 1
+	defaultDailyQuota   = 10000
+	defaultQuotaReserve = 1000
+)
+
+// Errors returned when the daily request budget does not allow a call.
+var (
+	ErrQuotaExhausted = errors.New("daily request quota exhausted")
+	ErrQuotaReserved  = errors.New("remaining daily quota is reserved for high-priority requests")
+)
+
+// Quota describes the daily request budget.
+type Quota struct {
+	Limit     int       `json:"limit"`     // Requests allowed per UTC day
+	Used      int       `json:"used"`      // Requests sent or scheduled today
+	Remaining int       `json:"remaining"` // Requests left today
+	Reserved  int       `json:"reserved"`  // Remaining requests kept for high-priority calls
+	ResetAt   time.Time `json:"reset_at"`  // Start of the next UTC day
+}
+
+// rateLimiter is a token bucket paced to the API rate, with a daily quota.
+// Low-priority calls are spread over the rest of the day and refused once only
+// the reserve is left.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // Time to refill one token
+	burst    float64
+	tokens   float64 // Negative when calls are scheduled ahead
+	updated  time.Time
+
+	quota   int
+	reserve int
+	used    int
+	day     time.Time // Start of the UTC day counted by used
+	lastLow time.Time // When the latest low-priority call was scheduled
+}
+
+// newRateLimiter creates a limiter allowing one call per interval, bursts of
+// burst calls and quota calls per UTC day, reserve of which for high priority.
+func newRateLimiter(interval time.Duration, burst, quota, reserve int) *rateLimiter {
+	return &rateLimiter{
+		interval: interval,
+		burst:    float64(max(burst, 1)),
+		tokens:   float64(max(burst, 1)),
+		quota:    max(quota, 1),
+		reserve:  min(max(reserve, 0), quota),
+	}
+}
+
+// wait blocks until a call may be sent at the priority of ctx. It fails when
+// ctx is done first or when the daily quota does not allow the call.
+func (r *rateLimiter) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	slot, err := r.schedule(ports.PriorityFrom(ctx))
+	if err != nil {
+		return err
+	}
+	delay := time.Until(slot.at)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		r.release(slot)
+		return ctx.Err()
+	}
+}
+
+// slot is a call booked with the limiter.
+type slot struct {
+	at      time.Time
+	low     bool
+	prevLow time.Time // Low-priority pacing before the booking
+}
+
+// schedule books the next call slot.
+func (r *rateLimiter) schedule(priority ports.RequestPriority) (slot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.advance(now)
+
+	remaining := r.remaining()
+	if remaining <= 0 {
+		return slot{}, ErrQuotaExhausted
+	}
+	if priority == ports.PriorityLow && remaining <= r.reserve {
+		return slot{}, ErrQuotaReserved
+	}
+
+	r.tokens--
+	booked := slot{at: now, low: priority == ports.PriorityLow, prevLow: r.lastLow}
+	if r.tokens < 0 {
+		booked.at = now.Add(time.Duration(-r.tokens * float64(r.interval)))
+	}
+	if booked.low {
+		// Spread the budget above the reserve over the rest of the day
+		pace := r.day.Add(24*time.Hour).Sub(now) / time.Duration(remaining-r.reserve)
+		if paced := r.lastLow.Add(pace); paced.After(booked.at) {
+			booked.at = paced
+		}
+		r.lastLow = booked.at
+	}
+	r.used++
+	return booked, nil
+}
+
+// release gives back a slot abandoned while waiting for it.
+func (r *rateLimiter) release(s slot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens = min(r.tokens+1, r.burst)
+	r.used = max(r.used-1, 0)
+	if s.low && r.lastLow.Equal(s.at) {
+		r.lastLow = s.prevLow
+	}
+}
+
+// advance refills tokens and resets the daily count on a new UTC day.
+func (r *rateLimiter) advance(now time.Time) {
+	if !r.updated.IsZero() && now.After(r.updated) {
+		r.tokens = min(r.tokens+float64(now.Sub(r.updated))/float64(r.interval), r.burst)
+	}
+	if now.After(r.updated) {
+		r.updated = now
+	}
+
+	if day := now.UTC().Truncate(24 * time.Hour); day.After(r.day) {
+		r.day = day
+		r.used = 0
+	}
+}
+
+// remaining returns the calls left today.
+func (r *rateLimiter) remaining() int {
+	return max(r.quota-r.used, 0)
+}
+
+// status returns the current daily budget.
+func (r *rateLimiter) status() Quota {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.advance(time.Now())
+	return Quota{
+		Limit:     r.quota,
+		Used:      r.used,
+		Remaining: r.remaining(),
+		Reserved:  min(r.reserve, r.remaining()),
+		ResetAt:   r.day.Add(24 * time.Hour),
+	}
+}
@@ -0,0 +1,155 @@
+package inaturalist_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/inaturalist"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// newTaxonServer serves a single taxon and counts the requests it receives.
+func newTaxonServer(t *testing.T, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"total_results": 1,
+			"results":       []map[string]interface{}{{"id": 1, "name": "Vulpes vulpes"}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_RateLimit_Concurrent(t *testing.T) {
+	var requests atomic.Int32
+	server := newTaxonServer(t, &requests)
+	client := inaturalist.NewClient(
+		inaturalist.WithBaseURL(server.URL),
+		inaturalist.WithRateLimit(20*time.Millisecond, 1),
+	)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetByID(context.Background(), 1); err != nil {
+				t.Errorf("GetByID() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("5 requests took %v, want at least 80ms at one per 20ms", elapsed)
+	}
+	if requests.Load() != 5 {
+		t.Errorf("server received %d requests, want 5", requests.Load())
+	}
+	if quota := client.Quota(); quota.Used != 5 || quota.Remaining != quota.Limit-5 {
+		t.Errorf("Quota() = %+v, want 5 used", quota)
+	}
+}
+
+func TestClient_RateLimit_ContextCancellation(t *testing.T) {
+	var requests atomic.Int32
+	server := newTaxonServer(t, &requests)
+	client := inaturalist.NewClient(
+		inaturalist.WithBaseURL(server.URL),
+		inaturalist.WithRateLimit(time.Hour, 1),
+	)
+
+	if _, err := client.GetByID(context.Background(), 1); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.GetByID(ctx, 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetByID() error = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GetByID() returned after %v, want as soon as the context is done", elapsed)
+	}
+	if requests.Load() != 1 || client.Quota().Used != 1 {
+		t.Errorf("requests/used = %d/%d, want the abandoned call not counted", requests.Load(), client.Quota().Used)
+	}
+}
+
+func TestClient_DailyQuota(t *testing.T) {
+	var requests atomic.Int32
+	server := newTaxonServer(t, &requests)
+	client := inaturalist.NewClient(
+		inaturalist.WithBaseURL(server.URL),
+		inaturalist.WithRateLimit(0, 1),
+		inaturalist.WithDailyQuota(3, 1),
+	)
+	high := context.Background()
+	low := ports.WithPriority(context.Background(), ports.PriorityLow)
+
+	steps := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{"low priority above reserve", low, nil},
+		{"high priority", high, nil},
+		{"low priority within reserve", low, inaturalist.ErrQuotaReserved},
+		{"high priority within reserve", high, nil},
+		{"quota exhausted", high, inaturalist.ErrQuotaExhausted},
+	}
+	for _, step := range steps {
+		if _, err := client.GetByID(step.ctx, 1); !errors.Is(err, step.wantErr) {
+			t.Errorf("%s: GetByID() error = %v, want %v", step.name, err, step.wantErr)
+		}
+	}
+
+	quota := client.Quota()
+	if quota.Limit != 3 || quota.Used != 3 || quota.Remaining != 0 || quota.Reserved != 0 {
+		t.Errorf("Quota() = %+v, want 3 of 3 used", quota)
+	}
+	if !quota.ResetAt.After(time.Now()) || quota.ResetAt.Sub(time.Now()) > 24*time.Hour {
+		t.Errorf("Quota() ResetAt = %v, want within the next day", quota.ResetAt)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("server received %d requests, want 3", requests.Load())
+	}
+}
+
+func TestClient_DailyQuota_DefersLowPriority(t *testing.T) {
+	var requests atomic.Int32
+	server := newTaxonServer(t, &requests)
+	client := inaturalist.NewClient(
+		inaturalist.WithBaseURL(server.URL),
+		inaturalist.WithRateLimit(0, 1),
+		inaturalist.WithDailyQuota(100, 10),
+	)
+	low := ports.WithPriority(context.Background(), ports.PriorityLow)
+
+	if _, err := client.GetByID(low, 1); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+
+	// The next low-priority call is paced to spread the budget over the day
+	ctx, cancel := context.WithTimeout(low, 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetByID(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetByID() error = %v, want the low-priority call deferred", err)
+	}
+
+	if _, err := client.GetByID(context.Background(), 1); err != nil {
+		t.Errorf("GetByID() high priority error = %v, want no deferral", err)
+	}
+}
@@ -0,0 +1,80 @@
+package inaturalist
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy configures retries of failed GET requests. Rate limiting,
+// gateway errors and transport failures are retried; other errors are not.
+type RetryPolicy struct {
+	MaxAttempts int           // Attempts per request, 1 disables retries
+	BaseDelay   time.Duration // Delay before the first retry, doubled on each one
+	MaxDelay    time.Duration // Longest wait; a longer Retry-After fails the request
+}
+
+// defaultRetryPolicy retries twice, within the default request timeout.
+var defaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    defaultTimeout,
+}
+
+// WithRetry sets the retry policy of GET requests.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// backoff returns the wait before retrying after attempt failed with err,
+// and false when the request should not be retried.
+func (p RetryPolicy) backoff(attempt int, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || !retryable(err) {
+		return 0, false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		return statusErr.RetryAfter, statusErr.RetryAfter <= p.MaxDelay
+	}
+
+	// Equal jitter: half the exponential delay, plus up to as much at random
+	delay := min(p.BaseDelay<<(attempt-1), p.MaxDelay)
+	if delay <= 0 {
+		return 0, true
+	}
+	return delay/2 + rand.N(delay/2+1), true
+}
+
+// retryable reports whether a failed request may succeed if sent again.
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrQuotaExhausted) || errors.Is(err, ErrQuotaReserved) {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.temporary()
+	}
+	return true // Transport failure
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,143 @@
+package inaturalist_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/inaturalist"
+)
+
+// fastRetry retries quickly so tests do not wait on backoff.
+var fastRetry = inaturalist.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Second}
+
+// newFlakyServer answers with the given statuses in turn, with an optional
+// Retry-After header, then serves a taxon.
+func newFlakyServer(t *testing.T, requests *atomic.Int32, retryAfter string, statuses ...int) *httptest.Server {
+	t.Helper()
+	taxa := newTaxonServer(t, new(atomic.Int32))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1))
+		if n <= len(statuses) {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		taxa.Config.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newRetryClient creates a client on the server without rate limiting delays.
+func newRetryClient(serverURL string, policy inaturalist.RetryPolicy) *inaturalist.Client {
+	return inaturalist.NewClient(
+		inaturalist.WithBaseURL(serverURL),
+		inaturalist.WithRateLimit(0, 1),
+		inaturalist.WithRetry(policy),
+	)
+}
+
+func TestClient_Retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		policy       inaturalist.RetryPolicy
+		wantErr      error
+		wantRequests int32
+	}{
+		{"recovers from gateway errors", []int{502, 503}, fastRetry, nil, 3},
+		{"recovers from timeout", []int{504}, fastRetry, nil, 2},
+		{"gives up after max attempts", []int{503, 503, 503, 503}, fastRetry, inaturalist.ErrUpstreamUnavailable, 3},
+		{"does not retry not found", []int{404}, fastRetry, inaturalist.ErrNotFound, 1},
+		{"does not retry server errors", []int{500}, fastRetry, inaturalist.ErrUpstreamUnavailable, 1},
+		{"retries disabled", []int{503}, inaturalist.RetryPolicy{MaxAttempts: 1}, inaturalist.ErrUpstreamUnavailable, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := newFlakyServer(t, &requests, "", tt.statuses...)
+			client := newRetryClient(server.URL, tt.policy)
+
+			_, err := client.GetByID(context.Background(), 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetByID() error = %v, want %v", err, tt.wantErr)
+			}
+			if requests.Load() != tt.wantRequests {
+				t.Errorf("server received %d requests, want %d", requests.Load(), tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestClient_Retry_HonorsRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	server := newFlakyServer(t, &requests, "1", http.StatusTooManyRequests)
+	client := newRetryClient(server.URL, fastRetry)
+
+	start := time.Now()
+	if _, err := client.GetByID(context.Background(), 1); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("GetByID() retried after %v, want Retry-After of 1s honored", elapsed)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("server received %d requests, want 2", requests.Load())
+	}
+}
+
+func TestClient_Retry_RetryAfterTooLong(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+	}{
+		{"seconds", "120"},
+		{"http date", time.Now().Add(2 * time.Minute).UTC().Format(http.TimeFormat)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := newFlakyServer(t, &requests, tt.retryAfter, http.StatusTooManyRequests)
+			client := newRetryClient(server.URL, fastRetry)
+
+			_, err := client.GetByID(context.Background(), 1)
+			if !errors.Is(err, inaturalist.ErrRateLimited) {
+				t.Fatalf("GetByID() error = %v, want ErrRateLimited", err)
+			}
+			var statusErr *inaturalist.StatusError
+			if !errors.As(err, &statusErr) || statusErr.RetryAfter < time.Minute || statusErr.RetryAfter > 2*time.Minute {
+				t.Errorf("GetByID() error = %#v, want a StatusError with RetryAfter ~2m", err)
+			}
+			if requests.Load() != 1 {
+				t.Errorf("server received %d requests, want 1", requests.Load())
+			}
+		})
+	}
+}
+
+func TestClient_Retry_ContextCancellation(t *testing.T) {
+	var requests atomic.Int32
+	server := newFlakyServer(t, &requests, "", 503, 503, 503)
+	client := newRetryClient(server.URL, inaturalist.RetryPolicy{
+		MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.GetByID(ctx, 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetByID() error = %v, want DeadlineExceeded while backing off", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("server received %d requests, want 1", requests.Load())
+	}
+}
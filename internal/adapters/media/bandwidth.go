@@ -0,0 +1,80 @@
+package media
+
+import (
+	"sync"
+	"time"
+)
+
+// Default upstream bandwidth budget, below the 5 GB per hour and 24 GB per day
+// iNaturalist tolerates before blocking media downloads.
+const (
+	defaultHourlyBytes = 4 << 30
+	defaultDailyBytes  = 20 << 30
+)
+
+// Bandwidth describes the upstream media download budget.
+type Bandwidth struct {
+	HourlyLimit int64 `json:"hourly_limit"` // Bytes allowed per hour
+	HourlyUsed  int64 `json:"hourly_used"`  // Bytes downloaded this hour
+	DailyLimit  int64 `json:"daily_limit"`  // Bytes allowed per UTC day
+	DailyUsed   int64 `json:"daily_used"`   // Bytes downloaded today
+}
+
+// bandwidthBudget counts the bytes downloaded per clock hour and UTC day.
+type bandwidthBudget struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	limits Bandwidth
+	hour   time.Time // Start of the hour counted by HourlyUsed
+	day    time.Time // Start of the UTC day counted by DailyUsed
+}
+
+// newBandwidthBudget creates a budget of hourly and daily bytes.
+func newBandwidthBudget(hourly, daily int64, now func() time.Time) *bandwidthBudget {
+	return &bandwidthBudget{
+		now:    now,
+		limits: Bandwidth{HourlyLimit: hourly, DailyLimit: daily},
+	}
+}
+
+// allow reports whether a download may start. Downloads are not sized
+// beforehand: the one crossing a limit completes, the next ones wait.
+func (b *bandwidthBudget) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+	return b.limits.HourlyUsed < b.limits.HourlyLimit && b.limits.DailyUsed < b.limits.DailyLimit
+}
+
+// add counts downloaded bytes.
+func (b *bandwidthBudget) add(n int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+	b.limits.HourlyUsed += n
+	b.limits.DailyUsed += n
+}
+
+// status returns the current budget.
+func (b *bandwidthBudget) status() Bandwidth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+	return b.limits
+}
+
+// advance resets the counts on a new hour or UTC day. The caller holds mu.
+func (b *bandwidthBudget) advance() {
+	now := b.now().UTC()
+	if hour := now.Truncate(time.Hour); hour.After(b.hour) {
+		b.hour = hour
+		b.limits.HourlyUsed = 0
+	}
+	if day := now.Truncate(24 * time.Hour); day.After(b.day) {
+		b.day = day
+		b.limits.DailyUsed = 0
+	}
+}
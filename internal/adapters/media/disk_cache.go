@@ -0,0 +1,168 @@
+package media
+
+import (
+	"container/list"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// diskCache is a directory of files bounded in total size, evicting the least
+// recently used file first. Files left by a previous run are kept, oldest
+// modification first in line for eviction.
+type diskCache struct {
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	files map[string]*list.Element
+	order *list.List // Front is the most recently used
+	size  int64
+}
+
+// cachedFile is a file of the cache and its size.
+type cachedFile struct {
+	name string
+	size int64
+}
+
+// newDiskCache opens the cache in dir, creating the directory when missing.
+func newDiskCache(dir string, maxBytes int64) (*diskCache, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("creating media cache: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading media cache: %w", err)
+	}
+
+	type existing struct {
+		cachedFile
+		modTime int64
+	}
+	var found []existing
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if strings.HasSuffix(entry.Name(), ".tmp") {
+			_ = os.Remove(filepath.Join(dir, entry.Name())) // Error ignored: left by an interrupted write
+			continue
+		}
+		found = append(found, existing{cachedFile{entry.Name(), info.Size()}, info.ModTime().UnixNano()})
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].modTime > found[j].modTime })
+
+	c := &diskCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		files:    make(map[string]*list.Element, len(found)),
+		order:    list.New(),
+	}
+	for _, f := range found {
+		c.files[f.name] = c.order.PushBack(&cachedFile{f.name, f.size})
+		c.size += f.size
+	}
+	c.mu.Lock()
+	c.evict()
+	c.mu.Unlock()
+	return c, nil
+}
+
+// get returns the content of a cached file.
+func (c *diskCache) get(name string) ([]byte, bool) {
+	c.mu.Lock()
+	elem, ok := c.files[name]
+	if ok {
+		c.order.MoveToFront(elem)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, name))
+	if err != nil {
+		c.remove(name)
+		return nil, false
+	}
+	return data, true
+}
+
+// put stores a file, replacing any previous version, then evicts the least
+// recently used files over the size bound.
+func (c *diskCache) put(name string, data []byte) error {
+	tmp, err := os.CreateTemp(c.dir, name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("caching media: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }() // Error ignored: gone once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close() // Error ignored: we're already returning an error
+		return fmt.Errorf("caching media: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("caching media: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := os.Rename(tmp.Name(), filepath.Join(c.dir, name)); err != nil {
+		return fmt.Errorf("caching media: %w", err)
+	}
+	if elem, ok := c.files[name]; ok {
+		file := fileOf(elem)
+		c.size += int64(len(data)) - file.size
+		file.size = int64(len(data))
+		c.order.MoveToFront(elem)
+	} else {
+		c.files[name] = c.order.PushFront(&cachedFile{name, int64(len(data))})
+		c.size += int64(len(data))
+	}
+	c.evict()
+	return nil
+}
+
+// remove forgets a file that could not be read.
+func (c *diskCache) remove(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.files[name]; ok {
+		c.drop(elem)
+	}
+}
+
+// evict deletes the least recently used files until the cache fits its bound,
+// always keeping the most recent one. The caller holds mu.
+func (c *diskCache) evict() {
+	for c.size > c.maxBytes && c.order.Len() > 1 {
+		c.drop(c.order.Back())
+	}
+}
+
+// drop deletes a file of the cache. The caller holds mu.
+func (c *diskCache) drop(elem *list.Element) {
+	file := fileOf(elem)
+	_ = os.Remove(filepath.Join(c.dir, file.name)) // Error ignored: forgotten either way
+	c.order.Remove(elem)
+	delete(c.files, file.name)
+	c.size -= file.size
+}
+
+// stats returns the number of files and their total size.
+func (c *diskCache) stats() (files int, bytes int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len(), c.size
+}
+
+// fileOf returns the file held by a list element.
+func fileOf(elem *list.Element) *cachedFile {
+	file, _ := elem.Value.(*cachedFile) // Only cachedFile values are stored
+	return file
+}
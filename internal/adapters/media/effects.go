@@ -0,0 +1,123 @@
+package media
+
+import (
+	"image"
+	"image/draw"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// downscale returns img shrunk with nearest-neighbour sampling so that its
+// longest side is at most maxSide pixels.
+func downscale(img image.Image, maxSide int) image.Image {
+	b := img.Bounds()
+	longest := max(b.Dx(), b.Dy())
+	if longest <= maxSide {
+		return img
+	}
+
+	w, h := max(b.Dx()*maxSide/longest, 1), max(b.Dy()*maxSide/longest, 1)
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		sy := b.Min.Y + y*b.Dy()/h
+		for x := 0; x < w; x++ {
+			dst.Set(x, y, img.At(b.Min.X+x*b.Dx()/w, sy))
+		}
+	}
+	return dst
+}
+
+// crop returns the region of img, at least one pixel wide and high.
+func crop(img image.Image, region ports.CropRegion) image.Image {
+	b := img.Bounds()
+	x0 := b.Min.X + int(clamp(region.X)*float64(b.Dx()))
+	y0 := b.Min.Y + int(clamp(region.Y)*float64(b.Dy()))
+	x1 := b.Min.X + int(clamp(region.X+region.Width)*float64(b.Dx()))
+	y1 := b.Min.Y + int(clamp(region.Y+region.Height)*float64(b.Dy()))
+	r := image.Rect(x0, y0, max(x1, x0+1), max(y1, y0+1)).Intersect(b)
+
+	dst := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, r.Min, draw.Src)
+	return dst
+}
+
+// silhouette returns the subject of img in black on a white background. Pixels
+// are split into dark and light with Otsu's threshold; the subject is the side
+// least present along the borders of the photo, since photographers frame it
+// away from the edges.
+func silhouette(img image.Image) *image.Gray {
+	b := img.Bounds()
+	gray := image.NewGray(b)
+	draw.Draw(gray, b, img, b.Min, draw.Src)
+
+	var histogram [256]int
+	for _, v := range gray.Pix {
+		histogram[v]++
+	}
+	threshold := otsuThreshold(histogram, len(gray.Pix))
+
+	dark, border := 0, 0
+	count := func(x, y int) {
+		if gray.GrayAt(x, y).Y <= threshold {
+			dark++
+		}
+		border++
+	}
+	for x := b.Min.X; x < b.Max.X; x++ {
+		count(x, b.Min.Y)
+		count(x, b.Max.Y-1)
+	}
+	for y := b.Min.Y + 1; y < b.Max.Y-1; y++ {
+		count(b.Min.X, y)
+		count(b.Max.X-1, y)
+	}
+	darkSubject := dark*2 < border
+
+	out := image.NewGray(b)
+	for i, v := range gray.Pix {
+		if (v <= threshold) == darkSubject {
+			out.Pix[i] = 0 // Black
+		} else {
+			out.Pix[i] = 255 // White
+		}
+	}
+	return out
+}
+
+// otsuThreshold returns the gray level separating the histogram into the two
+// classes of maximal between-class variance.
+func otsuThreshold(histogram [256]int, total int) uint8 {
+	var sum float64
+	for level, count := range histogram {
+		sum += float64(level * count)
+	}
+
+	var sumBackground, bestVariance float64
+	var weightBackground int
+	var best uint8
+	for level, count := range histogram {
+		weightBackground += count
+		weightForeground := total - weightBackground
+		if weightBackground == 0 {
+			continue
+		}
+		if weightForeground == 0 {
+			break
+		}
+		sumBackground += float64(level * count)
+		meanBackground := sumBackground / float64(weightBackground)
+		meanForeground := (sum - sumBackground) / float64(weightForeground)
+		variance := float64(weightBackground) * float64(weightForeground) *
+			(meanBackground - meanForeground) * (meanBackground - meanForeground)
+		if variance > bestVariance {
+			bestVariance = variance
+			best = uint8(level)
+		}
+	}
+	return best
+}
+
+// clamp limits a fraction to [0, 1].
+func clamp(f float64) float64 {
+	return min(max(f, 0), 1)
+}
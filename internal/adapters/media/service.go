@@ -0,0 +1,446 @@
+// Package media derives question images from source photos and serves
+// question media from a bounded disk cache, within an upstream bandwidth budget.
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// errUnknownEffect is returned for derivatives of an unsupported effect.
+var errUnknownEffect = errors.New("unknown image effect")
+
+// Default settings.
+const (
+	defaultMaxCacheBytes  = 256 << 20
+	defaultUserAgent      = "Naturieux/1.0 (https://naturieux.fr)"
+	defaultTimeout        = 10 * time.Second
+	maxSourceBytes        = 20 << 20
+	maxSide               = 1024
+	jpegQuality           = 85
+	idLength              = 32
+	derivativeScheme      = "media:"
+	derivativeSpecDir     = "derivatives"
+	derivativeSpecSuffix  = ".json"
+	derivativeSpecMaxAge  = 7 * 24 * time.Hour
+	derivativeImageSuffix = ".img"
+	sourceSuffix          = ".src"
+)
+
+// Service registers image derivatives and serves question media: derivatives
+// are produced on first request and source media is proxied, both through a
+// disk cache bounded in size. Derivative descriptions are kept outside the
+// bound for a week after their last registration, so an evicted derivative is
+// produced again. Media of an offline catalog is read from its directory.
+type Service struct {
+	httpClient  *http.Client
+	userAgent   string
+	maxBytes    int64
+	hourlyBytes int64
+	dailyBytes  int64
+	now         func() time.Time
+	catalogDir  string // Directory of catalog media, none when empty
+	specDir     string // Directory of derivative descriptions
+	cache       *diskCache
+	bandwidth   *bandwidthBudget
+}
+
+// Option configures the service.
+type Option func(*Service)
+
+// WithHTTPClient sets the client fetching source media.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *Service) {
+		s.httpClient = client
+	}
+}
+
+// WithMaxCacheBytes bounds the total size of the disk cache.
+func WithMaxCacheBytes(n int64) Option {
+	return func(s *Service) {
+		if n > 0 {
+			s.maxBytes = n
+		}
+	}
+}
+
+// WithBandwidthLimits bounds the bytes of source media downloaded per hour and per UTC day.
+func WithBandwidthLimits(hourly, daily int64) Option {
+	return func(s *Service) {
+		s.hourlyBytes = hourly
+		s.dailyBytes = daily
+	}
+}
+
+// WithClock sets the time source of the bandwidth budget.
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+// WithCatalogMedia serves the catalog media references from dir, as written
+// by catalog.Downloader.
+func WithCatalogMedia(dir string) Option {
+	return func(s *Service) {
+		s.catalogDir = dir
+	}
+}
+
+// NewService creates a service caching media in dir.
+func NewService(dir string, opts ...Option) (*Service, error) {
+	s := &Service{
+		httpClient:  &http.Client{Timeout: defaultTimeout},
+		userAgent:   defaultUserAgent,
+		maxBytes:    defaultMaxCacheBytes,
+		hourlyBytes: defaultHourlyBytes,
+		dailyBytes:  defaultDailyBytes,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	cache, err := newDiskCache(dir, s.maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	s.cache = cache
+	s.specDir = filepath.Join(dir, derivativeSpecDir)
+	if err := os.MkdirAll(s.specDir, 0o750); err != nil {
+		return nil, fmt.Errorf("creating derivative directory: %w", err)
+	}
+	s.PruneDerivatives()
+	s.bandwidth = newBandwidthBudget(s.hourlyBytes, s.dailyBytes, s.now)
+	return s, nil
+}
+
+// Register records a derivative and returns its media reference. Equal
+// derivatives share a reference. The source photo is only fetched on first request.
+func (s *Service) Register(_ context.Context, derivative ports.ImageDerivative) (string, error) {
+	if derivative.Effect != ports.EffectCrop && derivative.Effect != ports.EffectSilhouette {
+		return "", fmt.Errorf("%w: %q", errUnknownEffect, derivative.Effect)
+	}
+	spec, err := json.Marshal(derivative)
+	if err != nil {
+		return "", fmt.Errorf("encoding derivative: %w", err)
+	}
+	id := hashID(spec)
+
+	path := filepath.Join(s.specDir, id+derivativeSpecSuffix)
+	now := s.now()
+	if err := os.Chtimes(path, now, now); err == nil {
+		return derivativeScheme + id, nil // Registered before, kept for another week
+	}
+	if err := writeFile(path, spec); err != nil {
+		return "", fmt.Errorf("storing derivative: %w", err)
+	}
+	_ = os.Chtimes(path, now, now) // Error ignored: kept from the write time otherwise
+	return derivativeScheme + id, nil
+}
+
+// PruneDerivatives removes the descriptions of derivatives not registered for
+// a week, and their images, and returns how many were removed.
+func (s *Service) PruneDerivatives() int {
+	entries, err := os.ReadDir(s.specDir)
+	if err != nil {
+		return 0
+	}
+	pruned := 0
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || s.now().Sub(info.ModTime()) < derivativeSpecMaxAge {
+			continue
+		}
+		if os.Remove(filepath.Join(s.specDir, entry.Name())) != nil {
+			continue
+		}
+		if id, ok := strings.CutSuffix(entry.Name(), derivativeSpecSuffix); ok {
+			s.cache.remove(id + derivativeImageSuffix)
+			pruned++
+		}
+	}
+	return pruned
+}
+
+// StartPruning periodically prunes unused derivatives until ctx is cancelled.
+func (s *Service) StartPruning(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.PruneDerivatives()
+			}
+		}
+	}()
+}
+
+// Open returns the media at a source URL, catalog media or derivative
+// reference, from the cache when possible.
+func (s *Service) Open(ctx context.Context, ref string) (*ports.Media, error) {
+	if id, ok := strings.CutPrefix(ref, derivativeScheme); ok {
+		return s.openDerivative(ctx, id)
+	}
+	if name, ok := strings.CutPrefix(ref, ports.CatalogMediaScheme); ok {
+		return s.openCatalogMedia(name)
+	}
+	return s.openSource(ctx, ref)
+}
+
+// openDerivative returns the derivative with the given ID, producing and
+// caching it on first request.
+func (s *Service) openDerivative(ctx context.Context, id string) (*ports.Media, error) {
+	if !validID(id) {
+		return nil, fmt.Errorf("%w: %q", ports.ErrMediaNotFound, id)
+	}
+	spec, err := os.ReadFile(filepath.Join(s.specDir, id+derivativeSpecSuffix))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ports.ErrMediaNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading derivative %s: %w", id, err)
+	}
+	var derivative ports.ImageDerivative
+	if err := json.Unmarshal(spec, &derivative); err != nil {
+		return nil, fmt.Errorf("decoding derivative %s: %w", id, err)
+	}
+
+	if media, ok := s.cached(id + derivativeImageSuffix); ok {
+		return media, nil
+	}
+	media, err := s.derive(ctx, derivative)
+	if err != nil {
+		return nil, fmt.Errorf("deriving %s: %w", id, err)
+	}
+	if err := s.cache.put(id+derivativeImageSuffix, encodeMedia(media)); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// openSource returns the media at a source URL, downloading and caching it on
+// first request.
+func (s *Service) openSource(ctx context.Context, sourceURL string) (*ports.Media, error) {
+	if u, err := url.Parse(sourceURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("%w: %q", ports.ErrMediaNotFound, sourceURL)
+	}
+	name := hashID([]byte(sourceURL)) + sourceSuffix
+	if media, ok := s.cached(name); ok {
+		return media, nil
+	}
+
+	media, err := s.download(ctx, sourceURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.put(name, encodeMedia(media)); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// openCatalogMedia returns a media file of the catalog directory.
+func (s *Service) openCatalogMedia(name string) (*ports.Media, error) {
+	ext := filepath.Ext(name)
+	if s.catalogDir == "" || name != filepath.Base(name) || !validID(strings.TrimSuffix(name, ext)) {
+		return nil, fmt.Errorf("%w: %q", ports.ErrMediaNotFound, name)
+	}
+	data, err := os.ReadFile(filepath.Join(s.catalogDir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ports.ErrMediaNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading catalog media: %w", err)
+	}
+
+	contentType := mime.TypeByExtension(ext)
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	return &ports.Media{ContentType: contentType, Data: data}, nil
+}
+
+// cached returns a media file of the cache.
+func (s *Service) cached(name string) (*ports.Media, bool) {
+	data, ok := s.cache.get(name)
+	if !ok {
+		return nil, false
+	}
+	return decodeMedia(data)
+}
+
+// CacheStats describes the disk cache.
+type CacheStats struct {
+	Files    int   `json:"files"`
+	Bytes    int64 `json:"bytes"`
+	MaxBytes int64 `json:"max_bytes"`
+}
+
+// Stats returns the size of the disk cache.
+func (s *Service) Stats() CacheStats {
+	files, size := s.cache.stats()
+	return CacheStats{Files: files, Bytes: size, MaxBytes: s.maxBytes}
+}
+
+// Bandwidth returns the upstream download budget.
+func (s *Service) Bandwidth() Bandwidth {
+	return s.bandwidth.status()
+}
+
+// derive fetches the source photo and encodes its derivative.
+func (s *Service) derive(ctx context.Context, derivative ports.ImageDerivative) (*ports.Media, error) {
+	var source *ports.Media
+	var err error
+	if name, ok := strings.CutPrefix(derivative.SourceURL, ports.CatalogMediaScheme); ok {
+		source, err = s.openCatalogMedia(name)
+	} else {
+		source, err = s.download(ctx, derivative.SourceURL)
+	}
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(source.Data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding source photo: %w", err)
+	}
+	img = downscale(img, maxSide)
+
+	var buf bytes.Buffer
+	media := &ports.Media{}
+	switch derivative.Effect {
+	case ports.EffectCrop:
+		media.ContentType = "image/jpeg"
+		err = jpeg.Encode(&buf, crop(img, derivative.Region), &jpeg.Options{Quality: jpegQuality})
+	case ports.EffectSilhouette:
+		media.ContentType = "image/png"
+		err = png.Encode(&buf, silhouette(img))
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownEffect, derivative.Effect)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encoding derivative: %w", err)
+	}
+	media.Data = buf.Bytes()
+	return media, nil
+}
+
+// download fetches source media within the bandwidth budget.
+func (s *Service) download(ctx context.Context, sourceURL string) (*ports.Media, error) {
+	if !s.bandwidth.allow() {
+		return nil, ports.ErrMediaBandwidthExhausted
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", s.userAgent)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching source media: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, fmt.Errorf("%w: source status %d", ports.ErrMediaNotFound, resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching source media: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSourceBytes+1))
+	s.bandwidth.add(int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("reading source media: %w", err)
+	}
+	if len(data) > maxSourceBytes {
+		return nil, fmt.Errorf("source media larger than %d bytes", maxSourceBytes)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	return &ports.Media{ContentType: contentType, Data: data}, nil
+}
+
+// writeFile writes a file atomically, through a temporary file renamed once complete.
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }() // Error ignored: gone once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close() // Error ignored: we're already returning an error
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// encodeMedia returns the cache file of media: its content type on the first
+// line, then its data.
+func encodeMedia(media *ports.Media) []byte {
+	data := make([]byte, 0, len(media.ContentType)+1+len(media.Data))
+	data = append(data, media.ContentType...)
+	data = append(data, '\n')
+	return append(data, media.Data...)
+}
+
+// decodeMedia reads a cache file written by encodeMedia.
+func decodeMedia(data []byte) (*ports.Media, bool) {
+	contentType, body, ok := bytes.Cut(data, []byte{'\n'})
+	if !ok {
+		return nil, false
+	}
+	return &ports.Media{ContentType: string(contentType), Data: body}, true
+}
+
+// hashID returns the cache ID of content.
+func hashID(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])[:idLength]
+}
+
+// validID reports whether id is a derivative ID, so it can be used as a file name.
+func validID(id string) bool {
+	if len(id) != idLength {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+// Ensure interface compliance
+var (
+	_ ports.MediaDeriver = (*Service)(nil)
+	_ ports.MediaServer  = (*Service)(nil)
+)
@@ -0,0 +1,419 @@
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/media"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// photoServer serves a 200x100 PNG of a dark square on a white background,
+// counting requests.
+func photoServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 200; x++ {
+			c := color.RGBA{R: 250, G: 250, B: 250, A: 255}
+			if x >= 80 && x < 120 && y >= 30 && y < 70 {
+				c = color.RGBA{R: 20, G: 40, B: 10, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(buf.Bytes())
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestService_Crop(t *testing.T) {
+	server, requests := photoServer(t)
+	service, err := media.NewService(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	derivative := ports.ImageDerivative{
+		SourceURL: server.URL + "/photo.png",
+		Effect:    ports.EffectCrop,
+		Region:    ports.CropRegion{X: 0.25, Y: 0.25, Width: 0.5, Height: 0.5},
+	}
+	ref, err := service.Register(context.Background(), derivative)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if strings.Contains(ref, server.URL) || requests.Load() != 0 {
+		t.Errorf("Register() = %s after %d requests, want an opaque reference without fetching", ref, requests.Load())
+	}
+	if again, _ := service.Register(context.Background(), derivative); again != ref {
+		t.Errorf("Register() twice = %s and %s, want the same reference", ref, again)
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := service.Open(context.Background(), ref)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		if got.ContentType != "image/jpeg" {
+			t.Errorf("ContentType = %s, want image/jpeg", got.ContentType)
+		}
+		img, err := jpeg.Decode(bytes.NewReader(got.Data))
+		if err != nil {
+			t.Fatalf("decoding crop: %v", err)
+		}
+		if b := img.Bounds(); b.Dx() != 100 || b.Dy() != 50 {
+			t.Errorf("crop size = %dx%d, want 100x50", b.Dx(), b.Dy())
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("source fetched %d times, want once then cached", requests.Load())
+	}
+}
+
+func TestService_Silhouette(t *testing.T) {
+	server, _ := photoServer(t)
+	service, err := media.NewService(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref, err := service.Register(context.Background(), ports.ImageDerivative{
+		SourceURL: server.URL + "/photo.png",
+		Effect:    ports.EffectSilhouette,
+	})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	got, err := service.Open(context.Background(), ref)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if got.ContentType != "image/png" {
+		t.Errorf("ContentType = %s, want image/png", got.ContentType)
+	}
+	img, err := png.Decode(bytes.NewReader(got.Data))
+	if err != nil {
+		t.Fatalf("decoding silhouette: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		x, y int
+		want uint8
+	}{
+		{name: "subject", x: 100, y: 50, want: 0},
+		{name: "background", x: 10, y: 10, want: 255},
+		{name: "subject edge", x: 80, y: 30, want: 0},
+		{name: "background edge", x: 79, y: 30, want: 255},
+	}
+	for _, tt := range tests {
+		if y := color.GrayModel.Convert(img.At(tt.x, tt.y)).(color.Gray).Y; y != tt.want {
+			t.Errorf("%s (%d,%d) = %d, want %d", tt.name, tt.x, tt.y, y, tt.want)
+		}
+	}
+}
+
+func TestService_OpenErrors(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	service, err := media.NewService(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := service.Register(context.Background(), ports.ImageDerivative{
+		SourceURL: failing.URL + "/photo.png",
+		Effect:    ports.EffectSilhouette,
+	})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		id           string
+		wantNotFound bool
+	}{
+		{name: "path traversal", id: "media:../../etc/passwd", wantNotFound: true},
+		{name: "not hexadecimal", id: "media:" + strings.Repeat("z", 32), wantNotFound: true},
+		{name: "unknown", id: "media:" + strings.Repeat("a", 32), wantNotFound: true},
+		{name: "not a URL", id: "file:///etc/passwd", wantNotFound: true},
+		{name: "source unavailable", id: ref, wantNotFound: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Open(context.Background(), tt.id)
+			if err == nil {
+				t.Fatal("Open() error = nil, want an error")
+			}
+			if errors.Is(err, ports.ErrMediaNotFound) != tt.wantNotFound {
+				t.Errorf("Open() error = %v, want not found %v", err, tt.wantNotFound)
+			}
+		})
+	}
+}
+
+func TestService_RegisterUnknownEffect(t *testing.T) {
+	service, err := media.NewService(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = service.Register(context.Background(), ports.ImageDerivative{SourceURL: "x", Effect: "blur"})
+	if err == nil {
+		t.Error("Register() error = nil, want an error for an unknown effect")
+	}
+}
+
+func TestService_CacheBound(t *testing.T) {
+	server, requests := photoServer(t)
+	dir := t.TempDir()
+	service, err := media.NewService(dir, media.WithMaxCacheBytes(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	register := func(effect ports.ImageEffect) string {
+		ref, err := service.Register(context.Background(), ports.ImageDerivative{
+			SourceURL: server.URL + "/photo.png",
+			Effect:    effect,
+			Region:    ports.CropRegion{Width: 1, Height: 1},
+		})
+		if err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+		return ref
+	}
+
+	first := register(ports.EffectCrop)
+	if _, err := service.Open(context.Background(), first); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	register(ports.EffectSilhouette)
+
+	second := register(ports.EffectSilhouette)
+	if _, err := service.Open(context.Background(), second); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	// The evicted derivative is produced again from its kept description
+	if _, err := service.Open(context.Background(), first); err != nil {
+		t.Errorf("Open() evicted derivative error = %v", err)
+	}
+	if stats := service.Stats(); stats.Files != 1 || stats.MaxBytes != 1 {
+		t.Errorf("Stats() = %+v, want a single file over the bound", stats)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("source fetched %d times, want once per derivation", requests.Load())
+	}
+}
+
+func TestService_PrunesDerivatives(t *testing.T) {
+	server, _ := photoServer(t)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	service, err := media.NewService(t.TempDir(), media.WithClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	register := func(effect ports.ImageEffect) string {
+		ref, err := service.Register(context.Background(), ports.ImageDerivative{
+			SourceURL: server.URL + "/photo.png",
+			Effect:    effect,
+		})
+		if err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+		return ref
+	}
+
+	unused := register(ports.EffectSilhouette)
+	if _, err := service.Open(context.Background(), unused); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	registered := register(ports.EffectCrop)
+	now = now.Add(6 * 24 * time.Hour)
+	register(ports.EffectCrop) // Registered again, kept for another week
+	now = now.Add(2 * 24 * time.Hour)
+
+	if pruned := service.PruneDerivatives(); pruned != 1 {
+		t.Errorf("PruneDerivatives() = %d, want 1", pruned)
+	}
+	if _, err := service.Open(context.Background(), unused); !errors.Is(err, ports.ErrMediaNotFound) {
+		t.Errorf("Open() pruned derivative error = %v, want ErrMediaNotFound", err)
+	}
+	if _, err := service.Open(context.Background(), registered); err != nil {
+		t.Errorf("Open() kept derivative error = %v", err)
+	}
+	if stats := service.Stats(); stats.Files != 1 {
+		t.Errorf("Stats().Files = %d, want only the kept derivative", stats.Files)
+	}
+}
+
+func TestService_ReopensCache(t *testing.T) {
+	server, _ := photoServer(t)
+	dir := t.TempDir()
+	service, err := media.NewService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := service.Register(context.Background(), ports.ImageDerivative{
+		SourceURL: server.URL + "/photo.png",
+		Effect:    ports.EffectSilhouette,
+	})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	want, err := service.Open(context.Background(), ref)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	server.Close()
+
+	reopened, err := media.NewService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := reopened.Open(context.Background(), ref)
+	if err != nil {
+		t.Fatalf("Open() after restart error = %v", err)
+	}
+	if !bytes.Equal(got.Data, want.Data) {
+		t.Error("Open() after restart returned different data")
+	}
+	if stats := reopened.Stats(); stats.Files != 1 {
+		t.Errorf("Stats().Files = %d, want the derivative", stats.Files)
+	}
+}
+
+func TestService_ProxiesSource(t *testing.T) {
+	server, requests := photoServer(t)
+	service, err := media.NewService(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := service.Open(context.Background(), server.URL+"/photo.png")
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		if got.ContentType != "image/png" {
+			t.Errorf("ContentType = %s, want the upstream type", got.ContentType)
+		}
+		if _, err := png.Decode(bytes.NewReader(got.Data)); err != nil {
+			t.Errorf("decoding proxied photo: %v", err)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("source fetched %d times, want once then cached", requests.Load())
+	}
+	if bandwidth := service.Bandwidth(); bandwidth.HourlyUsed == 0 || bandwidth.HourlyUsed != bandwidth.DailyUsed {
+		t.Errorf("Bandwidth() = %+v, want the download counted once per window", bandwidth)
+	}
+}
+
+func TestService_BandwidthLimits(t *testing.T) {
+	server, requests := photoServer(t)
+	now := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	service, err := media.NewService(t.TempDir(),
+		media.WithBandwidthLimits(1, 1<<20),
+		media.WithClock(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Open(context.Background(), server.URL+"/first.png"); err != nil {
+		t.Fatalf("Open() within budget error = %v", err)
+	}
+	if _, err := service.Open(context.Background(), server.URL+"/first.png"); err != nil {
+		t.Errorf("Open() cached error = %v, want cached media served over budget", err)
+	}
+	_, err = service.Open(context.Background(), server.URL+"/second.png")
+	if !errors.Is(err, ports.ErrMediaBandwidthExhausted) {
+		t.Errorf("Open() over hourly budget error = %v, want ErrMediaBandwidthExhausted", err)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := service.Open(context.Background(), server.URL+"/second.png"); err != nil {
+		t.Errorf("Open() next hour error = %v", err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("source fetched %d times, want 2", requests.Load())
+	}
+}
+
+func TestService_CatalogMedia(t *testing.T) {
+	server, requests := photoServer(t)
+	source, err := http.Get(server.URL + "/photo.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	photo, _ := io.ReadAll(source.Body)
+	source.Body.Close()
+
+	dir := t.TempDir()
+	name := "0123456789abcdef0123456789abcdef.png"
+	if err := os.WriteFile(filepath.Join(dir, name), photo, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	service, err := media.NewService(t.TempDir(), media.WithCatalogMedia(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	got, err := service.Open(ctx, ports.CatalogMediaScheme+name)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if got.ContentType != "image/png" || !bytes.Equal(got.Data, photo) {
+		t.Errorf("Open() = %s of %d bytes, want the PNG photo", got.ContentType, len(got.Data))
+	}
+
+	ref, err := service.Register(ctx, ports.ImageDerivative{
+		SourceURL: ports.CatalogMediaScheme + name,
+		Effect:    ports.EffectSilhouette,
+	})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if _, err := service.Open(ctx, ref); err != nil {
+		t.Errorf("Open() silhouette error = %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("source requests = %d, want only the one of the test", requests.Load())
+	}
+
+	for _, bad := range []string{"missing", "0123456789abcdef0123456789abcdee.png", "../" + name} {
+		if _, err := service.Open(ctx, ports.CatalogMediaScheme+bad); !errors.Is(err, ports.ErrMediaNotFound) {
+			t.Errorf("Open(%s) error = %v, want ErrMediaNotFound", bad, err)
+		}
+	}
+}
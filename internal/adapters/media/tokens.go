@@ -0,0 +1,177 @@
+package media
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Default token settings.
+const (
+	defaultTokenGrace = 10 * time.Second
+	tokenKeyLength    = 32
+	tokenMACLength    = 16
+)
+
+// Tokens issues HMAC-signed media tokens bound to a session and a question.
+// A token expires once the question time limit and a grace window for network
+// delays have passed; FlashQuiz tokens expire after the flash duration and
+// are redeemed once only.
+type Tokens struct {
+	key   []byte
+	grace time.Duration
+	now   func() time.Time
+
+	mu       sync.Mutex
+	redeemed map[string]time.Time // MACs of single-use tokens already redeemed, until they expire
+}
+
+// TokenOption configures the tokens.
+type TokenOption func(*Tokens)
+
+// WithTokenGrace sets the time added to the question time limit before a token expires.
+func WithTokenGrace(grace time.Duration) TokenOption {
+	return func(t *Tokens) {
+		t.grace = grace
+	}
+}
+
+// WithTokenClock sets the time source of token expiry.
+func WithTokenClock(now func() time.Time) TokenOption {
+	return func(t *Tokens) {
+		t.now = now
+	}
+}
+
+// NewTokens creates tokens signed with key. An empty key is replaced by a
+// random one, invalidating tokens on restart.
+func NewTokens(key []byte, opts ...TokenOption) (*Tokens, error) {
+	if len(key) == 0 {
+		key = make([]byte, tokenKeyLength)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("generating media token key: %w", err)
+		}
+	}
+	t := &Tokens{
+		key:      key,
+		grace:    defaultTokenGrace,
+		now:      time.Now,
+		redeemed: make(map[string]time.Time),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t, nil
+}
+
+// tokenClaims is the signed content of a token.
+type tokenClaims struct {
+	SessionID  string `json:"s"`
+	QuestionID string `json:"q"`
+	ExpiresAt  int64  `json:"e"` // Unix milliseconds
+	SingleUse  bool   `json:"o,omitempty"`
+}
+
+// Issue returns a token granting access to the media of a question of a session.
+func (t *Tokens) Issue(sessionID string, question *quiz.Question) string {
+	window, singleUse := question.TimeLimit(), false
+	if question.QuizType() == quiz.FlashQuiz {
+		window, singleUse = question.FlashDuration(), true
+	}
+	claims := tokenClaims{
+		SessionID:  sessionID,
+		QuestionID: question.ID(),
+		ExpiresAt:  t.now().Add(window + t.grace).UnixMilli(),
+		SingleUse:  singleUse,
+	}
+	payload, _ := json.Marshal(claims) // Error ignored: strings, an integer and a bool always encode
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(t.sign(encoded))
+}
+
+// Redeem returns the grant of a token, or ErrInvalidMediaToken when it is
+// forged, expired or already used.
+func (t *Tokens) Redeem(token string) (ports.MediaGrant, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return ports.MediaGrant{}, fmt.Errorf("%w: malformed", ports.ErrInvalidMediaToken)
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, t.sign(encoded)) {
+		return ports.MediaGrant{}, fmt.Errorf("%w: bad signature", ports.ErrInvalidMediaToken)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ports.MediaGrant{}, fmt.Errorf("%w: malformed", ports.ErrInvalidMediaToken)
+	}
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ports.MediaGrant{}, fmt.Errorf("%w: malformed", ports.ErrInvalidMediaToken)
+	}
+
+	grant := ports.MediaGrant{
+		SessionID:  claims.SessionID,
+		QuestionID: claims.QuestionID,
+		ExpiresAt:  time.UnixMilli(claims.ExpiresAt),
+		SingleUse:  claims.SingleUse,
+	}
+	now := t.now()
+	if !now.Before(grant.ExpiresAt) {
+		return ports.MediaGrant{}, fmt.Errorf("%w: expired", ports.ErrInvalidMediaToken)
+	}
+	if grant.SingleUse && !t.markRedeemed(string(mac), grant.ExpiresAt, now) {
+		return ports.MediaGrant{}, fmt.Errorf("%w: already used", ports.ErrInvalidMediaToken)
+	}
+	return grant, nil
+}
+
+// Release forgets the redemption of a single-use token, so a client can retry
+// when its media could not be served.
+func (t *Tokens) Release(token string) {
+	encoded, signature, _ := strings.Cut(token, ".")
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, t.sign(encoded)) {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.redeemed, string(mac))
+}
+
+// markRedeemed records a single-use token, reporting false when it already was.
+// Expired tokens are forgotten, their expiry refusing them anyway.
+func (t *Tokens) markRedeemed(mac string, expiresAt, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for redeemed, expiry := range t.redeemed {
+		if !now.Before(expiry) {
+			delete(t.redeemed, redeemed)
+		}
+	}
+	if _, used := t.redeemed[mac]; used {
+		return false
+	}
+	t.redeemed[mac] = expiresAt
+	return true
+}
+
+// sign returns the truncated HMAC of an encoded payload.
+func (t *Tokens) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, t.key)
+	_, _ = mac.Write([]byte(encoded)) // Error ignored: hash writes never fail
+	return mac.Sum(nil)[:tokenMACLength]
+}
+
+// Ensure interface compliance
+var _ ports.MediaTokens = (*Tokens)(nil)
@@ -0,0 +1,128 @@
+package media_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/media"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// newQuestion creates a question of the given type at the beginner level.
+func newQuestion(t *testing.T, quizType quiz.QuizType) *quiz.Question {
+	t.Helper()
+	fox, _ := species.New(42069, "Vulpes vulpes", "Renard roux", "Mammalia")
+	badger, _ := species.New(41709, "Meles meles", "Blaireau europeen", "Mammalia")
+	choices := []quiz.Choice{{Species: fox, IsCorrect: true}, {Species: badger}}
+	q, err := quiz.NewQuestion("q1", quizType, quiz.Beginner, fox, choices, "https://example.com/fox.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+func TestTokens_Redeem(t *testing.T) {
+	config := quiz.DefaultDifficultyConfigs()[quiz.Beginner]
+	grace := 2 * time.Second
+
+	tests := []struct {
+		name      string
+		quizType  quiz.QuizType
+		elapsed   time.Duration
+		redeems   int
+		wantValid bool
+	}{
+		{name: "image within time limit", quizType: quiz.ImageQuiz, elapsed: config.TimeLimit, redeems: 3, wantValid: true},
+		{name: "image expired", quizType: quiz.ImageQuiz, elapsed: config.TimeLimit + grace, redeems: 1},
+		{name: "flash within flash duration", quizType: quiz.FlashQuiz, elapsed: config.FlashDuration, redeems: 1,
+			wantValid: true},
+		{name: "flash expired", quizType: quiz.FlashQuiz, elapsed: config.FlashDuration + grace, redeems: 1},
+		{name: "flash replayed", quizType: quiz.FlashQuiz, redeems: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+			tokens, err := media.NewTokens([]byte("secret"),
+				media.WithTokenGrace(grace),
+				media.WithTokenClock(func() time.Time { return now }),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			token := tokens.Issue("session-1", newQuestion(t, tt.quizType))
+			now = now.Add(tt.elapsed)
+
+			var grant ports.MediaGrant
+			for i := 0; i < tt.redeems; i++ {
+				grant, err = tokens.Redeem(token)
+			}
+			if !tt.wantValid {
+				if !errors.Is(err, ports.ErrInvalidMediaToken) {
+					t.Errorf("Redeem() error = %v, want ErrInvalidMediaToken", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Redeem() error = %v", err)
+			}
+			if grant.SessionID != "session-1" || grant.QuestionID != "q1" {
+				t.Errorf("Redeem() = %+v, want session-1 and q1", grant)
+			}
+			if grant.SingleUse != (tt.quizType == quiz.FlashQuiz) {
+				t.Errorf("SingleUse = %v for %s", grant.SingleUse, tt.quizType)
+			}
+		})
+	}
+}
+
+func TestTokens_RedeemForged(t *testing.T) {
+	tokens, err := media.NewTokens([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := media.NewTokens(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := tokens.Issue("session-1", newQuestion(t, quiz.ImageQuiz))
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "other key", token: other.Issue("session-1", newQuestion(t, quiz.ImageQuiz))},
+		{name: "tampered", token: "x" + token},
+		{name: "no signature", token: "payload"},
+		{name: "empty", token: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tokens.Redeem(tt.token); !errors.Is(err, ports.ErrInvalidMediaToken) {
+				t.Errorf("Redeem() error = %v, want ErrInvalidMediaToken", err)
+			}
+		})
+	}
+}
+
+func TestTokens_Release(t *testing.T) {
+	tokens, err := media.NewTokens([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := tokens.Issue("session-1", newQuestion(t, quiz.FlashQuiz))
+
+	if _, err := tokens.Redeem(token); err != nil {
+		t.Fatalf("Redeem() error = %v", err)
+	}
+	tokens.Release(token)
+	if _, err := tokens.Redeem(token); err != nil {
+		t.Fatalf("Redeem() after Release() error = %v", err)
+	}
+	if _, err := tokens.Redeem(token); !errors.Is(err, ports.ErrInvalidMediaToken) {
+		t.Errorf("third Redeem() error = %v, want ErrInvalidMediaToken", err)
+	}
+}
@@ -0,0 +1,207 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/memory"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// seedPlayers creates players with the given XP, keyed by ID.
+func seedPlayers(t *testing.T, repo *memory.PlayerRepository, xp map[string]int) {
+	t.Helper()
+	for id, amount := range xp {
+		player, _ := gamification.NewPlayer(id, "user_"+id)
+		player.AddXP(amount)
+		if err := repo.Create(context.Background(), player); err != nil {
+			t.Fatalf("Create(%s) error = %v", id, err)
+		}
+	}
+}
+
+func TestPlayerRepository_GetXPLeaderboard_Pagination(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	seedPlayers(t, repo, map[string]int{"c": 100, "a": 500, "b": 100, "d": 50, "e": 100})
+	ctx := context.Background()
+
+	want := []string{"a", "b", "c", "e", "d"}
+
+	var got []string
+	page := ports.PageRequest{Limit: 2}
+	for {
+		result, err := repo.GetXPLeaderboard(ctx, page)
+		if err != nil {
+			t.Fatalf("GetXPLeaderboard() error = %v", err)
+		}
+		if result.Total != len(want) {
+			t.Errorf("GetXPLeaderboard() Total = %d, want %d", result.Total, len(want))
+		}
+		for _, e := range result.Entries {
+			if e.Rank != len(got)+1 {
+				t.Errorf("entry %s Rank = %d, want %d", e.PlayerID, e.Rank, len(got)+1)
+			}
+			got = append(got, e.PlayerID)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		page.Cursor = result.NextCursor
+	}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("cursor pages = %v, want %v", got, want)
+	}
+
+	offsetPage, err := repo.GetXPLeaderboard(ctx, ports.PageRequest{Offset: 3, Limit: 10})
+	if err != nil {
+		t.Fatalf("GetXPLeaderboard() error = %v", err)
+	}
+	if len(offsetPage.Entries) != 2 || offsetPage.Entries[0].PlayerID != "e" || offsetPage.Entries[0].Rank != 4 {
+		t.Errorf("offset page = %+v", offsetPage.Entries)
+	}
+	if offsetPage.NextCursor != "" {
+		t.Errorf("last page NextCursor = %q, want empty", offsetPage.NextCursor)
+	}
+}
+
+func TestPlayerRepository_GetXPLeaderboard_CursorSurvivesUpdates(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	seedPlayers(t, repo, map[string]int{"a": 500, "b": 400, "c": 300, "d": 200})
+	ctx := context.Background()
+
+	first, _ := repo.GetXPLeaderboard(ctx, ports.PageRequest{Limit: 2})
+
+	// A player ahead of the cursor drops out of the first page
+	a, _ := repo.GetByID(ctx, "a")
+	moved, _ := gamification.RestorePlayer(withXP(a.Snapshot(), 0))
+	repo.Update(ctx, moved)
+
+	second, err := repo.GetXPLeaderboard(ctx, ports.PageRequest{Cursor: first.NextCursor, Limit: 2})
+	if err != nil {
+		t.Fatalf("GetXPLeaderboard() error = %v", err)
+	}
+	if len(second.Entries) != 2 || second.Entries[0].PlayerID != "c" || second.Entries[1].PlayerID != "d" {
+		t.Errorf("second page = %+v, want c then d", second.Entries)
+	}
+}
+
+func TestPlayerRepository_GetXPLeaderboard_InvalidCursor(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+
+	_, err := repo.GetXPLeaderboard(context.Background(), ports.PageRequest{Cursor: "!!!", Limit: 10})
+	if !errors.Is(err, ports.ErrInvalidCursor) {
+		t.Errorf("GetXPLeaderboard() error = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestPlayerRepository_GetXPStanding(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	seedPlayers(t, repo, map[string]int{"a": 500, "b": 400, "c": 300, "d": 200, "e": 100})
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		playerID  string
+		radius    int
+		wantRank  int
+		neighbors []string
+	}{
+		{"middle", "c", 1, 3, []string{"b", "c", "d"}},
+		{"top", "a", 2, 1, []string{"a", "b", "c"}},
+		{"bottom", "e", 2, 5, []string{"c", "d", "e"}},
+		{"alone", "b", 0, 2, []string{"b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standing, err := repo.GetXPStanding(ctx, tt.playerID, tt.radius)
+			if err != nil {
+				t.Fatalf("GetXPStanding() error = %v", err)
+			}
+			if standing.Player.Rank != tt.wantRank || standing.Player.PlayerID != tt.playerID {
+				t.Errorf("GetXPStanding() Player = %+v, want rank %d", standing.Player, tt.wantRank)
+			}
+
+			ids := make([]string, len(standing.Neighbors))
+			for i, e := range standing.Neighbors {
+				ids[i] = e.PlayerID
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.neighbors) {
+				t.Errorf("GetXPStanding() Neighbors = %v, want %v", ids, tt.neighbors)
+			}
+		})
+	}
+
+	if _, err := repo.GetXPStanding(ctx, "missing", 1); !errors.Is(err, ports.ErrPlayerNotFound) {
+		t.Errorf("GetXPStanding() error = %v, want ErrPlayerNotFound", err)
+	}
+}
+
+func TestPlayerRepository_XPRanksMatchSort(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(42))
+
+	xp := make(map[string]int)
+	for i := 0; i < 300; i++ {
+		xp[fmt.Sprintf("p%03d", i)] = rng.Intn(50) * 10
+	}
+	seedPlayers(t, repo, xp)
+
+	// Move random players around so the index sees deletes and re-inserts
+	for i := 0; i < 500; i++ {
+		id := fmt.Sprintf("p%03d", rng.Intn(300))
+		player, _ := repo.GetByID(ctx, id)
+		xp[id] = rng.Intn(50) * 10
+		updated, _ := gamification.RestorePlayer(withXP(player.Snapshot(), xp[id]))
+		if err := repo.Update(ctx, updated); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+
+	want := make([]string, 0, len(xp))
+	for id := range xp {
+		want = append(want, id)
+	}
+	sort.Slice(want, func(i, j int) bool {
+		if xp[want[i]] != xp[want[j]] {
+			return xp[want[i]] > xp[want[j]]
+		}
+		return want[i] < want[j]
+	})
+
+	page, err := repo.GetXPLeaderboard(ctx, ports.PageRequest{Limit: len(want)})
+	if err != nil {
+		t.Fatalf("GetXPLeaderboard() error = %v", err)
+	}
+	for i, e := range page.Entries {
+		if e.PlayerID != want[i] || e.Score != xp[want[i]] {
+			t.Fatalf("entry %d = %+v, want %s with %d XP", i, e, want[i], xp[want[i]])
+		}
+	}
+
+	for rank, id := range want {
+		standing, err := repo.GetXPStanding(ctx, id, 0)
+		if err != nil {
+			t.Fatalf("GetXPStanding(%s) error = %v", id, err)
+		}
+		if standing.Player.Rank != rank+1 {
+			t.Fatalf("GetXPStanding(%s) Rank = %d, want %d", id, standing.Player.Rank, rank+1)
+		}
+	}
+}
+
+// withXP returns a copy of a snapshot with total XP and level replaced.
+func withXP(snap gamification.PlayerSnapshot, xp int) gamification.PlayerSnapshot {
+	fresh, _ := gamification.NewPlayer(snap.ID, snap.Username)
+	fresh.AddXP(xp)
+	snap.TotalXP = fresh.TotalXP()
+	snap.Level = fresh.Level()
+	return snap
+}
@@ -0,0 +1,175 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// PlayerRepository is a concurrency-safe in-memory PlayerRepository.
+// Players are stored as snapshots and every read returns a fresh copy, so
+// callers never share a player. It also serves the XP leaderboard from a rank
+// index kept up to date on every Create and Update.
+type PlayerRepository struct {
+	mu         sync.RWMutex
+	players    map[string]gamification.PlayerSnapshot
+	byUsername map[string]string
+	xpIndex    *rankIndex
+}
+
+// NewPlayerRepository creates a new in-memory player repository.
+func NewPlayerRepository() *PlayerRepository {
+	return &PlayerRepository{
+		players:    make(map[string]gamification.PlayerSnapshot),
+		byUsername: make(map[string]string),
+		xpIndex:    newRankIndex(),
+	}
+}
+
+// Create stores a new player, rejecting duplicate IDs and usernames.
+func (r *PlayerRepository) Create(_ context.Context, player *gamification.Player) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.players[player.ID()]; ok {
+		return fmt.Errorf("player already exists: %s", player.ID())
+	}
+	if _, ok := r.byUsername[player.Username()]; ok {
+		return fmt.Errorf("%w: %s", ports.ErrUsernameTaken, player.Username())
+	}
+
+	r.players[player.ID()] = player.Snapshot()
+	r.byUsername[player.Username()] = player.ID()
+	r.xpIndex.Set(player.ID(), player.TotalXP())
+	return nil
+}
+
+// GetByID retrieves a player by ID.
+func (r *PlayerRepository) GetByID(_ context.Context, id string) (*gamification.Player, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if snap, ok := r.players[id]; ok {
+		return restorePlayer(snap)
+	}
+	return nil, fmt.Errorf("%w: %s", ports.ErrPlayerNotFound, id)
+}
+
+// GetByUsername retrieves a player by username.
+func (r *PlayerRepository) GetByUsername(_ context.Context, username string) (*gamification.Player, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if id, ok := r.byUsername[username]; ok {
+		return restorePlayer(r.players[id])
+	}
+	return nil, fmt.Errorf("%w: %s", ports.ErrPlayerNotFound, username)
+}
+
+// Update stores the player's current state.
+func (r *PlayerRepository) Update(_ context.Context, player *gamification.Player) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.players[player.ID()]; !ok {
+		return fmt.Errorf("%w: %s", ports.ErrPlayerNotFound, player.ID())
+	}
+	r.players[player.ID()] = player.Snapshot()
+	r.xpIndex.Set(player.ID(), player.TotalXP())
+	return nil
+}
+
+// GetLeaderboard retrieves top players by XP, ties broken by ID.
+func (r *PlayerRepository) GetLeaderboard(_ context.Context, limit int) ([]*gamification.Player, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if limit <= 0 {
+		limit = r.xpIndex.Len()
+	}
+	keys := r.xpIndex.Range(0, limit)
+	players := make([]*gamification.Player, len(keys))
+	for i, key := range keys {
+		player, err := restorePlayer(r.players[key.id])
+		if err != nil {
+			return nil, err
+		}
+		players[i] = player
+	}
+	return players, nil
+}
+
+// GetXPLeaderboard retrieves a page of the all-time XP leaderboard.
+func (r *PlayerRepository) GetXPLeaderboard(
+	_ context.Context,
+	page ports.PageRequest,
+) (*ports.LeaderboardPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys, offset, next, err := r.xpIndex.Page(page)
+	if err != nil {
+		return nil, err
+	}
+	return &ports.LeaderboardPage{
+		Entries:    r.entries(keys, offset),
+		Total:      r.xpIndex.Len(),
+		NextCursor: next,
+	}, nil
+}
+
+// GetXPStanding retrieves a player's XP rank and its neighbors.
+func (r *PlayerRepository) GetXPStanding(
+	_ context.Context,
+	playerID string,
+	radius int,
+) (*ports.LeaderboardStanding, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rank, ok := r.xpIndex.Rank(playerID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ports.ErrPlayerNotFound, playerID)
+	}
+
+	keys, offset := r.xpIndex.Around(rank, radius)
+	neighbors := r.entries(keys, offset)
+	return &ports.LeaderboardStanding{
+		Player:    neighbors[rank-1-offset],
+		Neighbors: neighbors,
+	}, nil
+}
+
+// entries converts index keys starting at offset into leaderboard entries.
+func (r *PlayerRepository) entries(keys []rankKey, offset int) []ports.LeaderboardEntry {
+	entries := make([]ports.LeaderboardEntry, len(keys))
+	for i, key := range keys {
+		snap := r.players[key.id]
+		entries[i] = ports.LeaderboardEntry{
+			Rank:     offset + i + 1,
+			PlayerID: key.id,
+			Username: snap.Username,
+			Level:    snap.Level,
+			Score:    key.score,
+		}
+	}
+	return entries
+}
+
+// restorePlayer rebuilds a stored player.
+func restorePlayer(snap gamification.PlayerSnapshot) (*gamification.Player, error) {
+	player, err := gamification.RestorePlayer(snap)
+	if err != nil {
+		return nil, fmt.Errorf("restoring player %s: %w", snap.ID, err)
+	}
+	return player, nil
+}
+
+// Ensure interface compliance
+var (
+	_ ports.PlayerRepository = (*PlayerRepository)(nil)
+	_ ports.XPLeaderboard    = (*PlayerRepository)(nil)
+)
@@ -0,0 +1,101 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/memory"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+func TestPlayerRepository_CreateAndGet(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	player, _ := gamification.NewPlayer("p1", "naturelover")
+
+	if err := repo.Create(context.Background(), player); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if got, err := repo.GetByID(context.Background(), "p1"); err != nil ||
+		!reflect.DeepEqual(got.Snapshot(), player.Snapshot()) {
+		t.Errorf("GetByID() = %v, %v", got, err)
+	}
+	if got, err := repo.GetByUsername(context.Background(), "naturelover"); err != nil ||
+		!reflect.DeepEqual(got.Snapshot(), player.Snapshot()) {
+		t.Errorf("GetByUsername() = %v, %v", got, err)
+	}
+}
+
+func TestPlayerRepository_ReturnsCopies(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	player, _ := gamification.NewPlayer("p1", "naturelover")
+	repo.Create(context.Background(), player)
+
+	// Neither the stored player nor a read one is shared with the store
+	player.AddXP(100)
+	got, _ := repo.GetByID(context.Background(), "p1")
+	got.AddXP(100)
+
+	stored, _ := repo.GetByID(context.Background(), "p1")
+	if stored.TotalXP() != 0 {
+		t.Errorf("TotalXP() = %d, want 0 until the player is updated", stored.TotalXP())
+	}
+
+	repo.Update(context.Background(), got)
+	if stored, _ := repo.GetByID(context.Background(), "p1"); stored.TotalXP() != 100 {
+		t.Errorf("TotalXP() after Update = %d, want 100", stored.TotalXP())
+	}
+}
+
+func TestPlayerRepository_Duplicates(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	p1, _ := gamification.NewPlayer("p1", "same")
+	p2, _ := gamification.NewPlayer("p2", "same")
+	p3, _ := gamification.NewPlayer("p1", "other")
+
+	repo.Create(context.Background(), p1)
+	if err := repo.Create(context.Background(), p2); !errors.Is(err, ports.ErrUsernameTaken) {
+		t.Errorf("Create() error = %v, want ErrUsernameTaken", err)
+	}
+	if err := repo.Create(context.Background(), p3); err == nil || errors.Is(err, ports.ErrUsernameTaken) {
+		t.Errorf("Create() error = %v, want a duplicate ID error", err)
+	}
+}
+
+func TestPlayerRepository_NotFound(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	ghost, _ := gamification.NewPlayer("ghost", "ghost")
+
+	if _, err := repo.GetByID(context.Background(), "ghost"); !errors.Is(err, ports.ErrPlayerNotFound) {
+		t.Errorf("GetByID() error = %v, want ErrPlayerNotFound", err)
+	}
+	if _, err := repo.GetByUsername(context.Background(), "ghost"); !errors.Is(err, ports.ErrPlayerNotFound) {
+		t.Errorf("GetByUsername() error = %v, want ErrPlayerNotFound", err)
+	}
+	if err := repo.Update(context.Background(), ghost); !errors.Is(err, ports.ErrPlayerNotFound) {
+		t.Errorf("Update() error = %v, want ErrPlayerNotFound", err)
+	}
+}
+
+func TestPlayerRepository_ConcurrentAccess(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			player, _ := gamification.NewPlayer(fmt.Sprintf("p%d", i), fmt.Sprintf("user%d", i))
+			repo.Create(context.Background(), player)
+			repo.Update(context.Background(), player)
+			repo.GetByUsername(context.Background(), player.Username())
+			repo.GetLeaderboard(context.Background(), 10)
+		}(i)
+	}
+	wg.Wait()
+}
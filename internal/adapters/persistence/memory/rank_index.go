@@ -0,0 +1,239 @@
+package memory
+
+import (
+	"math/rand"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Skip list parameters, as in Redis sorted sets.
+const (
+	rankIndexMaxLevel = 32
+	rankIndexP        = 0.25
+)
+
+// rankKey orders entries by descending score, then ascending ID.
+type rankKey struct {
+	score int
+	id    string
+}
+
+// before reports whether k ranks ahead of other.
+func (k rankKey) before(other rankKey) bool {
+	if k.score != other.score {
+		return k.score > other.score
+	}
+	return k.id < other.id
+}
+
+// rankIndex is an indexable skip list: insert, delete, rank lookup and
+// positional access are O(log n), so leaderboard pages and "my rank"
+// queries never scan the whole population. It is not safe for concurrent
+// use; callers hold their own lock.
+type rankIndex struct {
+	head   *rankNode
+	level  int
+	length int
+	scores map[string]int
+}
+
+type rankNode struct {
+	key   rankKey
+	links []rankLink
+}
+
+// rankLink points to the next node on a level; span counts the nodes it skips over.
+type rankLink struct {
+	next *rankNode
+	span int
+}
+
+func newRankIndex() *rankIndex {
+	return &rankIndex{
+		head:   &rankNode{links: make([]rankLink, rankIndexMaxLevel)},
+		level:  1,
+		scores: make(map[string]int),
+	}
+}
+
+// Len returns the number of ranked entries.
+func (idx *rankIndex) Len() int {
+	return idx.length
+}
+
+// Score returns the indexed score of an ID.
+func (idx *rankIndex) Score(id string) (int, bool) {
+	score, ok := idx.scores[id]
+	return score, ok
+}
+
+// Set inserts or moves an ID to the given score.
+func (idx *rankIndex) Set(id string, score int) {
+	if old, ok := idx.scores[id]; ok {
+		if old == score {
+			return
+		}
+		idx.delete(rankKey{score: old, id: id})
+	}
+	idx.insert(rankKey{score: score, id: id})
+	idx.scores[id] = score
+}
+
+// Remove deletes an ID from the index.
+func (idx *rankIndex) Remove(id string) {
+	if score, ok := idx.scores[id]; ok {
+		idx.delete(rankKey{score: score, id: id})
+		delete(idx.scores, id)
+	}
+}
+
+// Rank returns the 1-based position of an ID.
+func (idx *rankIndex) Rank(id string) (int, bool) {
+	score, ok := idx.scores[id]
+	if !ok {
+		return 0, false
+	}
+	return idx.countThrough(rankKey{score: score, id: id}), true
+}
+
+// countThrough returns how many entries rank at or ahead of key,
+// whether or not key itself is indexed.
+func (idx *rankIndex) countThrough(key rankKey) int {
+	count := 0
+	x := idx.head
+	for i := idx.level - 1; i >= 0; i-- {
+		for x.links[i].next != nil && !key.before(x.links[i].next.key) {
+			count += x.links[i].span
+			x = x.links[i].next
+		}
+	}
+	return count
+}
+
+// Range returns up to limit keys starting at the 0-based offset.
+func (idx *rankIndex) Range(offset, limit int) []rankKey {
+	if offset < 0 || offset >= idx.length || limit <= 0 {
+		return nil
+	}
+
+	x := idx.head
+	traversed := 0
+	target := offset + 1
+	for i := idx.level - 1; i >= 0; i-- {
+		for x.links[i].next != nil && traversed+x.links[i].span <= target {
+			traversed += x.links[i].span
+			x = x.links[i].next
+		}
+	}
+
+	keys := make([]rankKey, 0, min(limit, idx.length-offset))
+	for ; x != nil && len(keys) < limit; x = x.links[0].next {
+		keys = append(keys, x.key)
+	}
+	return keys
+}
+
+// Page resolves a page request into keys, the offset of the first key and
+// the cursor of the next page.
+func (idx *rankIndex) Page(page ports.PageRequest) ([]rankKey, int, string, error) {
+	offset := max(page.Offset, 0)
+	if page.Cursor != "" {
+		cursor, err := ports.ParseLeaderboardCursor(page.Cursor)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		offset = idx.countThrough(rankKey{score: cursor.Score, id: cursor.PlayerID})
+	}
+
+	keys := idx.Range(offset, page.Limit)
+	next := ""
+	if len(keys) > 0 && offset+len(keys) < idx.length {
+		last := keys[len(keys)-1]
+		next = ports.LeaderboardCursor{Score: last.score, PlayerID: last.id}.Encode()
+	}
+	return keys, offset, next, nil
+}
+
+// Around returns the keys within radius positions of rank (1-based) and the
+// offset of the first one.
+func (idx *rankIndex) Around(rank, radius int) ([]rankKey, int) {
+	offset := max(rank-1-radius, 0)
+	return idx.Range(offset, rank+radius-offset), offset
+}
+
+func (idx *rankIndex) randomLevel() int {
+	level := 1
+	for level < rankIndexMaxLevel && rand.Float64() < rankIndexP { //nolint:gosec // Not security sensitive
+		level++
+	}
+	return level
+}
+
+func (idx *rankIndex) insert(key rankKey) {
+	var update [rankIndexMaxLevel]*rankNode
+	var rank [rankIndexMaxLevel]int
+
+	x := idx.head
+	for i := idx.level - 1; i >= 0; i-- {
+		if i < idx.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.links[i].next != nil && x.links[i].next.key.before(key) {
+			rank[i] += x.links[i].span
+			x = x.links[i].next
+		}
+		update[i] = x
+	}
+
+	level := idx.randomLevel()
+	if level > idx.level {
+		for i := idx.level; i < level; i++ {
+			rank[i] = 0
+			update[i] = idx.head
+			idx.head.links[i].span = idx.length
+		}
+		idx.level = level
+	}
+
+	node := &rankNode{key: key, links: make([]rankLink, level)}
+	for i := 0; i < level; i++ {
+		node.links[i].next = update[i].links[i].next
+		update[i].links[i].next = node
+		node.links[i].span = update[i].links[i].span - (rank[0] - rank[i])
+		update[i].links[i].span = rank[0] - rank[i] + 1
+	}
+	for i := level; i < idx.level; i++ {
+		update[i].links[i].span++
+	}
+	idx.length++
+}
+
+func (idx *rankIndex) delete(key rankKey) {
+	var update [rankIndexMaxLevel]*rankNode
+
+	x := idx.head
+	for i := idx.level - 1; i >= 0; i-- {
+		for x.links[i].next != nil && x.links[i].next.key.before(key) {
+			x = x.links[i].next
+		}
+		update[i] = x
+	}
+
+	x = x.links[0].next
+	if x == nil || x.key != key {
+		return
+	}
+
+	for i := 0; i < idx.level; i++ {
+		if update[i].links[i].next == x {
+			update[i].links[i].span += x.links[i].span - 1
+			update[i].links[i].next = x.links[i].next
+		} else {
+			update[i].links[i].span--
+		}
+	}
+	for idx.level > 1 && idx.head.links[idx.level-1].next == nil {
+		idx.level--
+	}
+	idx.length--
+}
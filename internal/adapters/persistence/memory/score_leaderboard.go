@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// ScoreLeaderboard is a concurrency-safe in-memory ScoreLeaderboard.
+// Each board is a rank index; boards of elapsed daily, weekly and monthly
+// periods are dropped once a later period receives points.
+type ScoreLeaderboard struct {
+	mu      sync.RWMutex
+	players ports.PlayerRepository
+	boards  map[string]*rankIndex
+	windows map[string]ports.TimeWindow
+	periods map[ports.TimeWindow]string
+}
+
+// NewScoreLeaderboard creates an in-memory scoped leaderboard. Player names
+// and levels are read from players when entries are returned.
+func NewScoreLeaderboard(players ports.PlayerRepository) *ScoreLeaderboard {
+	return &ScoreLeaderboard{
+		players: players,
+		boards:  make(map[string]*rankIndex),
+		windows: make(map[string]ports.TimeWindow),
+		periods: make(map[ports.TimeWindow]string),
+	}
+}
+
+// AddScores adds points earned at the given time to each scope's leaderboard.
+// Points for a period older than the latest one seen are discarded.
+func (l *ScoreLeaderboard) AddScores(
+	_ context.Context,
+	playerID string,
+	at time.Time,
+	scores map[ports.LeaderboardScope]int,
+) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for scope, score := range scores {
+		if !l.advancePeriod(scope.Window, at) {
+			continue
+		}
+
+		board := scope.Board(at)
+		idx, ok := l.boards[board]
+		if !ok {
+			idx = newRankIndex()
+			l.boards[board] = idx
+			l.windows[board] = scope.Window
+		}
+		current, _ := idx.Score(playerID)
+		idx.Set(playerID, current+score)
+	}
+	return nil
+}
+
+// advancePeriod moves a window to the period containing t, dropping the
+// boards of the previous period. It reports false when t is in an elapsed period.
+func (l *ScoreLeaderboard) advancePeriod(window ports.TimeWindow, t time.Time) bool {
+	if window == ports.AllTime {
+		return true
+	}
+
+	period := window.Period(t)
+	latest := l.periods[window]
+	switch {
+	case period < latest:
+		return false
+	case period > latest:
+		for board, w := range l.windows {
+			if w == window {
+				delete(l.boards, board)
+				delete(l.windows, board)
+			}
+		}
+		l.periods[window] = period
+	}
+	return true
+}
+
+// GetScoreLeaderboard retrieves a page of a scoped leaderboard.
+func (l *ScoreLeaderboard) GetScoreLeaderboard(
+	ctx context.Context,
+	scope ports.LeaderboardScope,
+	at time.Time,
+	page ports.PageRequest,
+) (*ports.LeaderboardPage, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	idx, ok := l.boards[scope.Board(at)]
+	if !ok {
+		idx = newRankIndex()
+	}
+
+	keys, offset, next, err := idx.Page(page)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := l.entries(ctx, keys, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &ports.LeaderboardPage{
+		Entries:    entries,
+		Total:      idx.Len(),
+		NextCursor: next,
+	}, nil
+}
+
+// GetScoreStanding retrieves a player's rank in a scope and its neighbors.
+func (l *ScoreLeaderboard) GetScoreStanding(
+	ctx context.Context,
+	scope ports.LeaderboardScope,
+	at time.Time,
+	playerID string,
+	radius int,
+) (*ports.LeaderboardStanding, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	idx, ok := l.boards[scope.Board(at)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ports.ErrPlayerNotFound, playerID)
+	}
+	rank, ok := idx.Rank(playerID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ports.ErrPlayerNotFound, playerID)
+	}
+
+	keys, offset := idx.Around(rank, radius)
+	neighbors, err := l.entries(ctx, keys, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &ports.LeaderboardStanding{
+		Player:    neighbors[rank-1-offset],
+		Neighbors: neighbors,
+	}, nil
+}
+
+// entries converts index keys starting at offset into leaderboard entries.
+func (l *ScoreLeaderboard) entries(
+	ctx context.Context,
+	keys []rankKey,
+	offset int,
+) ([]ports.LeaderboardEntry, error) {
+	entries := make([]ports.LeaderboardEntry, len(keys))
+	for i, key := range keys {
+		player, err := l.players.GetByID(ctx, key.id)
+		if err != nil {
+			return nil, fmt.Errorf("loading ranked player: %w", err)
+		}
+		entries[i] = ports.LeaderboardEntry{
+			Rank:     offset + i + 1,
+			PlayerID: key.id,
+			Username: player.Username(),
+			Level:    player.Level(),
+			Score:    key.score,
+		}
+	}
+	return entries, nil
+}
+
+// Ensure interface compliance
+var _ ports.ScoreLeaderboard = (*ScoreLeaderboard)(nil)
@@ -0,0 +1,101 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/memory"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+func TestScoreLeaderboard_AddScores(t *testing.T) {
+	players := memory.NewPlayerRepository()
+	seedPlayers(t, players, map[string]int{"a": 0, "b": 0, "c": 0})
+	board := memory.NewScoreLeaderboard(players)
+	ctx := context.Background()
+	now := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+
+	birds := ports.LeaderboardScope{Window: ports.Weekly, IconicTaxon: "Aves"}
+	expert := ports.LeaderboardScope{Window: ports.AllTime, Difficulty: quiz.Expert}
+
+	board.AddScores(ctx, "a", now, map[ports.LeaderboardScope]int{birds: 100, expert: 50})
+	board.AddScores(ctx, "b", now, map[ports.LeaderboardScope]int{birds: 300})
+	board.AddScores(ctx, "c", now, map[ports.LeaderboardScope]int{birds: 100})
+	board.AddScores(ctx, "a", now.Add(time.Hour), map[ports.LeaderboardScope]int{birds: 100})
+
+	page, err := board.GetScoreLeaderboard(ctx, birds, now, ports.PageRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("GetScoreLeaderboard() error = %v", err)
+	}
+	if page.Total != 3 || len(page.Entries) != 2 || page.NextCursor == "" {
+		t.Fatalf("first page = %+v", page)
+	}
+	first, second := page.Entries[0], page.Entries[1]
+	if first.PlayerID != "b" || first.Score != 300 || second.PlayerID != "a" || second.Score != 200 {
+		t.Errorf("first page entries = %+v", page.Entries)
+	}
+	if first.Username != "user_b" {
+		t.Errorf("entry Username = %s, want user_b", first.Username)
+	}
+
+	next, _ := board.GetScoreLeaderboard(ctx, birds, now, ports.PageRequest{Cursor: page.NextCursor, Limit: 2})
+	if len(next.Entries) != 1 || next.Entries[0].PlayerID != "c" || next.Entries[0].Rank != 3 {
+		t.Errorf("second page = %+v", next.Entries)
+	}
+
+	standing, err := board.GetScoreStanding(ctx, expert, now, "a", 1)
+	if err != nil || standing.Player.Score != 50 || len(standing.Neighbors) != 1 {
+		t.Errorf("GetScoreStanding() = %+v, %v", standing, err)
+	}
+	if _, err := board.GetScoreStanding(ctx, expert, now, "b", 1); !errors.Is(err, ports.ErrPlayerNotFound) {
+		t.Errorf("GetScoreStanding() error = %v, want ErrPlayerNotFound", err)
+	}
+}
+
+func TestScoreLeaderboard_Periods(t *testing.T) {
+	players := memory.NewPlayerRepository()
+	seedPlayers(t, players, map[string]int{"a": 0})
+	board := memory.NewScoreLeaderboard(players)
+	ctx := context.Background()
+
+	daily := ports.LeaderboardScope{Window: ports.Daily}
+	allTime := ports.LeaderboardScope{Window: ports.AllTime}
+	monday := time.Date(2024, 5, 13, 10, 0, 0, 0, time.UTC)
+	tuesday := monday.Add(24 * time.Hour)
+
+	board.AddScores(ctx, "a", monday, map[ports.LeaderboardScope]int{daily: 100, allTime: 100})
+	board.AddScores(ctx, "a", tuesday, map[ports.LeaderboardScope]int{daily: 40, allTime: 40})
+
+	// Late points for an elapsed day are dropped
+	board.AddScores(ctx, "a", monday, map[ports.LeaderboardScope]int{daily: 5})
+
+	tests := []struct {
+		name  string
+		scope ports.LeaderboardScope
+		at    time.Time
+		want  int
+	}{
+		{"today", daily, tuesday, 40},
+		{"yesterday dropped", daily, monday, 0},
+		{"all time", allTime, monday, 140},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := board.GetScoreLeaderboard(ctx, tt.scope, tt.at, ports.PageRequest{Limit: 10})
+			if err != nil {
+				t.Fatalf("GetScoreLeaderboard() error = %v", err)
+			}
+			got := 0
+			if len(page.Entries) > 0 {
+				got = page.Entries[0].Score
+			}
+			if got != tt.want {
+				t.Errorf("score = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+// Package memory provides in-memory implementations of the persistence ports.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Default values for the session store.
+const (
+	defaultSessionTTL = 2 * time.Hour
+)
+
+// SessionRepository is a concurrency-safe in-memory QuizSessionRepository.
+// Sessions are stored as snapshots and every read returns a fresh copy, so
+// callers never share a session. Sessions expire TTL after their last save so
+// abandoned tabs do not leak memory.
+type SessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]sessionEntry
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// sessionEntry wraps a stored session with its expiry time.
+type sessionEntry struct {
+	snapshot  quiz.SessionSnapshot
+	expiresAt time.Time
+}
+
+// SessionRepositoryOption configures the session repository.
+type SessionRepositoryOption func(*SessionRepository)
+
+// WithSessionTTL sets how long a session is kept after its last save.
+func WithSessionTTL(ttl time.Duration) SessionRepositoryOption {
+	return func(r *SessionRepository) {
+		if ttl > 0 {
+			r.ttl = ttl
+		}
+	}
+}
+
+// WithClock sets the time source, mainly for tests.
+func WithClock(now func() time.Time) SessionRepositoryOption {
+	return func(r *SessionRepository) {
+		r.now = now
+	}
+}
+
+// NewSessionRepository creates a new in-memory session repository.
+func NewSessionRepository(opts ...SessionRepositoryOption) *SessionRepository {
+	r := &SessionRepository{
+		sessions: make(map[string]sessionEntry),
+		ttl:      defaultSessionTTL,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Save stores the session and refreshes its expiry.
+func (r *SessionRepository) Save(_ context.Context, session *quiz.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[session.ID()] = sessionEntry{
+		snapshot:  session.Snapshot(),
+		expiresAt: r.now().Add(r.ttl),
+	}
+	return nil
+}
+
+// GetByID retrieves a session by ID.
+func (r *SessionRepository) GetByID(_ context.Context, id string) (*quiz.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.sessions[id]
+	if !ok || r.expired(entry) {
+		return nil, ports.ErrSessionNotFound
+	}
+	return restoreSession(entry.snapshot)
+}
+
+// GetByUserID retrieves the most recent sessions of a user.
+func (r *SessionRepository) GetByUserID(_ context.Context, userID string, limit int) ([]*quiz.Session, error) {
+	sessions, err := r.userSessions(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt().After(sessions[j].StartedAt())
+	})
+
+	if limit > 0 && len(sessions) > limit {
+		sessions = sessions[:limit]
+	}
+	return sessions, nil
+}
+
+// GetStats aggregates statistics over the completed sessions of a user.
+func (r *SessionRepository) GetStats(_ context.Context, userID string) (*ports.UserQuizStats, error) {
+	stats := &ports.UserQuizStats{}
+	taxonCounts := make(map[string]int)
+	accuracySum := 0.0
+
+	sessions, err := r.userSessions(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		if session.Status() != quiz.SessionCompleted {
+			continue
+		}
+		stats.TotalSessions++
+		stats.TotalQuestions += session.AnsweredCount()
+		stats.TotalCorrect += session.CorrectCount()
+		stats.TotalScore += session.TotalScore()
+		stats.BestStreak = max(stats.BestStreak, session.MaxStreak())
+		accuracySum += session.Accuracy()
+		countCorrectTaxa(session, taxonCounts)
+	}
+
+	if stats.TotalSessions > 0 {
+		stats.AverageAccuracy = accuracySum / float64(stats.TotalSessions)
+	}
+	stats.FavoriteTaxon = favoriteTaxon(taxonCounts)
+	return stats, nil
+}
+
+// EvictExpired removes expired sessions and returns how many were removed.
+func (r *SessionRepository) EvictExpired() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	evicted := 0
+	for id, entry := range r.sessions {
+		if r.expired(entry) {
+			delete(r.sessions, id)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// StartEviction periodically evicts expired sessions until ctx is cancelled.
+func (r *SessionRepository) StartEviction(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.EvictExpired()
+			}
+		}
+	}()
+}
+
+// userSessions returns copies of the live sessions of a user.
+func (r *SessionRepository) userSessions(userID string) ([]*quiz.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sessions := make([]*quiz.Session, 0)
+	for _, entry := range r.sessions {
+		if entry.snapshot.UserID != userID || r.expired(entry) {
+			continue
+		}
+		session, err := restoreSession(entry.snapshot)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// restoreSession rebuilds a stored session.
+func restoreSession(snap quiz.SessionSnapshot) (*quiz.Session, error) {
+	session, err := quiz.RestoreSession(snap)
+	if err != nil {
+		return nil, fmt.Errorf("restoring session %s: %w", snap.ID, err)
+	}
+	return session, nil
+}
+
+// expired reports whether the entry is past its expiry time.
+func (r *SessionRepository) expired(entry sessionEntry) bool {
+	return !r.now().Before(entry.expiresAt)
+}
+
+// countCorrectTaxa counts correct identifications per iconic taxon.
+func countCorrectTaxa(session *quiz.Session, counts map[string]int) {
+	for _, aq := range session.AnsweredQuestions() {
+		if taxon := aq.Question.CorrectSpecies().IconicTaxon(); aq.Answer.IsCorrect && taxon != "" {
+			counts[taxon]++
+		}
+	}
+}
+
+// favoriteTaxon returns the most identified taxon, ties broken alphabetically.
+func favoriteTaxon(counts map[string]int) string {
+	favorite := ""
+	for taxon, count := range counts {
+		best := counts[favorite]
+		if count > best || (count == best && taxon < favorite) {
+			favorite = taxon
+		}
+	}
+	return favorite
+}
+
+// Ensure interface compliance
+var _ ports.QuizSessionRepository = (*SessionRepository)(nil)
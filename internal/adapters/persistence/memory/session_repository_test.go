@@ -0,0 +1,253 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/memory"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+func createTestQuestion(id string, correctID int, taxon string) *quiz.Question {
+	correct, _ := species.New(correctID, "Correct Species", "Correct", taxon)
+	wrong, _ := species.New(correctID+100, "Wrong Species", "Wrong", taxon)
+
+	choices := []quiz.Choice{
+		{Species: correct, IsCorrect: true},
+		{Species: wrong, IsCorrect: false},
+	}
+
+	q, _ := quiz.NewQuestion(id, quiz.ImageQuiz, quiz.Beginner, correct, choices, "https://example.com/img.jpg")
+	return q
+}
+
+func createTestSession(userID string, questions ...*quiz.Question) *quiz.Session {
+	session, _ := quiz.NewSessionBuilder().
+		WithUserID(userID).
+		WithQuestions(questions).
+		Build()
+	session.Start()
+	return session
+}
+
+// fakeClock is a controllable time source.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestSessionRepository_SaveAndGetByID(t *testing.T) {
+	repo := memory.NewSessionRepository()
+	session := createTestSession("user1", createTestQuestion("q1", 1, "Aves"))
+
+	if err := repo.Save(context.Background(), session); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := repo.GetByID(context.Background(), session.ID())
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.ID() != session.ID() {
+		t.Errorf("GetByID() ID = %s, want %s", got.ID(), session.ID())
+	}
+}
+
+func TestSessionRepository_GetByID_NotFound(t *testing.T) {
+	repo := memory.NewSessionRepository()
+
+	_, err := repo.GetByID(context.Background(), "nonexistent")
+	if !errors.Is(err, ports.ErrSessionNotFound) {
+		t.Errorf("GetByID() error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestSessionRepository_TTL(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	repo := memory.NewSessionRepository(
+		memory.WithSessionTTL(time.Hour),
+		memory.WithClock(clock.Now),
+	)
+
+	session := createTestSession("user1", createTestQuestion("q1", 1, "Aves"))
+	repo.Save(context.Background(), session)
+
+	clock.Advance(30 * time.Minute)
+	if _, err := repo.GetByID(context.Background(), session.ID()); err != nil {
+		t.Fatalf("GetByID() before expiry error = %v", err)
+	}
+
+	// Saving again refreshes the expiry
+	repo.Save(context.Background(), session)
+	clock.Advance(45 * time.Minute)
+	if _, err := repo.GetByID(context.Background(), session.ID()); err != nil {
+		t.Fatalf("GetByID() after refresh error = %v", err)
+	}
+
+	clock.Advance(time.Hour)
+	if _, err := repo.GetByID(context.Background(), session.ID()); !errors.Is(err, ports.ErrSessionNotFound) {
+		t.Errorf("GetByID() after expiry error = %v, want ErrSessionNotFound", err)
+	}
+
+	if evicted := repo.EvictExpired(); evicted != 1 {
+		t.Errorf("EvictExpired() = %d, want 1", evicted)
+	}
+}
+
+func TestSessionRepository_GetByUserID(t *testing.T) {
+	repo := memory.NewSessionRepository()
+
+	for i := 0; i < 3; i++ {
+		repo.Save(context.Background(), createTestSession("user1", createTestQuestion("q", i+1, "Aves")))
+	}
+	repo.Save(context.Background(), createTestSession("user2", createTestQuestion("q", 9, "Aves")))
+
+	sessions, _ := repo.GetByUserID(context.Background(), "user1", 2)
+	if len(sessions) != 2 {
+		t.Fatalf("GetByUserID() len = %d, want 2", len(sessions))
+	}
+	for _, s := range sessions {
+		if s.UserID() != "user1" {
+			t.Errorf("GetByUserID() returned session of %s", s.UserID())
+		}
+	}
+	if sessions[0].StartedAt().Before(sessions[1].StartedAt()) {
+		t.Error("GetByUserID() should return most recent sessions first")
+	}
+}
+
+func TestSessionRepository_GetStats(t *testing.T) {
+	repo := memory.NewSessionRepository()
+
+	s1 := createTestSession("user1",
+		createTestQuestion("q1", 1, "Aves"),
+		createTestQuestion("q2", 2, "Aves"),
+		createTestQuestion("q3", 3, "Mammalia"),
+	)
+	s1.SubmitAnswer(1, time.Second)
+	s1.SubmitAnswer(2, time.Second)
+	s1.SubmitAnswer(999, time.Second)
+
+	s2 := createTestSession("user1", createTestQuestion("q4", 4, "Mammalia"))
+	s2.SubmitAnswer(4, time.Second)
+
+	// In-progress sessions are not counted
+	s3 := createTestSession("user1", createTestQuestion("q5", 5, "Mammalia"), createTestQuestion("q6", 6, "Fungi"))
+	s3.SubmitAnswer(5, time.Second)
+
+	for _, s := range []*quiz.Session{s1, s2, s3} {
+		repo.Save(context.Background(), s)
+	}
+
+	stats, err := repo.GetStats(context.Background(), "user1")
+	if err != nil {
+		t.Fatalf("GetStats() error = %v", err)
+	}
+
+	if stats.TotalSessions != 2 {
+		t.Errorf("TotalSessions = %d, want 2", stats.TotalSessions)
+	}
+	if stats.TotalQuestions != 4 {
+		t.Errorf("TotalQuestions = %d, want 4", stats.TotalQuestions)
+	}
+	if stats.TotalCorrect != 3 {
+		t.Errorf("TotalCorrect = %d, want 3", stats.TotalCorrect)
+	}
+	if stats.TotalScore != s1.TotalScore()+s2.TotalScore() {
+		t.Errorf("TotalScore = %d, want %d", stats.TotalScore, s1.TotalScore()+s2.TotalScore())
+	}
+	if stats.BestStreak != 2 {
+		t.Errorf("BestStreak = %d, want 2", stats.BestStreak)
+	}
+	wantAccuracy := (s1.Accuracy() + s2.Accuracy()) / 2
+	if stats.AverageAccuracy != wantAccuracy {
+		t.Errorf("AverageAccuracy = %f, want %f", stats.AverageAccuracy, wantAccuracy)
+	}
+	if stats.FavoriteTaxon != "Aves" {
+		t.Errorf("FavoriteTaxon = %s, want Aves", stats.FavoriteTaxon)
+	}
+}
+
+func TestSessionRepository_GetStats_NoSessions(t *testing.T) {
+	repo := memory.NewSessionRepository()
+
+	stats, err := repo.GetStats(context.Background(), "user1")
+	if err != nil {
+		t.Fatalf("GetStats() error = %v", err)
+	}
+	if stats.TotalSessions != 0 || stats.FavoriteTaxon != "" {
+		t.Errorf("GetStats() = %+v, want empty stats", stats)
+	}
+}
+
+func TestSessionRepository_ReturnsCopies(t *testing.T) {
+	repo := memory.NewSessionRepository()
+	session := createTestSession("user1", createTestQuestion("q1", 1, "Aves"), createTestQuestion("q2", 2, "Aves"))
+	repo.Save(context.Background(), session)
+
+	// Neither the saved session nor a read one is shared with the store
+	session.SubmitAnswer(1, time.Second)
+	got, _ := repo.GetByID(context.Background(), session.ID())
+	got.SubmitAnswer(1, time.Second)
+
+	stored, err := repo.GetByID(context.Background(), session.ID())
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if stored.AnsweredCount() != 0 {
+		t.Errorf("AnsweredCount() = %d, want 0 until the session is saved again", stored.AnsweredCount())
+	}
+}
+
+func TestSessionRepository_ConcurrentAccess(t *testing.T) {
+	repo := memory.NewSessionRepository()
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			userID := fmt.Sprintf("user%d", i%5)
+			session := createTestSession(userID, createTestQuestion("q", i+1, "Aves"))
+			repo.Save(context.Background(), session)
+			repo.GetByID(context.Background(), session.ID())
+			repo.GetByUserID(context.Background(), userID, 10)
+			repo.GetStats(context.Background(), userID)
+			repo.EvictExpired()
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestSessionRepository_StartEviction(t *testing.T) {
+	repo := memory.NewSessionRepository(memory.WithSessionTTL(time.Millisecond))
+	session := createTestSession("user1", createTestQuestion("q1", 1, "Aves"))
+	repo.Save(context.Background(), session)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo.StartEviction(ctx, 5*time.Millisecond)
+
+	time.Sleep(30 * time.Millisecond)
+	if evicted := repo.EvictExpired(); evicted != 0 {
+		t.Errorf("EvictExpired() = %d, want 0 after background eviction", evicted)
+	}
+}
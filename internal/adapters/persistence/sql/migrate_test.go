@@ -0,0 +1,56 @@
+package sql_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+
+	sqlstore "github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/sql"
+)
+
+// openTestDB opens a migrated SQLite database in a temporary directory.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "naturieux.db")
+	db, err := sqlstore.Open(context.Background(), "sqlite", dsn)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// latestSchemaVersion is the number of embedded migrations.
+const latestSchemaVersion = 3
+
+func TestMigrate(t *testing.T) {
+	db := openTestDB(t)
+
+	version, err := sqlstore.SchemaVersion(context.Background(), db)
+	if err != nil {
+		t.Fatalf("SchemaVersion() error = %v", err)
+	}
+	if version != latestSchemaVersion {
+		t.Errorf("SchemaVersion() = %d, want %d", version, latestSchemaVersion)
+	}
+
+	// Running migrations again is a no-op
+	if err := sqlstore.Migrate(context.Background(), db); err != nil {
+		t.Fatalf("Migrate() second run error = %v", err)
+	}
+
+	var count int
+	db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&count)
+	if count != latestSchemaVersion {
+		t.Errorf("schema_migrations rows = %d, want %d", count, latestSchemaVersion)
+	}
+}
+
+func TestOpen_InvalidDriver(t *testing.T) {
+	if _, err := sqlstore.Open(context.Background(), "unknown", ""); err == nil {
+		t.Error("Open() should fail with an unknown driver")
+	}
+}
@@ -0,0 +1,12 @@
+-- Players keep their full domain state as a JSON snapshot; the columns
+-- duplicate what queries need to filter and sort on.
+CREATE TABLE players (
+    id         TEXT PRIMARY KEY,
+    username   TEXT NOT NULL UNIQUE,
+    total_xp   INTEGER NOT NULL DEFAULT 0,
+    level      INTEGER NOT NULL DEFAULT 1,
+    created_at INTEGER NOT NULL,
+    state      TEXT NOT NULL
+);
+
+CREATE INDEX idx_players_total_xp ON players (total_xp DESC, id ASC);
@@ -0,0 +1,26 @@
+-- Sessions are stored as JSON snapshots with denormalized counters used by
+-- the stats aggregates. Timestamps are Unix nanoseconds.
+CREATE TABLE quiz_sessions (
+    id             TEXT PRIMARY KEY,
+    user_id        TEXT NOT NULL,
+    status         TEXT NOT NULL,
+    difficulty     TEXT NOT NULL,
+    total_score    INTEGER NOT NULL DEFAULT 0,
+    answered_count INTEGER NOT NULL DEFAULT 0,
+    correct_count  INTEGER NOT NULL DEFAULT 0,
+    max_streak     INTEGER NOT NULL DEFAULT 0,
+    accuracy       REAL NOT NULL DEFAULT 0,
+    started_at     INTEGER NOT NULL DEFAULT 0,
+    state          TEXT NOT NULL
+);
+
+CREATE INDEX idx_quiz_sessions_user ON quiz_sessions (user_id, started_at DESC);
+
+-- One row per answered question, used to aggregate per-taxon results.
+CREATE TABLE quiz_answers (
+    session_id   TEXT NOT NULL REFERENCES quiz_sessions (id) ON DELETE CASCADE,
+    position     INTEGER NOT NULL,
+    iconic_taxon TEXT NOT NULL DEFAULT '',
+    is_correct   BOOLEAN NOT NULL,
+    PRIMARY KEY (session_id, position)
+);
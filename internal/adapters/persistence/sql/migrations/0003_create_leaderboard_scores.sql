@@ -0,0 +1,10 @@
+-- Points per player on each scoped leaderboard. The board key combines the
+-- time window, its period, the iconic taxon and the difficulty.
+CREATE TABLE leaderboard_scores (
+    board     TEXT NOT NULL,
+    player_id TEXT NOT NULL,
+    score     INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (board, player_id)
+);
+
+CREATE INDEX idx_leaderboard_scores_rank ON leaderboard_scores (board, score DESC, player_id ASC);
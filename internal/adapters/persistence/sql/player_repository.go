@@ -0,0 +1,269 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// PlayerRepository is a database-backed PlayerRepository.
+type PlayerRepository struct {
+	db *sql.DB
+}
+
+// NewPlayerRepository creates a player repository on a migrated database.
+func NewPlayerRepository(db *sql.DB) *PlayerRepository {
+	return &PlayerRepository{db: db}
+}
+
+// Create inserts a new player, rejecting duplicate IDs and usernames.
+func (r *PlayerRepository) Create(ctx context.Context, player *gamification.Player) error {
+	snap := player.Snapshot()
+	state, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("encoding player: %w", err)
+	}
+
+	// Conflicts are detected without relying on driver specific errors
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO players (id, username, total_xp, level, created_at, state) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT DO NOTHING`,
+		snap.ID, snap.Username, snap.TotalXP, snap.Level, snap.CreatedAt.UnixNano(), string(state),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting player: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("inserting player: %w", err)
+	}
+	if n > 0 {
+		return nil
+	}
+
+	var taken bool
+	err = r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM players WHERE username = ?)`, snap.Username).
+		Scan(&taken)
+	switch {
+	case err != nil:
+		return fmt.Errorf("checking username: %w", err)
+	case taken:
+		return fmt.Errorf("%w: %s", ports.ErrUsernameTaken, snap.Username)
+	default:
+		return fmt.Errorf("player already exists: %s", snap.ID)
+	}
+}
+
+// GetByID retrieves a player by ID.
+func (r *PlayerRepository) GetByID(ctx context.Context, id string) (*gamification.Player, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT state FROM players WHERE id = ?`, id)
+	return scanPlayer(row, id)
+}
+
+// GetByUsername retrieves a player by username.
+func (r *PlayerRepository) GetByUsername(ctx context.Context, username string) (*gamification.Player, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT state FROM players WHERE username = ?`, username)
+	return scanPlayer(row, username)
+}
+
+// Update saves a player's current state.
+func (r *PlayerRepository) Update(ctx context.Context, player *gamification.Player) error {
+	snap := player.Snapshot()
+	state, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("encoding player: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE players SET username = ?, total_xp = ?, level = ?, state = ? WHERE id = ?`,
+		snap.Username, snap.TotalXP, snap.Level, string(state), snap.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("updating player: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%w: %s", ports.ErrPlayerNotFound, snap.ID)
+	}
+	return nil
+}
+
+// GetLeaderboard retrieves top players by XP, ties broken by ID.
+func (r *PlayerRepository) GetLeaderboard(ctx context.Context, limit int) ([]*gamification.Player, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT state FROM players ORDER BY total_xp DESC, id ASC LIMIT ?`, queryLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("querying leaderboard: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	players := make([]*gamification.Player, 0)
+	for rows.Next() {
+		player, err := scanPlayer(rows, "")
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, player)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading leaderboard: %w", err)
+	}
+	return players, nil
+}
+
+// GetXPLeaderboard retrieves a page of the all-time XP leaderboard using the
+// (total_xp DESC, id ASC) index.
+func (r *PlayerRepository) GetXPLeaderboard(
+	ctx context.Context,
+	page ports.PageRequest,
+) (*ports.LeaderboardPage, error) {
+	offset := max(page.Offset, 0)
+	if page.Cursor != "" {
+		cursor, err := ports.ParseLeaderboardCursor(page.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		err = r.db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM players WHERE total_xp > ? OR (total_xp = ? AND id <= ?)`,
+			cursor.Score, cursor.Score, cursor.PlayerID,
+		).Scan(&offset)
+		if err != nil {
+			return nil, fmt.Errorf("resolving cursor: %w", err)
+		}
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM players`).Scan(&total); err != nil {
+		return nil, fmt.Errorf("counting players: %w", err)
+	}
+
+	entries, err := r.queryEntries(ctx, offset, page.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(entries, offset, total), nil
+}
+
+// GetXPStanding retrieves a player's XP rank and its neighbors.
+func (r *PlayerRepository) GetXPStanding(
+	ctx context.Context,
+	playerID string,
+	radius int,
+) (*ports.LeaderboardStanding, error) {
+	var score int
+	err := r.db.QueryRowContext(ctx, `SELECT total_xp FROM players WHERE id = ?`, playerID).Scan(&score)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ports.ErrPlayerNotFound, playerID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading player score: %w", err)
+	}
+
+	var ahead int
+	err = r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM players WHERE total_xp > ? OR (total_xp = ? AND id < ?)`,
+		score, score, playerID,
+	).Scan(&ahead)
+	if err != nil {
+		return nil, fmt.Errorf("computing rank: %w", err)
+	}
+
+	offset := max(ahead-radius, 0)
+	neighbors, err := r.queryEntries(ctx, offset, ahead+radius+1-offset)
+	if err != nil {
+		return nil, err
+	}
+	if ahead-offset >= len(neighbors) {
+		return nil, fmt.Errorf("%w: %s", ports.ErrPlayerNotFound, playerID)
+	}
+	return &ports.LeaderboardStanding{
+		Player:    neighbors[ahead-offset],
+		Neighbors: neighbors,
+	}, nil
+}
+
+// queryEntries reads ranked leaderboard entries starting at offset.
+func (r *PlayerRepository) queryEntries(ctx context.Context, offset, limit int) ([]ports.LeaderboardEntry, error) {
+	if limit <= 0 {
+		return []ports.LeaderboardEntry{}, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, username, level, total_xp FROM players ORDER BY total_xp DESC, id ASC LIMIT ? OFFSET ?`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying leaderboard: %w", err)
+	}
+	return readEntries(rows, offset)
+}
+
+// newPage builds a leaderboard page, with a cursor when entries remain past it.
+func newPage(entries []ports.LeaderboardEntry, offset, total int) *ports.LeaderboardPage {
+	page := &ports.LeaderboardPage{Entries: entries, Total: total}
+	if len(entries) > 0 && offset+len(entries) < total {
+		last := entries[len(entries)-1]
+		page.NextCursor = ports.LeaderboardCursor{Score: last.Score, PlayerID: last.PlayerID}.Encode()
+	}
+	return page
+}
+
+// readEntries reads (id, username, level, score) rows ranked from offset and closes them.
+func readEntries(rows *sql.Rows, offset int) ([]ports.LeaderboardEntry, error) {
+	defer func() { _ = rows.Close() }()
+
+	entries := make([]ports.LeaderboardEntry, 0)
+	for rows.Next() {
+		entry := ports.LeaderboardEntry{Rank: offset + len(entries) + 1}
+		if err := rows.Scan(&entry.PlayerID, &entry.Username, &entry.Level, &entry.Score); err != nil {
+			return nil, fmt.Errorf("reading leaderboard: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading leaderboard: %w", err)
+	}
+	return entries, nil
+}
+
+// queryLimit maps a non-positive limit to "no limit".
+func queryLimit(limit int) int {
+	if limit <= 0 {
+		return math.MaxInt32
+	}
+	return limit
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanPlayer decodes a player from a row holding its state column.
+func scanPlayer(row rowScanner, key string) (*gamification.Player, error) {
+	var state string
+	if err := row.Scan(&state); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ports.ErrPlayerNotFound, key)
+		}
+		return nil, fmt.Errorf("reading player: %w", err)
+	}
+
+	var snap gamification.PlayerSnapshot
+	if err := json.Unmarshal([]byte(state), &snap); err != nil {
+		return nil, fmt.Errorf("decoding player: %w", err)
+	}
+	return gamification.RestorePlayer(snap)
+}
+
+// Ensure interface compliance
+var (
+	_ ports.PlayerRepository = (*PlayerRepository)(nil)
+	_ ports.XPLeaderboard    = (*PlayerRepository)(nil)
+)
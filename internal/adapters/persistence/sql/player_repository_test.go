@@ -0,0 +1,209 @@
+package sql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	sqlstore "github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/sql"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+func TestPlayerRepository_CreateAndGet(t *testing.T) {
+	repo := sqlstore.NewPlayerRepository(openTestDB(t))
+	ctx := context.Background()
+
+	player, _ := gamification.NewPlayer("p1", "naturelover")
+	player.AddXP(300)
+	player.RecordGame(gamification.GameSummary{Correct: 9, Total: 10, MaxStreak: 6})
+
+	if err := repo.Create(ctx, player); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	byID, err := repo.GetByID(ctx, "p1")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	gotJSON, _ := json.Marshal(byID.Snapshot())
+	wantJSON, _ := json.Marshal(player.Snapshot())
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("GetByID() = %s, want %s", gotJSON, wantJSON)
+	}
+
+	byName, err := repo.GetByUsername(ctx, "naturelover")
+	if err != nil {
+		t.Fatalf("GetByUsername() error = %v", err)
+	}
+	if byName.ID() != "p1" {
+		t.Errorf("GetByUsername() ID = %s, want p1", byName.ID())
+	}
+}
+
+func TestPlayerRepository_NotFound(t *testing.T) {
+	repo := sqlstore.NewPlayerRepository(openTestDB(t))
+	ctx := context.Background()
+
+	if _, err := repo.GetByID(ctx, "missing"); !errors.Is(err, ports.ErrPlayerNotFound) {
+		t.Errorf("GetByID() error = %v, want ErrPlayerNotFound", err)
+	}
+	if _, err := repo.GetByUsername(ctx, "missing"); !errors.Is(err, ports.ErrPlayerNotFound) {
+		t.Errorf("GetByUsername() error = %v, want ErrPlayerNotFound", err)
+	}
+
+	ghost, _ := gamification.NewPlayer("ghost", "ghost")
+	if err := repo.Update(ctx, ghost); !errors.Is(err, ports.ErrPlayerNotFound) {
+		t.Errorf("Update() error = %v, want ErrPlayerNotFound", err)
+	}
+}
+
+func TestPlayerRepository_DuplicateUsername(t *testing.T) {
+	repo := sqlstore.NewPlayerRepository(openTestDB(t))
+	ctx := context.Background()
+
+	p1, _ := gamification.NewPlayer("p1", "same")
+	p2, _ := gamification.NewPlayer("p2", "same")
+	p3, _ := gamification.NewPlayer("p1", "other")
+
+	repo.Create(ctx, p1)
+	if err := repo.Create(ctx, p2); !errors.Is(err, ports.ErrUsernameTaken) {
+		t.Errorf("Create() error = %v, want ErrUsernameTaken", err)
+	}
+	if err := repo.Create(ctx, p3); err == nil || errors.Is(err, ports.ErrUsernameTaken) {
+		t.Errorf("Create() error = %v, want a duplicate ID error", err)
+	}
+}
+
+func TestPlayerRepository_Update(t *testing.T) {
+	repo := sqlstore.NewPlayerRepository(openTestDB(t))
+	ctx := context.Background()
+
+	player, _ := gamification.NewPlayer("p1", "naturelover")
+	repo.Create(ctx, player)
+
+	player.AddXP(1000)
+	if err := repo.Update(ctx, player); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, "p1")
+	if got.TotalXP() != 1000 || got.Level() != player.Level() {
+		t.Errorf("after Update() XP/level = %d/%d, want 1000/%d", got.TotalXP(), got.Level(), player.Level())
+	}
+}
+
+func TestPlayerRepository_GetLeaderboard(t *testing.T) {
+	repo := sqlstore.NewPlayerRepository(openTestDB(t))
+	ctx := context.Background()
+
+	for _, p := range []struct {
+		id string
+		xp int
+	}{{"c", 100}, {"a", 500}, {"b", 100}, {"d", 50}} {
+		player, _ := gamification.NewPlayer(p.id, "user_"+p.id)
+		player.AddXP(p.xp)
+		repo.Create(ctx, player)
+	}
+
+	top, err := repo.GetLeaderboard(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetLeaderboard() error = %v", err)
+	}
+
+	want := []string{"a", "b", "c"}
+	if len(top) != len(want) {
+		t.Fatalf("GetLeaderboard() len = %d, want %d", len(top), len(want))
+	}
+	for i, id := range want {
+		if top[i].ID() != id {
+			t.Errorf("GetLeaderboard()[%d] = %s, want %s", i, top[i].ID(), id)
+		}
+	}
+}
+
+func TestPlayerRepository_GetXPLeaderboard(t *testing.T) {
+	repo := sqlstore.NewPlayerRepository(openTestDB(t))
+	ctx := context.Background()
+
+	for _, p := range []struct {
+		id string
+		xp int
+	}{{"c", 100}, {"a", 500}, {"b", 100}, {"d", 50}, {"e", 100}} {
+		player, _ := gamification.NewPlayer(p.id, "user_"+p.id)
+		player.AddXP(p.xp)
+		repo.Create(ctx, player)
+	}
+
+	want := []string{"a", "b", "c", "e", "d"}
+	var got []string
+	page := ports.PageRequest{Limit: 2}
+	for {
+		result, err := repo.GetXPLeaderboard(ctx, page)
+		if err != nil {
+			t.Fatalf("GetXPLeaderboard() error = %v", err)
+		}
+		if result.Total != len(want) {
+			t.Errorf("GetXPLeaderboard() Total = %d, want %d", result.Total, len(want))
+		}
+		for _, e := range result.Entries {
+			if e.Rank != len(got)+1 {
+				t.Errorf("entry %s Rank = %d, want %d", e.PlayerID, e.Rank, len(got)+1)
+			}
+			got = append(got, e.PlayerID)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		page.Cursor = result.NextCursor
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("cursor pages = %v, want %v", got, want)
+	}
+
+	offsetPage, err := repo.GetXPLeaderboard(ctx, ports.PageRequest{Offset: 3, Limit: 10})
+	if err != nil {
+		t.Fatalf("GetXPLeaderboard() error = %v", err)
+	}
+	if len(offsetPage.Entries) != 2 || offsetPage.Entries[0].PlayerID != "e" || offsetPage.Entries[0].Rank != 4 {
+		t.Errorf("offset page = %+v", offsetPage.Entries)
+	}
+
+	_, err = repo.GetXPLeaderboard(ctx, ports.PageRequest{Cursor: "!!!", Limit: 1})
+	if !errors.Is(err, ports.ErrInvalidCursor) {
+		t.Errorf("GetXPLeaderboard() error = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestPlayerRepository_GetXPStanding(t *testing.T) {
+	repo := sqlstore.NewPlayerRepository(openTestDB(t))
+	ctx := context.Background()
+
+	for i, id := range []string{"a", "b", "c", "d", "e"} {
+		player, _ := gamification.NewPlayer(id, "user_"+id)
+		player.AddXP(500 - i*100)
+		repo.Create(ctx, player)
+	}
+
+	standing, err := repo.GetXPStanding(ctx, "d", 2)
+	if err != nil {
+		t.Fatalf("GetXPStanding() error = %v", err)
+	}
+	if standing.Player.PlayerID != "d" || standing.Player.Rank != 4 || standing.Player.Username != "user_d" {
+		t.Errorf("GetXPStanding() Player = %+v", standing.Player)
+	}
+
+	ids := make([]string, len(standing.Neighbors))
+	for i, e := range standing.Neighbors {
+		ids[i] = e.PlayerID
+	}
+	if want := "[b c d e]"; fmt.Sprint(ids) != want {
+		t.Errorf("GetXPStanding() Neighbors = %v, want %s", ids, want)
+	}
+
+	if _, err := repo.GetXPStanding(ctx, "missing", 1); !errors.Is(err, ports.ErrPlayerNotFound) {
+		t.Errorf("GetXPStanding() error = %v, want ErrPlayerNotFound", err)
+	}
+}
@@ -0,0 +1,162 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// ScoreLeaderboard is a database-backed ScoreLeaderboard. Every period keeps
+// its own board, so past periods remain in the table.
+type ScoreLeaderboard struct {
+	db *sql.DB
+}
+
+// NewScoreLeaderboard creates a scoped leaderboard on a migrated database.
+func NewScoreLeaderboard(db *sql.DB) *ScoreLeaderboard {
+	return &ScoreLeaderboard{db: db}
+}
+
+// AddScores adds points earned at the given time to each scope's leaderboard.
+func (l *ScoreLeaderboard) AddScores(
+	ctx context.Context,
+	playerID string,
+	at time.Time,
+	scores map[ports.LeaderboardScope]int,
+) error {
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Error ignored: no-op after commit
+
+	for scope, score := range scores {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO leaderboard_scores (board, player_id, score) VALUES (?, ?, ?)
+			ON CONFLICT (board, player_id) DO UPDATE SET score = score + excluded.score`,
+			scope.Board(at), playerID, score,
+		)
+		if err != nil {
+			return fmt.Errorf("adding score: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing scores: %w", err)
+	}
+	return nil
+}
+
+// GetScoreLeaderboard retrieves a page of a scoped leaderboard.
+func (l *ScoreLeaderboard) GetScoreLeaderboard(
+	ctx context.Context,
+	scope ports.LeaderboardScope,
+	at time.Time,
+	page ports.PageRequest,
+) (*ports.LeaderboardPage, error) {
+	board := scope.Board(at)
+
+	offset := max(page.Offset, 0)
+	if page.Cursor != "" {
+		cursor, err := ports.ParseLeaderboardCursor(page.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		err = l.db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM leaderboard_scores
+			WHERE board = ? AND (score > ? OR (score = ? AND player_id <= ?))`,
+			board, cursor.Score, cursor.Score, cursor.PlayerID,
+		).Scan(&offset)
+		if err != nil {
+			return nil, fmt.Errorf("resolving cursor: %w", err)
+		}
+	}
+
+	var total int
+	err := l.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM leaderboard_scores WHERE board = ?`, board).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("counting ranked players: %w", err)
+	}
+
+	entries, err := l.queryEntries(ctx, board, offset, page.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(entries, offset, total), nil
+}
+
+// GetScoreStanding retrieves a player's rank in a scope and its neighbors.
+func (l *ScoreLeaderboard) GetScoreStanding(
+	ctx context.Context,
+	scope ports.LeaderboardScope,
+	at time.Time,
+	playerID string,
+	radius int,
+) (*ports.LeaderboardStanding, error) {
+	board := scope.Board(at)
+
+	var score int
+	err := l.db.QueryRowContext(ctx,
+		`SELECT score FROM leaderboard_scores WHERE board = ? AND player_id = ?`, board, playerID,
+	).Scan(&score)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ports.ErrPlayerNotFound, playerID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading player score: %w", err)
+	}
+
+	var ahead int
+	err = l.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM leaderboard_scores
+		WHERE board = ? AND (score > ? OR (score = ? AND player_id < ?))`,
+		board, score, score, playerID,
+	).Scan(&ahead)
+	if err != nil {
+		return nil, fmt.Errorf("computing rank: %w", err)
+	}
+
+	offset := max(ahead-radius, 0)
+	neighbors, err := l.queryEntries(ctx, board, offset, ahead+radius+1-offset)
+	if err != nil {
+		return nil, err
+	}
+	if ahead-offset >= len(neighbors) {
+		return nil, fmt.Errorf("%w: %s", ports.ErrPlayerNotFound, playerID)
+	}
+	return &ports.LeaderboardStanding{
+		Player:    neighbors[ahead-offset],
+		Neighbors: neighbors,
+	}, nil
+}
+
+// queryEntries reads ranked entries of a board starting at offset.
+func (l *ScoreLeaderboard) queryEntries(
+	ctx context.Context,
+	board string,
+	offset, limit int,
+) ([]ports.LeaderboardEntry, error) {
+	if limit <= 0 {
+		return []ports.LeaderboardEntry{}, nil
+	}
+
+	rows, err := l.db.QueryContext(ctx,
+		`SELECT s.player_id, p.username, p.level, s.score
+		FROM leaderboard_scores s JOIN players p ON p.id = s.player_id
+		WHERE s.board = ?
+		ORDER BY s.score DESC, s.player_id ASC
+		LIMIT ? OFFSET ?`,
+		board, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying leaderboard: %w", err)
+	}
+	return readEntries(rows, offset)
+}
+
+// Ensure interface compliance
+var _ ports.ScoreLeaderboard = (*ScoreLeaderboard)(nil)
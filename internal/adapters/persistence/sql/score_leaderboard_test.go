@@ -0,0 +1,72 @@
+package sql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sqlstore "github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/sql"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+func TestScoreLeaderboard(t *testing.T) {
+	db := openTestDB(t)
+	players := sqlstore.NewPlayerRepository(db)
+	board := sqlstore.NewScoreLeaderboard(db)
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b", "c"} {
+		player, _ := gamification.NewPlayer(id, "user_"+id)
+		players.Create(ctx, player)
+	}
+
+	now := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	birds := ports.LeaderboardScope{Window: ports.Weekly, IconicTaxon: "Aves"}
+	expert := ports.LeaderboardScope{Window: ports.AllTime, Difficulty: quiz.Expert}
+
+	board.AddScores(ctx, "a", now, map[ports.LeaderboardScope]int{birds: 100, expert: 50})
+	board.AddScores(ctx, "b", now, map[ports.LeaderboardScope]int{birds: 300})
+	board.AddScores(ctx, "c", now, map[ports.LeaderboardScope]int{birds: 100})
+	if err := board.AddScores(ctx, "a", now, map[ports.LeaderboardScope]int{birds: 100}); err != nil {
+		t.Fatalf("AddScores() error = %v", err)
+	}
+
+	page, err := board.GetScoreLeaderboard(ctx, birds, now, ports.PageRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("GetScoreLeaderboard() error = %v", err)
+	}
+	if page.Total != 3 || len(page.Entries) != 2 || page.NextCursor == "" {
+		t.Fatalf("first page = %+v", page)
+	}
+	if page.Entries[0].PlayerID != "b" || page.Entries[0].Username != "user_b" || page.Entries[1].Score != 200 {
+		t.Errorf("first page entries = %+v", page.Entries)
+	}
+
+	next, err := board.GetScoreLeaderboard(ctx, birds, now, ports.PageRequest{Cursor: page.NextCursor, Limit: 2})
+	if err != nil {
+		t.Fatalf("GetScoreLeaderboard() error = %v", err)
+	}
+	if len(next.Entries) != 1 || next.Entries[0].PlayerID != "c" || next.Entries[0].Rank != 3 {
+		t.Errorf("second page = %+v", next.Entries)
+	}
+
+	// Next week starts an empty board
+	later, _ := board.GetScoreLeaderboard(ctx, birds, now.Add(7*24*time.Hour), ports.PageRequest{Limit: 2})
+	if later.Total != 0 {
+		t.Errorf("next week Total = %d, want 0", later.Total)
+	}
+
+	standing, err := board.GetScoreStanding(ctx, birds, now, "c", 1)
+	if err != nil {
+		t.Fatalf("GetScoreStanding() error = %v", err)
+	}
+	if standing.Player.Rank != 3 || len(standing.Neighbors) != 2 || standing.Neighbors[0].PlayerID != "a" {
+		t.Errorf("GetScoreStanding() = %+v", standing)
+	}
+	if _, err := board.GetScoreStanding(ctx, expert, now, "b", 1); !errors.Is(err, ports.ErrPlayerNotFound) {
+		t.Errorf("GetScoreStanding() error = %v, want ErrPlayerNotFound", err)
+	}
+}
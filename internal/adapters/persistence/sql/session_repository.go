@@ -0,0 +1,191 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// SessionRepository is a database-backed QuizSessionRepository.
+type SessionRepository struct {
+	db *sql.DB
+}
+
+// NewSessionRepository creates a session repository on a migrated database.
+func NewSessionRepository(db *sql.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+// Save inserts or replaces a session and its answers.
+func (r *SessionRepository) Save(ctx context.Context, session *quiz.Session) error {
+	state, err := json.Marshal(session.Snapshot())
+	if err != nil {
+		return fmt.Errorf("encoding session: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // Error ignored: no-op after commit
+
+	if err := upsertSession(ctx, tx, session, string(state)); err != nil {
+		return err
+	}
+	if err := replaceAnswers(ctx, tx, session); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing session: %w", err)
+	}
+	return nil
+}
+
+// upsertSession writes the session row.
+func upsertSession(ctx context.Context, tx *sql.Tx, session *quiz.Session, state string) error {
+	var startedAt int64
+	if !session.StartedAt().IsZero() {
+		startedAt = session.StartedAt().UnixNano()
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO quiz_sessions (
+			id, user_id, status, difficulty, total_score, answered_count,
+			correct_count, max_streak, accuracy, started_at, state
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			status = excluded.status,
+			total_score = excluded.total_score,
+			answered_count = excluded.answered_count,
+			correct_count = excluded.correct_count,
+			max_streak = excluded.max_streak,
+			accuracy = excluded.accuracy,
+			started_at = excluded.started_at,
+			state = excluded.state`,
+		session.ID(), session.UserID(), string(session.Status()), string(session.Difficulty()),
+		session.TotalScore(), session.AnsweredCount(), session.CorrectCount(),
+		session.MaxStreak(), session.Accuracy(), startedAt, state,
+	)
+	if err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+	return nil
+}
+
+// replaceAnswers rewrites the per-answer rows of a session.
+func replaceAnswers(ctx context.Context, tx *sql.Tx, session *quiz.Session) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM quiz_answers WHERE session_id = ?`, session.ID()); err != nil {
+		return fmt.Errorf("clearing answers: %w", err)
+	}
+
+	for i, aq := range session.AnsweredQuestions() {
+		taxon := aq.Question.CorrectSpecies().IconicTaxon()
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO quiz_answers (session_id, position, iconic_taxon, is_correct) VALUES (?, ?, ?, ?)`,
+			session.ID(), i, taxon, aq.Answer.IsCorrect,
+		); err != nil {
+			return fmt.Errorf("saving answer %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// GetByID retrieves a session by ID.
+func (r *SessionRepository) GetByID(ctx context.Context, id string) (*quiz.Session, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT state FROM quiz_sessions WHERE id = ?`, id)
+	session, err := scanSession(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ports.ErrSessionNotFound
+	}
+	return session, err
+}
+
+// GetByUserID retrieves the most recent sessions of a user.
+func (r *SessionRepository) GetByUserID(ctx context.Context, userID string, limit int) ([]*quiz.Session, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT state FROM quiz_sessions
+		WHERE user_id = ?
+		ORDER BY started_at DESC, id ASC
+		LIMIT ?`, userID, queryLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("querying sessions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	sessions := make([]*quiz.Session, 0)
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// GetStats aggregates statistics over the completed sessions of a user.
+func (r *SessionRepository) GetStats(ctx context.Context, userID string) (*ports.UserQuizStats, error) {
+	stats := &ports.UserQuizStats{}
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(answered_count), 0),
+			COALESCE(SUM(correct_count), 0),
+			COALESCE(SUM(total_score), 0),
+			COALESCE(AVG(accuracy), 0),
+			COALESCE(MAX(max_streak), 0)
+		FROM quiz_sessions
+		WHERE user_id = ? AND status = ?`, userID, string(quiz.SessionCompleted),
+	).Scan(
+		&stats.TotalSessions, &stats.TotalQuestions, &stats.TotalCorrect,
+		&stats.TotalScore, &stats.AverageAccuracy, &stats.BestStreak,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("aggregating stats: %w", err)
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		SELECT a.iconic_taxon
+		FROM quiz_answers a
+		JOIN quiz_sessions s ON s.id = a.session_id
+		WHERE s.user_id = ? AND s.status = ? AND a.is_correct AND a.iconic_taxon <> ''
+		GROUP BY a.iconic_taxon
+		ORDER BY COUNT(*) DESC, a.iconic_taxon ASC
+		LIMIT 1`, userID, string(quiz.SessionCompleted),
+	).Scan(&stats.FavoriteTaxon)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("finding favorite taxon: %w", err)
+	}
+
+	return stats, nil
+}
+
+// scanSession decodes a session from a row holding its state column.
+func scanSession(row rowScanner) (*quiz.Session, error) {
+	var state string
+	if err := row.Scan(&state); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("reading session: %w", err)
+	}
+
+	var snap quiz.SessionSnapshot
+	if err := json.Unmarshal([]byte(state), &snap); err != nil {
+		return nil, fmt.Errorf("decoding session: %w", err)
+	}
+	return quiz.RestoreSession(snap)
+}
+
+// Ensure interface compliance
+var _ ports.QuizSessionRepository = (*SessionRepository)(nil)
@@ -0,0 +1,156 @@
+package sql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	sqlstore "github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/sql"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+func createTestQuestion(id string, correctID int, taxon string) *quiz.Question {
+	correct, _ := species.New(correctID, "Correct Species", "Correct", taxon)
+	correct.AddPhoto(species.Photo{ID: correctID, MediumURL: "https://example.com/m.jpg"})
+	wrong, _ := species.New(correctID+100, "Wrong Species", "Wrong", taxon)
+
+	choices := []quiz.Choice{
+		{Species: correct, IsCorrect: true},
+		{Species: wrong, IsCorrect: false},
+	}
+
+	q, _ := quiz.NewQuestion(id, quiz.ImageQuiz, quiz.Beginner, correct, choices, "https://example.com/img.jpg")
+	return q
+}
+
+func createTestSession(userID string, questions ...*quiz.Question) *quiz.Session {
+	session, _ := quiz.NewSessionBuilder().
+		WithUserID(userID).
+		WithQuestions(questions).
+		Build()
+	session.Start()
+	return session
+}
+
+func TestSessionRepository_SaveAndGetByID(t *testing.T) {
+	repo := sqlstore.NewSessionRepository(openTestDB(t))
+	ctx := context.Background()
+
+	session := createTestSession("user1", createTestQuestion("q1", 1, "Aves"), createTestQuestion("q2", 2, "Aves"))
+	if err := repo.Save(ctx, session); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// Saving again after progress updates the stored state
+	session.SubmitAnswer(1, time.Second)
+	if err := repo.Save(ctx, session); err != nil {
+		t.Fatalf("Save() update error = %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, session.ID())
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	gotJSON, _ := json.Marshal(got.Snapshot())
+	wantJSON, _ := json.Marshal(session.Snapshot())
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("GetByID() = %s, want %s", gotJSON, wantJSON)
+	}
+}
+
+func TestSessionRepository_GetByID_NotFound(t *testing.T) {
+	repo := sqlstore.NewSessionRepository(openTestDB(t))
+
+	_, err := repo.GetByID(context.Background(), "missing")
+	if !errors.Is(err, ports.ErrSessionNotFound) {
+		t.Errorf("GetByID() error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestSessionRepository_GetByUserID(t *testing.T) {
+	repo := sqlstore.NewSessionRepository(openTestDB(t))
+	ctx := context.Background()
+
+	var last *quiz.Session
+	for i := 0; i < 3; i++ {
+		last = createTestSession("user1", createTestQuestion("q", i+1, "Aves"))
+		repo.Save(ctx, last)
+		time.Sleep(time.Millisecond)
+	}
+	repo.Save(ctx, createTestSession("user2", createTestQuestion("q", 9, "Aves")))
+
+	sessions, err := repo.GetByUserID(ctx, "user1", 2)
+	if err != nil {
+		t.Fatalf("GetByUserID() error = %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("GetByUserID() len = %d, want 2", len(sessions))
+	}
+	if sessions[0].ID() != last.ID() {
+		t.Errorf("GetByUserID()[0] = %s, want most recent %s", sessions[0].ID(), last.ID())
+	}
+}
+
+func TestSessionRepository_GetStats(t *testing.T) {
+	repo := sqlstore.NewSessionRepository(openTestDB(t))
+	ctx := context.Background()
+
+	s1 := createTestSession("user1",
+		createTestQuestion("q1", 1, "Aves"),
+		createTestQuestion("q2", 2, "Aves"),
+		createTestQuestion("q3", 3, "Mammalia"),
+	)
+	s1.SubmitAnswer(1, time.Second)
+	s1.SubmitAnswer(2, time.Second)
+	s1.SubmitAnswer(999, time.Second)
+
+	s2 := createTestSession("user1", createTestQuestion("q4", 4, "Mammalia"))
+	s2.SubmitAnswer(4, time.Second)
+
+	// In-progress sessions and other users are not counted
+	s3 := createTestSession("user1", createTestQuestion("q5", 5, "Fungi"), createTestQuestion("q6", 6, "Fungi"))
+	s3.SubmitAnswer(5, time.Second)
+	s4 := createTestSession("user2", createTestQuestion("q7", 7, "Fungi"))
+	s4.SubmitAnswer(7, time.Second)
+
+	for _, s := range []*quiz.Session{s1, s2, s3, s4} {
+		if err := repo.Save(ctx, s); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	stats, err := repo.GetStats(ctx, "user1")
+	if err != nil {
+		t.Fatalf("GetStats() error = %v", err)
+	}
+
+	want := &ports.UserQuizStats{
+		TotalSessions:   2,
+		TotalQuestions:  4,
+		TotalCorrect:    3,
+		TotalScore:      s1.TotalScore() + s2.TotalScore(),
+		AverageAccuracy: (s1.Accuracy() + s2.Accuracy()) / 2,
+		BestStreak:      2,
+		FavoriteTaxon:   "Aves",
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("GetStats() = %+v, want %+v", stats, want)
+	}
+}
+
+func TestSessionRepository_GetStats_NoSessions(t *testing.T) {
+	repo := sqlstore.NewSessionRepository(openTestDB(t))
+
+	stats, err := repo.GetStats(context.Background(), "nobody")
+	if err != nil {
+		t.Fatalf("GetStats() error = %v", err)
+	}
+	if !reflect.DeepEqual(stats, &ports.UserQuizStats{}) {
+		t.Errorf("GetStats() = %+v, want empty stats", stats)
+	}
+}
@@ -0,0 +1,292 @@
+// Package resilience provides decorators that keep the application responsive
+// when an upstream species source is slow or failing.
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Default values for the circuit breaker.
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+	defaultHalfOpenProbes   = 1
+)
+
+// ErrCircuitOpen is returned while the circuit is open and no fallback is configured.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// State is the state of a circuit breaker.
+type State string
+
+// Circuit breaker states.
+const (
+	StateClosed   State = "closed"    // Calls go to the repository
+	StateOpen     State = "open"      // Calls fail fast or go to the fallback
+	StateHalfOpen State = "half_open" // A few probe calls test whether the repository recovered
+)
+
+// BreakerStatus describes a circuit breaker for monitoring.
+type BreakerStatus struct {
+	State               State      `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	Fallback            bool       `json:"fallback"`
+}
+
+// CircuitBreaker is a SpeciesRepository decorator that stops calling a failing
+// repository. It opens after consecutive failures, fails fast (or uses the
+// fallback) while open, then lets probe calls through to close again.
+type CircuitBreaker struct {
+	next     ports.SpeciesRepository
+	fallback ports.SpeciesRepository
+
+	failureThreshold int
+	openTimeout      time.Duration
+	halfOpenProbes   int
+	ignored          []error
+	now              func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probes   int // Probe calls in flight while half-open
+}
+
+// CircuitBreakerOption configures the circuit breaker.
+type CircuitBreakerOption func(*CircuitBreaker)
+
+// WithFailureThreshold sets how many consecutive failures open the circuit.
+func WithFailureThreshold(n int) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		if n > 0 {
+			b.failureThreshold = n
+		}
+	}
+}
+
+// WithOpenTimeout sets how long the circuit stays open before probing.
+func WithOpenTimeout(d time.Duration) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		if d > 0 {
+			b.openTimeout = d
+		}
+	}
+}
+
+// WithHalfOpenProbes sets how many probe calls may run at once while half-open.
+func WithHalfOpenProbes(n int) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		if n > 0 {
+			b.halfOpenProbes = n
+		}
+	}
+}
+
+// WithFallback sets the repository used while the circuit is open.
+func WithFallback(fallback ports.SpeciesRepository) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.fallback = fallback
+	}
+}
+
+// WithIgnoredErrors sets errors that show the repository is up, such as not
+// found answers or local quota refusals, and so never open the circuit.
+func WithIgnoredErrors(errs ...error) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.ignored = append(b.ignored, errs...)
+	}
+}
+
+// WithClock sets the time source, mainly for tests.
+func WithClock(now func() time.Time) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.now = now
+	}
+}
+
+// NewCircuitBreaker wraps a repository with a circuit breaker.
+func NewCircuitBreaker(next ports.SpeciesRepository, opts ...CircuitBreakerOption) *CircuitBreaker {
+	b := &CircuitBreaker{
+		next:             next,
+		failureThreshold: defaultFailureThreshold,
+		openTimeout:      defaultOpenTimeout,
+		halfOpenProbes:   defaultHalfOpenProbes,
+		now:              time.Now,
+		state:            StateClosed,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// GetByID retrieves a species by its ID.
+func (b *CircuitBreaker) GetByID(ctx context.Context, id int) (*species.Species, error) {
+	return call(ctx, b, func(r ports.SpeciesRepository) (*species.Species, error) {
+		return r.GetByID(ctx, id)
+	})
+}
+
+// GetRandom retrieves random species matching the filter.
+func (b *CircuitBreaker) GetRandom(ctx context.Context, filter ports.SpeciesFilter) ([]*species.Species, error) {
+	return call(ctx, b, func(r ports.SpeciesRepository) ([]*species.Species, error) {
+		return r.GetRandom(ctx, filter)
+	})
+}
+
+// GetSimilar retrieves species similar to the given one.
+func (b *CircuitBreaker) GetSimilar(ctx context.Context, speciesID int, limit int) ([]*species.Species, error) {
+	return call(ctx, b, func(r ports.SpeciesRepository) ([]*species.Species, error) {
+		return r.GetSimilar(ctx, speciesID, limit)
+	})
+}
+
+// Search searches for species by name.
+func (b *CircuitBreaker) Search(ctx context.Context, query string, limit int) ([]*species.Species, error) {
+	return call(ctx, b, func(r ports.SpeciesRepository) ([]*species.Species, error) {
+		return r.Search(ctx, query, limit)
+	})
+}
+
+// GetTaxa retrieves taxa by ID.
+func (b *CircuitBreaker) GetTaxa(ctx context.Context, ids []int) ([]species.Taxon, error) {
+	return call(ctx, b, func(r ports.SpeciesRepository) ([]species.Taxon, error) {
+		return r.GetTaxa(ctx, ids)
+	})
+}
+
+// SearchTaxa searches for taxa by name.
+func (b *CircuitBreaker) SearchTaxa(ctx context.Context, query string, limit int) ([]species.Taxon, error) {
+	return call(ctx, b, func(r ports.SpeciesRepository) ([]species.Taxon, error) {
+		return r.SearchTaxa(ctx, query, limit)
+	})
+}
+
+// GetLookalikes retrieves the species most often confused with the given one.
+func (b *CircuitBreaker) GetLookalikes(ctx context.Context, speciesID int, limit int) ([]*species.Species, error) {
+	return call(ctx, b, func(r ports.SpeciesRepository) ([]*species.Species, error) {
+		return r.GetLookalikes(ctx, speciesID, limit)
+	})
+}
+
+// State returns the current state of the circuit.
+func (b *CircuitBreaker) State() State {
+	return b.Status().State
+}
+
+// Status returns the circuit state for monitoring.
+func (b *CircuitBreaker) Status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.expireOpen()
+	status := BreakerStatus{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		Fallback:            b.fallback != nil,
+	}
+	if b.state != StateClosed {
+		openedAt := b.openedAt
+		status.OpenedAt = &openedAt
+	}
+	return status
+}
+
+// call runs fn on the repository when the circuit allows it, on the fallback
+// or not at all otherwise.
+func call[T any](ctx context.Context, b *CircuitBreaker, fn func(ports.SpeciesRepository) (T, error)) (T, error) {
+	probe, err := b.allow()
+	if err != nil {
+		if b.fallback != nil {
+			return fn(b.fallback)
+		}
+		var zero T
+		return zero, err
+	}
+
+	result, err := fn(b.next)
+	b.record(ctx, probe, err)
+	return result, err
+}
+
+// allow reports whether a call may go to the repository, and whether it is a probe.
+func (b *CircuitBreaker) allow() (probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.expireOpen()
+	switch b.state {
+	case StateClosed:
+		return false, nil
+	case StateHalfOpen:
+		if b.probes < b.halfOpenProbes {
+			b.probes++
+			return true, nil
+		}
+	case StateOpen:
+	}
+	return false, ErrCircuitOpen
+}
+
+// expireOpen moves an open circuit to half-open once the open timeout has elapsed.
+func (b *CircuitBreaker) expireOpen() {
+	if b.state == StateOpen && !b.now().Before(b.openedAt.Add(b.openTimeout)) {
+		b.state = StateHalfOpen
+		b.probes = 0
+	}
+}
+
+// record updates the circuit with the outcome of a call.
+func (b *CircuitBreaker) record(ctx context.Context, probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probes = max(b.probes-1, 0)
+	}
+
+	switch {
+	case ctx.Err() != nil && err != nil:
+		// The caller gave up: the call says nothing about the repository
+	case err == nil || b.isIgnored(err):
+		b.failures = 0
+		if b.state == StateHalfOpen && probe {
+			b.state = StateClosed
+		}
+	case b.state == StateHalfOpen && probe:
+		b.open()
+	case b.state == StateClosed:
+		b.failures++
+		if b.failures >= b.failureThreshold {
+			b.open()
+		}
+	}
+}
+
+// open opens the circuit.
+func (b *CircuitBreaker) open() {
+	b.state = StateOpen
+	b.openedAt = b.now()
+	b.probes = 0
+}
+
+// isIgnored reports whether err does not count as a failure.
+func (b *CircuitBreaker) isIgnored(err error) bool {
+	for _, target := range b.ignored {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ensure interface compliance
+var _ ports.SpeciesRepository = (*CircuitBreaker)(nil)
@@ -0,0 +1,288 @@
+package resilience_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/resilience"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+var (
+	errUpstream = errors.New("upstream down")
+	errNotFound = errors.New("not found")
+)
+
+// stubRepository answers every call with err, or a single species when err is nil.
+type stubRepository struct {
+	mu    sync.Mutex
+	err   error
+	calls int
+}
+
+func (s *stubRepository) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func (s *stubRepository) callCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func (s *stubRepository) answer() ([]*species.Species, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	sp, _ := species.New(1, "Vulpes vulpes", "Renard roux", "Mammalia")
+	return []*species.Species{sp}, nil
+}
+
+func (s *stubRepository) GetByID(_ context.Context, _ int) (*species.Species, error) {
+	list, err := s.answer()
+	if err != nil {
+		return nil, err
+	}
+	return list[0], nil
+}
+
+func (s *stubRepository) GetRandom(_ context.Context, _ ports.SpeciesFilter) ([]*species.Species, error) {
+	return s.answer()
+}
+
+func (s *stubRepository) GetSimilar(_ context.Context, _ int, _ int) ([]*species.Species, error) {
+	return s.answer()
+}
+
+func (s *stubRepository) Search(_ context.Context, _ string, _ int) ([]*species.Species, error) {
+	return s.answer()
+}
+
+func (s *stubRepository) GetTaxa(_ context.Context, ids []int) ([]species.Taxon, error) {
+	if _, err := s.answer(); err != nil {
+		return nil, err
+	}
+	return []species.Taxon{{ID: ids[0], Name: "Vulpes", Rank: species.RankGenus}}, nil
+}
+
+func (s *stubRepository) SearchTaxa(_ context.Context, query string, _ int) ([]species.Taxon, error) {
+	if _, err := s.answer(); err != nil {
+		return nil, err
+	}
+	return []species.Taxon{{ID: 42, Name: query, Rank: species.RankGenus}}, nil
+}
+
+func (s *stubRepository) GetLookalikes(_ context.Context, _ int, _ int) ([]*species.Species, error) {
+	return s.answer()
+}
+
+// fakeClock is a manually advanced time source.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newBreaker creates a breaker opening after 3 failures for one minute.
+func newBreaker(
+	next ports.SpeciesRepository, opts ...resilience.CircuitBreakerOption,
+) (*resilience.CircuitBreaker, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	opts = append([]resilience.CircuitBreakerOption{
+		resilience.WithFailureThreshold(3),
+		resilience.WithOpenTimeout(time.Minute),
+		resilience.WithClock(clock.Now),
+	}, opts...)
+	return resilience.NewCircuitBreaker(next, opts...), clock
+}
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	repo := &stubRepository{err: errUpstream}
+	breaker, _ := newBreaker(repo)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := breaker.GetByID(ctx, 1); !errors.Is(err, errUpstream) {
+			t.Fatalf("call %d error = %v, want upstream error", i, err)
+		}
+	}
+	if breaker.State() != resilience.StateOpen {
+		t.Fatalf("State() = %s, want open", breaker.State())
+	}
+
+	if _, err := breaker.GetRandom(ctx, ports.SpeciesFilter{}); !errors.Is(err, resilience.ErrCircuitOpen) {
+		t.Errorf("GetRandom() error = %v, want ErrCircuitOpen", err)
+	}
+	if repo.callCount() != 3 {
+		t.Errorf("repository calls = %d, want 3 (fail fast while open)", repo.callCount())
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	repo := &stubRepository{err: errUpstream}
+	breaker, _ := newBreaker(repo)
+	ctx := context.Background()
+
+	breaker.Search(ctx, "renard", 5)
+	breaker.Search(ctx, "renard", 5)
+	repo.setErr(nil)
+	breaker.Search(ctx, "renard", 5)
+	repo.setErr(errUpstream)
+	breaker.Search(ctx, "renard", 5)
+	breaker.Search(ctx, "renard", 5)
+
+	if status := breaker.Status(); status.State != resilience.StateClosed || status.ConsecutiveFailures != 2 {
+		t.Errorf("Status() = %+v, want closed with 2 failures", status)
+	}
+}
+
+func TestCircuitBreaker_IgnoredErrors(t *testing.T) {
+	repo := &stubRepository{err: errNotFound}
+	breaker, _ := newBreaker(repo, resilience.WithIgnoredErrors(errNotFound))
+
+	for i := 0; i < 5; i++ {
+		breaker.GetByID(context.Background(), 1)
+	}
+	if breaker.State() != resilience.StateClosed {
+		t.Errorf("State() = %s, want closed on ignored errors", breaker.State())
+	}
+}
+
+func TestCircuitBreaker_CallerCancellationIsNotAFailure(t *testing.T) {
+	repo := &stubRepository{err: context.Canceled}
+	breaker, _ := newBreaker(repo)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for i := 0; i < 5; i++ {
+		breaker.GetByID(ctx, 1)
+	}
+	if breaker.State() != resilience.StateClosed {
+		t.Errorf("State() = %s, want closed when callers cancel", breaker.State())
+	}
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	tests := []struct {
+		name      string
+		probeErr  error
+		wantState resilience.State
+	}{
+		{"successful probe closes", nil, resilience.StateClosed},
+		{"failed probe reopens", errUpstream, resilience.StateOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubRepository{err: errUpstream}
+			breaker, clock := newBreaker(repo)
+			ctx := context.Background()
+			for i := 0; i < 3; i++ {
+				breaker.GetByID(ctx, 1)
+			}
+
+			clock.Advance(time.Minute)
+			if breaker.State() != resilience.StateHalfOpen {
+				t.Fatalf("State() = %s after the open timeout, want half_open", breaker.State())
+			}
+
+			repo.setErr(tt.probeErr)
+			breaker.GetByID(ctx, 1)
+			if breaker.State() != tt.wantState {
+				t.Errorf("State() = %s after probe, want %s", breaker.State(), tt.wantState)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker_HalfOpenLimitsProbes(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	repo := &blockingRepository{stubRepository: stubRepository{err: errUpstream}, release: release, started: started}
+	breaker, clock := newBreaker(repo)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		breaker.GetByID(ctx, 1)
+	}
+	clock.Advance(time.Minute)
+	repo.setErr(nil)
+	repo.block = true
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		breaker.GetByID(ctx, 1)
+	}()
+	<-started
+
+	if _, err := breaker.GetByID(ctx, 1); !errors.Is(err, resilience.ErrCircuitOpen) {
+		t.Errorf("second probe error = %v, want ErrCircuitOpen", err)
+	}
+	close(release)
+	<-done
+
+	if breaker.State() != resilience.StateClosed {
+		t.Errorf("State() = %s, want closed after the probe succeeded", breaker.State())
+	}
+}
+
+func TestCircuitBreaker_Fallback(t *testing.T) {
+	repo := &stubRepository{err: errUpstream}
+	fallback := &stubRepository{}
+	breaker, _ := newBreaker(repo, resilience.WithFallback(fallback))
+	ctx := context.Background()
+
+	// Failures before the circuit opens are returned as is
+	for i := 0; i < 3; i++ {
+		if _, err := breaker.GetRandom(ctx, ports.SpeciesFilter{}); !errors.Is(err, errUpstream) {
+			t.Fatalf("GetRandom() error = %v, want upstream error", err)
+		}
+	}
+
+	got, err := breaker.GetRandom(ctx, ports.SpeciesFilter{})
+	if err != nil || len(got) != 1 {
+		t.Fatalf("GetRandom() = %v, %v, want the fallback result", got, err)
+	}
+	if fallback.callCount() != 1 || repo.callCount() != 3 {
+		t.Errorf("calls repo/fallback = %d/%d, want 3/1", repo.callCount(), fallback.callCount())
+	}
+	if !breaker.Status().Fallback {
+		t.Error("Status() Fallback = false, want true")
+	}
+}
+
+// blockingRepository holds calls until released once block is set.
+type blockingRepository struct {
+	stubRepository
+	block   bool
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingRepository) GetByID(ctx context.Context, id int) (*species.Species, error) {
+	if b.block {
+		close(b.started)
+		<-b.release
+	}
+	return b.stubRepository.GetByID(ctx, id)
+}
@@ -0,0 +1,193 @@
+// Package leaderboard contains application services for player rankings.
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Pagination bounds for leaderboard queries.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+	DefaultRadius   = 2
+	MaxRadius       = 10
+)
+
+// Errors returned by the leaderboard service.
+var (
+	ErrInvalidPage  = errors.New("invalid page")
+	ErrInvalidScope = errors.New("invalid leaderboard scope")
+)
+
+// Service serves ranked leaderboards and feeds the scoped ones from
+// completed quiz sessions.
+type Service struct {
+	xp     ports.XPLeaderboard
+	scores ports.ScoreLeaderboard
+}
+
+// NewService creates a new leaderboard service.
+func NewService(xp ports.XPLeaderboard, scores ports.ScoreLeaderboard) *Service {
+	return &Service{xp: xp, scores: scores}
+}
+
+// GetXPLeaderboard retrieves a page of the all-time XP leaderboard.
+// A zero limit selects DefaultPageSize; larger limits are capped at MaxPageSize.
+func (s *Service) GetXPLeaderboard(ctx context.Context, page ports.PageRequest) (*ports.LeaderboardPage, error) {
+	page, err := normalizePage(page)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.xp.GetXPLeaderboard(ctx, page)
+	if err != nil {
+		return nil, fmt.Errorf("loading XP leaderboard: %w", err)
+	}
+	return result, nil
+}
+
+// GetXPStanding retrieves a player's XP rank and the players around them.
+// A zero radius selects DefaultRadius; larger radii are capped at MaxRadius.
+func (s *Service) GetXPStanding(ctx context.Context, playerID string, radius int) (*ports.LeaderboardStanding, error) {
+	radius, err := normalizeRadius(radius)
+	if err != nil {
+		return nil, err
+	}
+
+	standing, err := s.xp.GetXPStanding(ctx, playerID, radius)
+	if err != nil {
+		return nil, fmt.Errorf("loading XP standing: %w", err)
+	}
+	return standing, nil
+}
+
+// RecordSession adds a completed session's points to every leaderboard it
+// counts towards: each time window, overall and per difficulty, for all taxa
+// and for each iconic taxon the session's questions covered.
+func (s *Service) RecordSession(ctx context.Context, session *quiz.Session) error {
+	if session.Status() != quiz.SessionCompleted {
+		return fmt.Errorf("session %s is not completed", session.ID())
+	}
+
+	if err := s.scores.AddScores(ctx, session.UserID(), session.CompletedAt(), sessionScores(session)); err != nil {
+		return fmt.Errorf("recording session scores: %w", err)
+	}
+	return nil
+}
+
+// GetScoreLeaderboard retrieves a page of a scoped leaderboard for the current period.
+func (s *Service) GetScoreLeaderboard(
+	ctx context.Context,
+	scope ports.LeaderboardScope,
+	page ports.PageRequest,
+) (*ports.LeaderboardPage, error) {
+	scope, err := normalizeScope(scope)
+	if err != nil {
+		return nil, err
+	}
+	page, err = normalizePage(page)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.scores.GetScoreLeaderboard(ctx, scope, time.Now(), page)
+	if err != nil {
+		return nil, fmt.Errorf("loading scoped leaderboard: %w", err)
+	}
+	return result, nil
+}
+
+// GetScoreStanding retrieves a player's rank on a scoped leaderboard for the
+// current period and the players around them.
+func (s *Service) GetScoreStanding(
+	ctx context.Context,
+	scope ports.LeaderboardScope,
+	playerID string,
+	radius int,
+) (*ports.LeaderboardStanding, error) {
+	scope, err := normalizeScope(scope)
+	if err != nil {
+		return nil, err
+	}
+	radius, err = normalizeRadius(radius)
+	if err != nil {
+		return nil, err
+	}
+
+	standing, err := s.scores.GetScoreStanding(ctx, scope, time.Now(), playerID, radius)
+	if err != nil {
+		return nil, fmt.Errorf("loading scoped standing: %w", err)
+	}
+	return standing, nil
+}
+
+// sessionScores computes the points a session adds to each scope.
+func sessionScores(session *quiz.Session) map[ports.LeaderboardScope]int {
+	byTaxon := map[string]int{"": session.TotalScore()}
+	for _, aq := range session.AnsweredQuestions() {
+		if taxon := aq.Question.CorrectSpecies().IconicTaxon(); taxon != "" {
+			byTaxon[taxon] += aq.Answer.Score
+		}
+	}
+
+	scores := make(map[ports.LeaderboardScope]int)
+	for _, window := range ports.TimeWindows() {
+		for taxon, score := range byTaxon {
+			for _, difficulty := range []quiz.Difficulty{"", session.Difficulty()} {
+				scope := ports.LeaderboardScope{Window: window, IconicTaxon: taxon, Difficulty: difficulty}
+				scores[scope] = score
+			}
+		}
+	}
+	return scores
+}
+
+// normalizeScope validates a scope, defaulting to the all-time window.
+func normalizeScope(scope ports.LeaderboardScope) (ports.LeaderboardScope, error) {
+	if scope.Window == "" {
+		scope.Window = ports.AllTime
+	}
+	if !ports.IsValidTimeWindow(scope.Window) {
+		return scope, fmt.Errorf("%w: unknown window %q", ErrInvalidScope, scope.Window)
+	}
+	if scope.IconicTaxon != "" && !species.IsValidIconicTaxon(scope.IconicTaxon) {
+		return scope, fmt.Errorf("%w: unknown iconic taxon %q", ErrInvalidScope, scope.IconicTaxon)
+	}
+	if scope.Difficulty != "" && !quiz.IsValidDifficulty(scope.Difficulty) {
+		return scope, fmt.Errorf("%w: unknown difficulty %q", ErrInvalidScope, scope.Difficulty)
+	}
+	return scope, nil
+}
+
+// normalizePage validates a page request and applies the size bounds.
+func normalizePage(page ports.PageRequest) (ports.PageRequest, error) {
+	if page.Offset < 0 {
+		return page, fmt.Errorf("%w: offset must not be negative", ErrInvalidPage)
+	}
+	if page.Limit < 0 {
+		return page, fmt.Errorf("%w: limit must not be negative", ErrInvalidPage)
+	}
+	if page.Limit == 0 {
+		page.Limit = DefaultPageSize
+	}
+	page.Limit = min(page.Limit, MaxPageSize)
+	return page, nil
+}
+
+// normalizeRadius validates a standing radius and applies its bounds.
+func normalizeRadius(radius int) (int, error) {
+	if radius < 0 {
+		return 0, fmt.Errorf("%w: radius must not be negative", ErrInvalidPage)
+	}
+	if radius == 0 {
+		return DefaultRadius, nil
+	}
+	return min(radius, MaxRadius), nil
+}
@@ -0,0 +1,296 @@
+package leaderboard_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/memory"
+	appleaderboard "github.com/Naturieux-fr/Naturieux.fr/internal/application/leaderboard"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// newRankedRepo creates a repository with n players of strictly decreasing XP.
+func newRankedRepo(t *testing.T, n int) *memory.PlayerRepository {
+	t.Helper()
+	repo := memory.NewPlayerRepository()
+	for i := 0; i < n; i++ {
+		player, _ := gamification.NewPlayer(fmt.Sprintf("p%03d", i), fmt.Sprintf("user%03d", i))
+		player.AddXP((n - i) * 10)
+		if err := repo.Create(context.Background(), player); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	return repo
+}
+
+// newService creates a service ranking the repository's players, with in-memory scoped boards.
+func newService(repo *memory.PlayerRepository) *appleaderboard.Service {
+	return appleaderboard.NewService(repo, memory.NewScoreLeaderboard(repo))
+}
+
+// answeredQuestion describes a question of a test session and whether it is answered correctly.
+type answeredQuestion struct {
+	taxon   string
+	correct bool
+}
+
+// playSession builds a session for the user and answers every question.
+func playSession(t *testing.T, userID string, difficulty quiz.Difficulty, plan []answeredQuestion) *quiz.Session {
+	t.Helper()
+
+	questions := make([]*quiz.Question, len(plan))
+	for i, p := range plan {
+		right, _ := species.New(i+1, "Species right", "", p.taxon)
+		wrong, _ := species.New(i+100, "Species wrong", "", p.taxon)
+		choices := []quiz.Choice{{Species: right, IsCorrect: true}, {Species: wrong}}
+		id := fmt.Sprintf("q%d", i)
+		questions[i], _ = quiz.NewQuestion(id, quiz.ImageQuiz, difficulty, right, choices, "https://example.com/a.jpg")
+	}
+
+	session, err := quiz.NewSessionBuilder().
+		WithUserID(userID).
+		WithDifficulty(difficulty).
+		WithQuestions(questions).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	session.Start()
+
+	for i, p := range plan {
+		answer := i + 100
+		if p.correct {
+			answer = i + 1
+		}
+		if _, err := session.SubmitAnswer(answer, time.Second); err != nil {
+			t.Fatalf("SubmitAnswer() error = %v", err)
+		}
+	}
+	return session
+}
+
+// scoreOf returns a player's score on a scoped leaderboard, or -1 if unranked.
+func scoreOf(t *testing.T, service *appleaderboard.Service, scope ports.LeaderboardScope, playerID string) int {
+	t.Helper()
+	standing, err := service.GetScoreStanding(context.Background(), scope, playerID, 0)
+	if errors.Is(err, ports.ErrPlayerNotFound) {
+		return -1
+	}
+	if err != nil {
+		t.Fatalf("GetScoreStanding() error = %v", err)
+	}
+	return standing.Player.Score
+}
+
+func TestService_RecordSession(t *testing.T) {
+	repo := newRankedRepo(t, 2)
+	service := newService(repo)
+	ctx := context.Background()
+
+	session := playSession(t, "p000", quiz.Expert, []answeredQuestion{
+		{"Aves", true}, {"Aves", true}, {"Mammalia", true}, {"Mammalia", false},
+	})
+	if err := service.RecordSession(ctx, session); err != nil {
+		t.Fatalf("RecordSession() error = %v", err)
+	}
+
+	answers := session.Answers()
+	aves := answers[0].Score + answers[1].Score
+	mammals := answers[2].Score
+
+	tests := []struct {
+		name  string
+		scope ports.LeaderboardScope
+		want  int
+	}{
+		{"all time", ports.LeaderboardScope{}, session.TotalScore()},
+		{"weekly birds", ports.LeaderboardScope{Window: ports.Weekly, IconicTaxon: "Aves"}, aves},
+		{"daily mammals", ports.LeaderboardScope{Window: ports.Daily, IconicTaxon: "Mammalia"}, mammals},
+		{"monthly expert", ports.LeaderboardScope{Window: ports.Monthly, Difficulty: quiz.Expert}, session.TotalScore()},
+		{"expert birds", ports.LeaderboardScope{IconicTaxon: "Aves", Difficulty: quiz.Expert}, aves},
+		{"other difficulty", ports.LeaderboardScope{Difficulty: quiz.Beginner}, -1},
+		{"other taxon", ports.LeaderboardScope{IconicTaxon: "Fungi"}, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scoreOf(t, service, tt.scope, "p000"); got != tt.want {
+				t.Errorf("score = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	// A second session accumulates
+	second := playSession(t, "p000", quiz.Beginner, []answeredQuestion{{"Aves", true}})
+	service.RecordSession(ctx, second)
+	want := aves + second.TotalScore()
+	if got := scoreOf(t, service, ports.LeaderboardScope{IconicTaxon: "Aves"}, "p000"); got != want {
+		t.Errorf("accumulated birds score = %d, want %d", got, want)
+	}
+}
+
+func TestService_RecordSession_RequiresCompletion(t *testing.T) {
+	service := newService(newRankedRepo(t, 1))
+
+	session, _ := quiz.NewSessionBuilder().
+		WithUserID("p000").
+		WithQuestions(playSession(t, "p000", quiz.Beginner, []answeredQuestion{{"Aves", true}}).Questions()).
+		Build()
+
+	if err := service.RecordSession(context.Background(), session); err == nil {
+		t.Error("RecordSession() should reject a session that is not completed")
+	}
+}
+
+func TestService_GetScoreLeaderboard(t *testing.T) {
+	service := newService(newRankedRepo(t, 3))
+	ctx := context.Background()
+
+	service.RecordSession(ctx, playSession(t, "p002", quiz.Beginner, []answeredQuestion{{"Aves", true}, {"Aves", true}}))
+	service.RecordSession(ctx, playSession(t, "p000", quiz.Beginner, []answeredQuestion{{"Aves", true}}))
+	service.RecordSession(ctx, playSession(t, "p001", quiz.Beginner, []answeredQuestion{{"Insecta", true}}))
+
+	weeklyBirds := ports.LeaderboardScope{Window: ports.Weekly, IconicTaxon: "Aves"}
+	page, err := service.GetScoreLeaderboard(ctx, weeklyBirds, ports.PageRequest{})
+	if err != nil {
+		t.Fatalf("GetScoreLeaderboard() error = %v", err)
+	}
+	if page.Total != 2 || page.Entries[0].PlayerID != "p002" || page.Entries[1].PlayerID != "p000" {
+		t.Errorf("weekly birds = %+v", page)
+	}
+	if page.Entries[0].Username != "user002" {
+		t.Errorf("entry username = %s, want user002", page.Entries[0].Username)
+	}
+}
+
+func TestService_InvalidScope(t *testing.T) {
+	service := newService(newRankedRepo(t, 1))
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		scope ports.LeaderboardScope
+	}{
+		{"window", ports.LeaderboardScope{Window: "yearly"}},
+		{"taxon", ports.LeaderboardScope{IconicTaxon: "Dinosauria"}},
+		{"difficulty", ports.LeaderboardScope{Difficulty: "impossible"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetScoreLeaderboard(ctx, tt.scope, ports.PageRequest{})
+			if !errors.Is(err, appleaderboard.ErrInvalidScope) {
+				t.Errorf("GetScoreLeaderboard() error = %v, want ErrInvalidScope", err)
+			}
+			_, err = service.GetScoreStanding(ctx, tt.scope, "p000", 0)
+			if !errors.Is(err, appleaderboard.ErrInvalidScope) {
+				t.Errorf("GetScoreStanding() error = %v, want ErrInvalidScope", err)
+			}
+		})
+	}
+}
+
+func TestService_GetXPLeaderboard_Limits(t *testing.T) {
+	service := newService(newRankedRepo(t, 150))
+
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{"default", 0, appleaderboard.DefaultPageSize},
+		{"explicit", 5, 5},
+		{"capped", 1000, appleaderboard.MaxPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := service.GetXPLeaderboard(context.Background(), ports.PageRequest{Limit: tt.limit})
+			if err != nil {
+				t.Fatalf("GetXPLeaderboard() error = %v", err)
+			}
+			if len(page.Entries) != tt.want {
+				t.Errorf("GetXPLeaderboard() len = %d, want %d", len(page.Entries), tt.want)
+			}
+			if page.Total != 150 {
+				t.Errorf("GetXPLeaderboard() Total = %d, want 150", page.Total)
+			}
+		})
+	}
+}
+
+func TestService_InvalidParameters(t *testing.T) {
+	service := newService(newRankedRepo(t, 3))
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{"negative offset", func() error {
+			_, err := service.GetXPLeaderboard(ctx, ports.PageRequest{Offset: -1})
+			return err
+		}, appleaderboard.ErrInvalidPage},
+		{"negative limit", func() error {
+			_, err := service.GetXPLeaderboard(ctx, ports.PageRequest{Limit: -1})
+			return err
+		}, appleaderboard.ErrInvalidPage},
+		{"negative radius", func() error {
+			_, err := service.GetXPStanding(ctx, "p000", -1)
+			return err
+		}, appleaderboard.ErrInvalidPage},
+		{"bad cursor", func() error {
+			_, err := service.GetXPLeaderboard(ctx, ports.PageRequest{Cursor: "%"})
+			return err
+		}, ports.ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_GetXPStanding_Radius(t *testing.T) {
+	service := newService(newRankedRepo(t, 50))
+
+	tests := []struct {
+		name   string
+		radius int
+		want   int
+	}{
+		{"default", 0, 2*appleaderboard.DefaultRadius + 1},
+		{"explicit", 1, 3},
+		{"capped", 100, 2*appleaderboard.MaxRadius + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standing, err := service.GetXPStanding(context.Background(), "p025", tt.radius)
+			if err != nil {
+				t.Fatalf("GetXPStanding() error = %v", err)
+			}
+			if standing.Player.Rank != 26 {
+				t.Errorf("GetXPStanding() Rank = %d, want 26", standing.Player.Rank)
+			}
+			if len(standing.Neighbors) != tt.want {
+				t.Errorf("GetXPStanding() neighbors = %d, want %d", len(standing.Neighbors), tt.want)
+			}
+		})
+	}
+
+	_, err := service.GetXPStanding(context.Background(), "missing", 0)
+	if !errors.Is(err, ports.ErrPlayerNotFound) {
+		t.Errorf("GetXPStanding() error = %v, want ErrPlayerNotFound", err)
+	}
+}
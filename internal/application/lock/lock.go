@@ -0,0 +1,44 @@
+// Package lock serializes the work of application services per key.
+package lock
+
+import "sync"
+
+// KeyedMutex serializes work per key, such as the read-modify-write of a
+// player. Services updating the same records share one. Unused keys are
+// released. The zero value is ready to use.
+type KeyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+// keyedLock is the lock of one key and the number of its holders and waiters.
+type keyedLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Lock locks key and returns the function unlocking it.
+func (m *KeyedMutex) Lock(key string) func() {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = make(map[string]*keyedLock)
+	}
+	l, ok := m.locks[key]
+	if !ok {
+		l = &keyedLock{}
+		m.locks[key] = l
+	}
+	l.refs++
+	m.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if l.refs--; l.refs == 0 {
+			delete(m.locks, key)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Package player contains application services for player management.
+package player
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/google/uuid"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/application/lock"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Errors returned by the player service.
+var (
+	ErrInvalidUsername = errors.New("username must be 3-30 letters, digits, '_', '-' or '.'")
+	ErrUsernameTaken   = ports.ErrUsernameTaken
+	ErrInvalidPlace    = errors.New("invalid place")
+)
+
+// usernamePattern restricts usernames to a URL and display friendly set.
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{3,30}$`)
+
+// Service handles player registration and profiles.
+type Service struct {
+	playerRepo ports.PlayerRepository
+	places     ports.PlaceRepository // Nil when places cannot be checked
+	locks      *lock.KeyedMutex      // Serializes updates per player, shared with other services
+}
+
+// ServiceOption configures the player service.
+type ServiceOption func(*Service)
+
+// WithPlaces checks home places against a place repository.
+func WithPlaces(places ports.PlaceRepository) ServiceOption {
+	return func(s *Service) {
+		s.places = places
+	}
+}
+
+// WithPlayerLocks serializes profile updates with the other player updates
+// holding locks, such as the progression of completed games.
+func WithPlayerLocks(locks *lock.KeyedMutex) ServiceOption {
+	return func(s *Service) {
+		s.locks = locks
+	}
+}
+
+// NewService creates a new player service.
+func NewService(playerRepo ports.PlayerRepository, opts ...ServiceOption) *Service {
+	s := &Service{playerRepo: playerRepo, locks: &lock.KeyedMutex{}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// AchievementStatus describes an achievement and the player's advance towards it.
+type AchievementStatus struct {
+	Info     gamification.AchievementInfo
+	Unlocked bool
+	Tier     gamification.Tier      // Highest tier reached, empty for single-level achievements
+	Progress *gamification.Progress // Nil for achievements without a count
+}
+
+// Register creates a new player with a unique username.
+func (s *Service) Register(ctx context.Context, username string) (*gamification.Player, error) {
+	if !usernamePattern.MatchString(username) {
+		return nil, ErrInvalidUsername
+	}
+
+	_, err := s.playerRepo.GetByUsername(ctx, username)
+	if err == nil {
+		return nil, ErrUsernameTaken
+	}
+	if !errors.Is(err, ports.ErrPlayerNotFound) {
+		return nil, fmt.Errorf("checking username: %w", err)
+	}
+
+	player, err := gamification.NewPlayer(uuid.New().String(), username)
+	if err != nil {
+		return nil, fmt.Errorf("creating player: %w", err)
+	}
+	// A concurrent registration may take the username since the check
+	if err := s.playerRepo.Create(ctx, player); err != nil {
+		return nil, fmt.Errorf("storing player: %w", err)
+	}
+	return player, nil
+}
+
+// GetProfile retrieves a player by ID.
+func (s *Service) GetProfile(ctx context.Context, playerID string) (*gamification.Player, error) {
+	return s.playerRepo.GetByID(ctx, playerID)
+}
+
+// SetHomePlace sets the place the sessions of a player default to. 0 unsets it.
+func (s *Service) SetHomePlace(ctx context.Context, playerID string, placeID int) (*gamification.Player, error) {
+	if placeID < 0 {
+		return nil, fmt.Errorf("%w: negative place ID %d", ErrInvalidPlace, placeID)
+	}
+	if placeID > 0 && s.places != nil {
+		_, err := s.places.GetPlace(ctx, placeID)
+		if errors.Is(err, ports.ErrPlaceNotFound) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPlace, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("checking place: %w", err)
+		}
+	}
+
+	// Players are read and written as copies: a concurrent game must not be overwritten
+	unlock := s.locks.Lock(playerID)
+	defer unlock()
+
+	player, err := s.playerRepo.GetByID(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := player.SetHomePlace(placeID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPlace, err)
+	}
+	if err := s.playerRepo.Update(ctx, player); err != nil {
+		return nil, fmt.Errorf("storing player: %w", err)
+	}
+	return player, nil
+}
+
+// GetAchievements lists every achievement with the player's tier and progress.
+func (s *Service) GetAchievements(ctx context.Context, playerID string) ([]AchievementStatus, error) {
+	player, err := s.playerRepo.GetByID(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	all := gamification.AllAchievements()
+	statuses := make([]AchievementStatus, 0, len(all))
+	for _, a := range all {
+		status := AchievementStatus{
+			Info:     gamification.GetAchievementInfo(a),
+			Unlocked: player.HasAchievement(a),
+			Tier:     player.AchievementTier(a),
+		}
+		if progress, ok := player.AchievementProgress(a); ok {
+			status.Progress = &progress
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
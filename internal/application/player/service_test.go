@@ -0,0 +1,251 @@
+package player_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/memory"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/application/lock"
+	appplayer "github.com/Naturieux-fr/Naturieux.fr/internal/application/player"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/place"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+func TestService_Register(t *testing.T) {
+	service := appplayer.NewService(memory.NewPlayerRepository())
+
+	player, err := service.Register(context.Background(), "naturelover")
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if player.ID() == "" {
+		t.Error("Register() should assign an ID")
+	}
+	if player.Username() != "naturelover" {
+		t.Errorf("Username = %s, want naturelover", player.Username())
+	}
+
+	got, err := service.GetProfile(context.Background(), player.ID())
+	if err != nil {
+		t.Fatalf("GetProfile() error = %v", err)
+	}
+	if got.ID() != player.ID() {
+		t.Errorf("GetProfile() ID = %s, want %s", got.ID(), player.ID())
+	}
+}
+
+func TestService_Register_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		wantErr  error
+	}{
+		{"too short", "ab", appplayer.ErrInvalidUsername},
+		{"too long", "abcdefghijklmnopqrstuvwxyz012345", appplayer.ErrInvalidUsername},
+		{"invalid characters", "nature lover!", appplayer.ErrInvalidUsername},
+		{"empty", "", appplayer.ErrInvalidUsername},
+		{"valid", "nature.lover-42", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := appplayer.NewService(memory.NewPlayerRepository())
+			_, err := service.Register(context.Background(), tt.username)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Register(%q) error = %v, want %v", tt.username, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_Register_UsernameTaken(t *testing.T) {
+	service := appplayer.NewService(memory.NewPlayerRepository())
+
+	service.Register(context.Background(), "naturelover")
+	_, err := service.Register(context.Background(), "naturelover")
+	if !errors.Is(err, appplayer.ErrUsernameTaken) {
+		t.Errorf("Register() error = %v, want ErrUsernameTaken", err)
+	}
+}
+
+// racingRepository misses a username at the check then finds it taken at Create,
+// as when a concurrent registration wins.
+type racingRepository struct {
+	*memory.PlayerRepository
+}
+
+func (r racingRepository) GetByUsername(context.Context, string) (*gamification.Player, error) {
+	return nil, ports.ErrPlayerNotFound
+}
+
+func TestService_Register_ConcurrentUsername(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	service := appplayer.NewService(racingRepository{repo})
+	service.Register(context.Background(), "naturelover")
+
+	_, err := service.Register(context.Background(), "naturelover")
+	if !errors.Is(err, appplayer.ErrUsernameTaken) {
+		t.Errorf("Register() error = %v, want ErrUsernameTaken", err)
+	}
+}
+
+func TestService_GetAchievements(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	service := appplayer.NewService(repo)
+
+	player, _ := gamification.NewPlayer("p1", "naturelover")
+	player.RecordGame(gamification.GameSummary{Correct: 5, Total: 10, MaxStreak: 2})
+	repo.Create(context.Background(), player)
+
+	statuses, err := service.GetAchievements(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("GetAchievements() error = %v", err)
+	}
+
+	if len(statuses) != len(gamification.AllAchievements()) {
+		t.Fatalf("GetAchievements() len = %d, want all %d achievements",
+			len(statuses), len(gamification.AllAchievements()))
+	}
+
+	unlocked := 0
+	for _, s := range statuses {
+		if s.Info.Name == "" || s.Info.Icon == "" {
+			t.Errorf("achievement %s is missing display info", s.Info.ID)
+		}
+		if s.Unlocked {
+			unlocked++
+			if s.Info.ID != gamification.FirstGame {
+				t.Errorf("unexpected unlocked achievement %s", s.Info.ID)
+			}
+		}
+	}
+	if unlocked != 1 {
+		t.Errorf("unlocked = %d, want 1", unlocked)
+	}
+}
+
+func TestService_GetAchievements_Progress(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	service := appplayer.NewService(repo)
+
+	player, _ := gamification.NewPlayer("p1", "naturelover")
+	answers := make([]gamification.AnswerSummary, 63)
+	for i := range answers {
+		answers[i] = gamification.AnswerSummary{IconicTaxon: "Aves", Correct: true}
+	}
+	player.RecordGame(gamification.GameSummary{Correct: 63, Total: 70, MaxStreak: 4, Answers: answers})
+	repo.Create(context.Background(), player)
+
+	statuses, _ := service.GetAchievements(context.Background(), "p1")
+	for _, s := range statuses {
+		switch s.Info.ID {
+		case gamification.BirdWatcher:
+			if s.Tier != gamification.TierBronze || s.Progress == nil || s.Progress.Current != 63 || s.Progress.Target != 100 {
+				t.Errorf("bird_watcher tier/progress = %q/%+v, want bronze with 63/100", s.Tier, s.Progress)
+			}
+		case gamification.Veteran:
+			if s.Progress == nil || s.Progress.Current != 1 || s.Progress.Target != 100 {
+				t.Errorf("veteran progress = %+v, want 1/100", s.Progress)
+			}
+		case gamification.MasterNatural:
+			if s.Progress != nil {
+				t.Errorf("master_natural progress = %+v, want none", *s.Progress)
+			}
+		}
+	}
+}
+
+func TestService_GetAchievements_NotFound(t *testing.T) {
+	service := appplayer.NewService(memory.NewPlayerRepository())
+
+	_, err := service.GetAchievements(context.Background(), "missing")
+	if !errors.Is(err, ports.ErrPlayerNotFound) {
+		t.Errorf("GetAchievements() error = %v, want ErrPlayerNotFound", err)
+	}
+}
+
+// knownPlaces is a place repository holding a fixed set of place IDs.
+type knownPlaces map[int]bool
+
+func (k knownPlaces) SearchPlaces(context.Context, string, int) ([]place.Place, error) {
+	return nil, nil
+}
+
+func (k knownPlaces) GetPlace(_ context.Context, id int) (*place.Place, error) {
+	if !k[id] {
+		return nil, ports.ErrPlaceNotFound
+	}
+	return &place.Place{ID: id}, nil
+}
+
+func TestService_SetHomePlace(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	service := appplayer.NewService(repo, appplayer.WithPlaces(knownPlaces{6906: true}))
+	ctx := context.Background()
+	player, _ := service.Register(ctx, "naturelover")
+
+	tests := []struct {
+		name    string
+		placeID int
+		wantErr error
+		want    int
+	}{
+		{"known place", 6906, nil, 6906},
+		{"unknown place", 1, appplayer.ErrInvalidPlace, 6906},
+		{"negative place", -1, appplayer.ErrInvalidPlace, 6906},
+		{"unset", 0, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.SetHomePlace(ctx, player.ID(), tt.placeID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetHomePlace() error = %v, want %v", err, tt.wantErr)
+			}
+			stored, _ := repo.GetByID(ctx, player.ID())
+			if stored.HomePlaceID() != tt.want {
+				t.Errorf("HomePlaceID() = %d, want %d", stored.HomePlaceID(), tt.want)
+			}
+		})
+	}
+}
+
+func TestService_SetHomePlace_SharedLocks(t *testing.T) {
+	repo := memory.NewPlayerRepository()
+	locks := &lock.KeyedMutex{}
+	service := appplayer.NewService(repo, appplayer.WithPlayerLocks(locks))
+	ctx := context.Background()
+	player, _ := service.Register(ctx, "naturelover")
+
+	// Another service updates the player's progression under the shared lock
+	unlock := locks.Lock(player.ID())
+	done := make(chan error)
+	go func() {
+		_, err := service.SetHomePlace(ctx, player.ID(), 6906)
+		done <- err
+	}()
+	progressed, _ := repo.GetByID(ctx, player.ID())
+	time.Sleep(10 * time.Millisecond)
+	progressed.AddXP(100)
+	repo.Update(ctx, progressed)
+	unlock()
+
+	if err := <-done; err != nil {
+		t.Fatalf("SetHomePlace() error = %v", err)
+	}
+	stored, _ := repo.GetByID(ctx, player.ID())
+	if stored.TotalXP() != 100 || stored.HomePlaceID() != 6906 {
+		t.Errorf("TotalXP() = %d, HomePlaceID() = %d, want 100 and 6906", stored.TotalXP(), stored.HomePlaceID())
+	}
+}
+
+func TestService_SetHomePlace_PlayerNotFound(t *testing.T) {
+	service := appplayer.NewService(memory.NewPlayerRepository())
+
+	_, err := service.SetHomePlace(context.Background(), "missing", 6906)
+	if !errors.Is(err, ports.ErrPlayerNotFound) {
+		t.Errorf("SetHomePlace() error = %v, want ErrPlayerNotFound", err)
+	}
+}
@@ -16,31 +16,40 @@ import (
 
 // QuestionFactory creates quiz questions of various types.
 type QuestionFactory interface {
-	// CreateQuestion generates a new question of the specified type and difficulty.
-	CreateQuestion(ctx context.Context, quizType quiz.QuizType, difficulty quiz.Difficulty) (*quiz.Question, error)
+	// CreateQuestion generates a new question of the specified type and
+	// difficulty, about a species matching filter.
+	CreateQuestion(
+		ctx context.Context,
+		quizType quiz.QuizType,
+		difficulty quiz.Difficulty,
+		filter quiz.Filter,
+	) (*quiz.Question, error)
 }
 
 // questionFactory implements QuestionFactory.
 type questionFactory struct {
 	speciesRepo ports.SpeciesRepository
-	taxonFilter string
-	placeID     int
+	deriver     ports.MediaDeriver
+	licenses    []string
 }
 
 // QuestionFactoryOption configures the factory.
 type QuestionFactoryOption func(*questionFactory)
 
-// WithTaxonFilter sets the taxon filter for questions.
-func WithTaxonFilter(taxon string) QuestionFactoryOption {
+// WithMediaDeriver serves PartialQuiz and SilhouetteQuiz photos as derivatives
+// produced on the server, so clients never receive the full photo.
+func WithMediaDeriver(deriver ports.MediaDeriver) QuestionFactoryOption {
 	return func(f *questionFactory) {
-		f.taxonFilter = taxon
+		f.deriver = deriver
 	}
 }
 
-// WithPlaceFilter sets the geographic filter for questions.
-func WithPlaceFilter(placeID int) QuestionFactoryOption {
+// WithAllowedLicenses only shows photos and plays sounds under one of the
+// license codes, such as cc-by; crops and silhouettes also exclude
+// no-derivatives licenses. All media is used without it.
+func WithAllowedLicenses(codes ...string) QuestionFactoryOption {
 	return func(f *questionFactory) {
-		f.placeID = placeID
+		f.licenses = codes
 	}
 }
 
@@ -60,18 +69,31 @@ func (f *questionFactory) CreateQuestion(
 	ctx context.Context,
 	quizType quiz.QuizType,
 	difficulty quiz.Difficulty,
+	filter quiz.Filter,
 ) (*quiz.Question, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
 	config := quiz.DefaultDifficultyConfigs()[difficulty]
 
 	// Get random species for the correct answer
-	filter := ports.SpeciesFilter{
-		IconicTaxon: f.taxonFilter,
-		PlaceID:     f.placeID,
+	correctFilter := ports.SpeciesFilter{
+		IconicTaxon: filter.IconicTaxon,
+		TaxonID:     filter.TaxonID,
+		PlaceID:     filter.PlaceID,
+		Lat:         filter.Area.Lat,
+		Lng:         filter.Area.Lng,
+		Radius:      filter.Area.RadiusKm,
+		Months:      filter.Months.List(),
 		Limit:       1,
 		HasPhotos:   true,
+		HasSounds:   quizType == quiz.SoundQuiz,
+	}
+	if quizType != quiz.SoundQuiz {
+		correctFilter.PhotoLicenses = f.licenses
 	}
 
-	correctSpecies, err := f.speciesRepo.GetRandom(ctx, filter)
+	correctSpecies, err := f.speciesRepo.GetRandom(ctx, correctFilter)
 	if err != nil {
 		return nil, fmt.Errorf("getting correct species: %w", err)
 	}
@@ -83,9 +105,28 @@ func (f *questionFactory) CreateQuestion(
 	if !correct.HasPhotos() {
 		return nil, errors.New("correct species has no photos")
 	}
+	if quizType == quiz.SoundQuiz && !correct.HasSounds() {
+		return nil, errors.New("correct species has no sounds")
+	}
+	var (
+		photo species.Photo
+		sound species.Sound
+	)
+	if quizType == quiz.SoundQuiz {
+		sounds := correct.LicensedSounds(f.licenses)
+		if len(sounds) == 0 {
+			return nil, errors.New("correct species has no sound under an allowed license")
+		}
+		sound = sounds[0]
+	} else {
+		var ok bool
+		if photo, ok = f.selectPhoto(correct, quizType); !ok {
+			return nil, errors.New("correct species has no photo under an allowed license")
+		}
+	}
 
-	// Get similar species for wrong answers
-	wrongChoices, err := f.getWrongChoices(ctx, correct, config.ChoicesCount-1)
+	// Get wrong answers at the taxonomic distance of the difficulty
+	wrongChoices, err := f.getWrongChoices(ctx, correct, difficulty, filter.Area, config.ChoicesCount-1)
 	if err != nil {
 		return nil, fmt.Errorf("getting wrong choices: %w", err)
 	}
@@ -109,9 +150,12 @@ func (f *questionFactory) CreateQuestion(
 	})
 
 	// Get media URL
-	mediaURL := f.selectMediaURL(correct, quizType)
+	mediaURL, err := f.deriveMedia(ctx, selectMediaURL(photo, sound, quizType), quizType, config)
+	if err != nil {
+		return nil, fmt.Errorf("deriving media: %w", err)
+	}
 
-	return quiz.NewQuestion(
+	question, err := quiz.NewQuestion(
 		uuid.New().String(),
 		quizType,
 		difficulty,
@@ -119,35 +163,146 @@ func (f *questionFactory) CreateQuestion(
 		choices,
 		mediaURL,
 	)
+	if err != nil {
+		return nil, err
+	}
+	question.SetPhotoID(photo.ID)
+	return question, nil
 }
 
 // Minimum number of choices required for a valid question.
 const minChoicesRequired = 2
 
-// getWrongChoices retrieves species to use as incorrect answers.
+// distractorLevel is a step of a distractor ladder: draw species within the
+// ancestor of the correct species at rank, or outside of it.
+type distractorLevel struct {
+	rank    string
+	outside bool
+}
+
+// distractorLadders lists, per difficulty, the taxonomic distances wrong
+// answers are drawn from. When a level runs out of species, the next one up
+// the ranks fills the remaining choices.
+var distractorLadders = map[quiz.Difficulty][]distractorLevel{
+	quiz.Beginner: {
+		{rank: species.RankOrder, outside: true},
+		{rank: species.RankClass},
+	},
+	quiz.Intermediate: {
+		{rank: species.RankOrder},
+		{rank: species.RankClass},
+	},
+	quiz.Expert: {
+		{rank: species.RankFamily},
+		{rank: species.RankOrder},
+		{rank: species.RankClass},
+	},
+	quiz.Master: {
+		{rank: species.RankGenus},
+		{rank: species.RankFamily},
+		{rank: species.RankOrder},
+		{rank: species.RankClass},
+	},
+}
+
+// getWrongChoices retrieves species to use as incorrect answers, at the
+// taxonomic distance of the difficulty. Expert and Master questions start with
+// the species identifiers most often confuse with the correct one, and Master
+// ones with known lookalikes next. Levels whose ancestor is unknown are
+// skipped, and the iconic taxon and similar species complete the choices last.
+// Within an area, wrong answers are only drawn from species observed there:
+// lookalikes and similar species, which may live elsewhere, are left out.
 func (f *questionFactory) getWrongChoices(
 	ctx context.Context,
 	correct *species.Species,
+	difficulty quiz.Difficulty,
+	area quiz.Area,
 	count int,
 ) ([]*species.Species, error) {
-	similar := f.fetchSimilarSpecies(ctx, correct.ID(), count)
-	if len(similar) >= count {
-		return similar[:count], nil
+	seen := map[int]bool{correct.ID(): true}
+	result := make([]*species.Species, 0, count)
+	anywhere := area.IsZero()
+
+	if anywhere && confusedFirst(difficulty) {
+		result = collectUniqueSpecies(result, f.fetchLookalikes(ctx, correct.ID(), count), seen, count)
+	}
+	if anywhere && difficulty == quiz.Master {
+		result = collectUniqueSpecies(result, f.fetchSimilarSpecies(ctx, correct.ID(), count), seen, count)
 	}
 
-	random, err := f.fetchRandomSpecies(ctx, correct, count)
-	if err != nil {
-		return nil, err
+	ancestors := f.fetchAncestors(ctx, correct)
+	for _, level := range distractorLadders[difficulty] {
+		if len(result) >= count {
+			break
+		}
+		ancestor, ok := species.FindRank(ancestors, level.rank)
+		if !ok {
+			continue
+		}
+		filter := f.distractorFilter(correct, result, area, count)
+		if level.outside {
+			filter.ExcludeIDs = append(filter.ExcludeIDs, ancestor.ID)
+		} else {
+			filter.TaxonID = ancestor.ID
+		}
+		random, _ := f.speciesRepo.GetRandom(ctx, filter) // Error ignored: the next level up fills in
+		result = collectUniqueSpecies(result, random, seen, count)
+	}
+
+	var err error
+	if len(result) < count {
+		var random []*species.Species
+		random, err = f.speciesRepo.GetRandom(ctx, f.distractorFilter(correct, result, area, count))
+		result = collectUniqueSpecies(result, random, seen, count)
+	}
+	if anywhere && len(result) < count && difficulty != quiz.Master {
+		result = collectUniqueSpecies(result, f.fetchSimilarSpecies(ctx, correct.ID(), count), seen, count)
 	}
 
-	result := f.combineUniqueSpecies(correct.ID(), similar, random, count)
 	if len(result) < minChoicesRequired {
+		if err != nil {
+			return nil, err
+		}
 		return nil, errors.New("not enough species for choices")
 	}
-
 	return result, nil
 }
 
+// confusedFirst reports whether wrong answers of a difficulty start with the
+// species most often confused with the correct one.
+func confusedFirst(difficulty quiz.Difficulty) bool {
+	switch difficulty {
+	case quiz.Expert, quiz.Master:
+		return true
+	case quiz.Beginner, quiz.Intermediate:
+		return false
+	}
+	return false
+}
+
+// fetchLookalikes retrieves the species most often confused with the given
+// one, returning empty slice on error.
+func (f *questionFactory) fetchLookalikes(ctx context.Context, speciesID, count int) []*species.Species {
+	lookalikes, err := f.speciesRepo.GetLookalikes(ctx, speciesID, count)
+	if err != nil {
+		return nil
+	}
+	return lookalikes
+}
+
+// fetchAncestors retrieves the ancestors of a species, returning an empty
+// slice on error.
+func (f *questionFactory) fetchAncestors(ctx context.Context, sp *species.Species) []species.Taxon {
+	if len(sp.AncestorIDs()) == 0 {
+		return nil
+	}
+	ancestors, err := f.speciesRepo.GetTaxa(ctx, sp.AncestorIDs())
+	if err != nil {
+		return nil
+	}
+	return ancestors
+}
+
 // fetchSimilarSpecies retrieves similar species, returning empty slice on error.
 func (f *questionFactory) fetchSimilarSpecies(ctx context.Context, speciesID, count int) []*species.Species {
 	similar, err := f.speciesRepo.GetSimilar(ctx, speciesID, count)
@@ -157,34 +312,28 @@ func (f *questionFactory) fetchSimilarSpecies(ctx context.Context, speciesID, co
 	return similar
 }
 
-// fetchRandomSpecies retrieves random species from the same taxon.
-func (f *questionFactory) fetchRandomSpecies(
-	ctx context.Context,
+// distractorFilter selects random species of the iconic taxon of correct
+// observed in area, excluding it and the choices already picked.
+func (f *questionFactory) distractorFilter(
 	correct *species.Species,
+	picked []*species.Species,
+	area quiz.Area,
 	count int,
-) ([]*species.Species, error) {
-	filter := ports.SpeciesFilter{
+) ports.SpeciesFilter {
+	excludeIDs := make([]int, 0, len(picked)+2)
+	excludeIDs = append(excludeIDs, correct.ID())
+	for _, sp := range picked {
+		excludeIDs = append(excludeIDs, sp.ID())
+	}
+	return ports.SpeciesFilter{
 		IconicTaxon: correct.IconicTaxon(),
 		Limit:       count + 5,
 		HasPhotos:   true,
-		ExcludeIDs:  []int{correct.ID()},
+		ExcludeIDs:  excludeIDs,
+		Lat:         area.Lat,
+		Lng:         area.Lng,
+		Radius:      area.RadiusKm,
 	}
-	return f.speciesRepo.GetRandom(ctx, filter)
-}
-
-// combineUniqueSpecies merges species lists, removing duplicates and the correct species.
-func (f *questionFactory) combineUniqueSpecies(
-	correctID int,
-	similar, random []*species.Species,
-	maxCount int,
-) []*species.Species {
-	seen := map[int]bool{correctID: true}
-	result := make([]*species.Species, 0, maxCount)
-
-	result = collectUniqueSpecies(result, similar, seen, maxCount)
-	result = collectUniqueSpecies(result, random, seen, maxCount)
-
-	return result
 }
 
 // collectUniqueSpecies adds unique species to the result slice up to maxCount.
@@ -205,15 +354,32 @@ func collectUniqueSpecies(
 	return result
 }
 
-// selectMediaURL selects the appropriate media URL based on quiz type.
-func (f *questionFactory) selectMediaURL(sp *species.Species, quizType quiz.QuizType) string {
-	photos := sp.Photos()
-	if len(photos) == 0 {
-		return ""
+// selectPhoto returns the first photo of sp under an allowed license. Crops
+// and silhouettes also need a license allowing derivatives.
+func (f *questionFactory) selectPhoto(sp *species.Species, quizType quiz.QuizType) (species.Photo, bool) {
+	for _, photo := range sp.LicensedPhotos(f.licenses) {
+		if len(f.licenses) > 0 && derivesMedia(quizType) && !photo.AllowsDerivatives() {
+			continue
+		}
+		return photo, true
 	}
+	return species.Photo{}, false
+}
 
-	photo := photos[0]
+// derivesMedia reports whether questions of a quiz type show a modified photo.
+func derivesMedia(quizType quiz.QuizType) bool {
+	switch quizType {
+	case quiz.PartialQuiz, quiz.SilhouetteQuiz:
+		return true
+	case quiz.ImageQuiz, quiz.FlashQuiz, quiz.SoundQuiz:
+		return false
+	}
+	return false
+}
 
+// selectMediaURL selects the appropriate media URL based on quiz type: the
+// recording for sound quiz, a size of photo otherwise.
+func selectMediaURL(photo species.Photo, sound species.Sound, quizType quiz.QuizType) string {
 	switch quizType {
 	case quiz.ImageQuiz:
 		if photo.LargeURL != "" {
@@ -230,10 +396,47 @@ func (f *questionFactory) selectMediaURL(sp *species.Species, quizType quiz.Quiz
 		}
 		return photo.LargeURL
 	case quiz.SoundQuiz:
-		// Sound quiz uses audio, not photos - return empty
-		return ""
+		return sound.FileURL
 	}
 
 	// Default fallback (should not be reached with exhaustive switch)
 	return photo.MediumURL
 }
+
+// deriveMedia registers the derivative shown by PartialQuiz and SilhouetteQuiz
+// questions and returns its media reference. Other quiz types, and all of them
+// without a media deriver, show the source media.
+func (f *questionFactory) deriveMedia(
+	ctx context.Context,
+	sourceURL string,
+	quizType quiz.QuizType,
+	config quiz.DifficultyConfig,
+) (string, error) {
+	if f.deriver == nil || sourceURL == "" {
+		return sourceURL, nil
+	}
+
+	derivative := ports.ImageDerivative{SourceURL: sourceURL}
+	switch quizType {
+	case quiz.PartialQuiz:
+		derivative.Effect = ports.EffectCrop
+		derivative.Region = cropRegion(config.CropRatio)
+	case quiz.SilhouetteQuiz:
+		derivative.Effect = ports.EffectSilhouette
+	case quiz.ImageQuiz, quiz.FlashQuiz, quiz.SoundQuiz:
+		return sourceURL, nil
+	}
+	return f.deriver.Register(ctx, derivative)
+}
+
+// cropRegion returns a random region covering ratio of the photo width and
+// height, biased towards the centre where the subject usually is.
+func cropRegion(ratio float64) ports.CropRegion {
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+	offset := func() float64 {
+		return (1 - ratio) * (0.25 + 0.5*rand.Float64()) //nolint:gosec // Not security sensitive
+	}
+	return ports.CropRegion{X: offset(), Y: offset(), Width: ratio, Height: ratio}
+}
@@ -3,7 +3,9 @@ package quiz_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
+	"time"
 
 	appquiz "github.com/Naturieux-fr/Naturieux.fr/internal/application/quiz"
 	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
@@ -17,6 +19,9 @@ type mockSpeciesRepository struct {
 	getRandomFunc  func(ctx context.Context, filter ports.SpeciesFilter) ([]*species.Species, error)
 	getSimilarFunc func(ctx context.Context, speciesID int, limit int) ([]*species.Species, error)
 	searchFunc     func(ctx context.Context, query string, limit int) ([]*species.Species, error)
+	getTaxaFunc    func(ctx context.Context, ids []int) ([]species.Taxon, error)
+	searchTaxaFunc func(ctx context.Context, query string, limit int) ([]species.Taxon, error)
+	lookalikesFunc func(ctx context.Context, speciesID int, limit int) ([]*species.Species, error)
 }
 
 func (m *mockSpeciesRepository) GetByID(ctx context.Context, id int) (*species.Species, error) {
@@ -47,6 +52,31 @@ func (m *mockSpeciesRepository) Search(ctx context.Context, query string, limit
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockSpeciesRepository) GetTaxa(ctx context.Context, ids []int) ([]species.Taxon, error) {
+	if m.getTaxaFunc != nil {
+		return m.getTaxaFunc(ctx, ids)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockSpeciesRepository) SearchTaxa(ctx context.Context, query string, limit int) ([]species.Taxon, error) {
+	if m.searchTaxaFunc != nil {
+		return m.searchTaxaFunc(ctx, query, limit)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockSpeciesRepository) GetLookalikes(
+	ctx context.Context,
+	speciesID int,
+	limit int,
+) ([]*species.Species, error) {
+	if m.lookalikesFunc != nil {
+		return m.lookalikesFunc(ctx, speciesID, limit)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func createMockSpecies(id int, name string) *species.Species {
 	sp, _ := species.New(id, name, name+" Common", "Mammalia")
 	sp.AddPhoto(species.Photo{
@@ -75,7 +105,7 @@ func TestQuestionFactory_CreateQuestion(t *testing.T) {
 
 	factory := appquiz.NewQuestionFactory(mockRepo)
 
-	question, err := factory.CreateQuestion(context.Background(), quiz.ImageQuiz, quiz.Beginner)
+	question, err := factory.CreateQuestion(context.Background(), quiz.ImageQuiz, quiz.Beginner, quiz.Filter{})
 	if err != nil {
 		t.Fatalf("CreateQuestion() error = %v", err)
 	}
@@ -118,7 +148,7 @@ func TestQuestionFactory_CreateQuestion_NoSpeciesFound(t *testing.T) {
 
 	factory := appquiz.NewQuestionFactory(mockRepo)
 
-	_, err := factory.CreateQuestion(context.Background(), quiz.ImageQuiz, quiz.Beginner)
+	_, err := factory.CreateQuestion(context.Background(), quiz.ImageQuiz, quiz.Beginner, quiz.Filter{})
 	if err == nil {
 		t.Error("CreateQuestion() should return error when no species found")
 	}
@@ -130,7 +160,9 @@ func TestQuestionFactory_CreateQuestion_WithFilters(t *testing.T) {
 	capturedFilter := ports.SpeciesFilter{}
 	mockRepo := &mockSpeciesRepository{
 		getRandomFunc: func(ctx context.Context, filter ports.SpeciesFilter) ([]*species.Species, error) {
-			capturedFilter = filter
+			if len(filter.ExcludeIDs) == 0 { // The correct answer, not distractors
+				capturedFilter = filter
+			}
 			return []*species.Species{correct}, nil
 		},
 		getSimilarFunc: func(ctx context.Context, speciesID int, limit int) ([]*species.Species, error) {
@@ -142,13 +174,13 @@ func TestQuestionFactory_CreateQuestion_WithFilters(t *testing.T) {
 		},
 	}
 
-	factory := appquiz.NewQuestionFactory(
-		mockRepo,
-		appquiz.WithTaxonFilter("Aves"),
-		appquiz.WithPlaceFilter(6753), // France
-	)
-
-	_, err := factory.CreateQuestion(context.Background(), quiz.ImageQuiz, quiz.Beginner)
+	filter := quiz.Filter{
+		IconicTaxon: "Aves",
+		TaxonID:     3,
+		PlaceID:     6753, // France
+		Months:      quiz.Winter.Months(),
+	}
+	_, err := appquiz.NewQuestionFactory(mockRepo).CreateQuestion(context.Background(), quiz.ImageQuiz, quiz.Beginner, filter)
 	if err != nil {
 		t.Fatalf("CreateQuestion() error = %v", err)
 	}
@@ -157,9 +189,18 @@ func TestQuestionFactory_CreateQuestion_WithFilters(t *testing.T) {
 		t.Errorf("IconicTaxon filter = %s, want Aves", capturedFilter.IconicTaxon)
 	}
 
+	if capturedFilter.TaxonID != 3 {
+		t.Errorf("TaxonID filter = %d, want 3", capturedFilter.TaxonID)
+	}
+
 	if capturedFilter.PlaceID != 6753 {
 		t.Errorf("PlaceID filter = %d, want 6753", capturedFilter.PlaceID)
 	}
+
+	wantMonths := []time.Month{time.January, time.February, time.December}
+	if !slices.Equal(capturedFilter.Months, wantMonths) {
+		t.Errorf("Months filter = %v, want %v", capturedFilter.Months, wantMonths)
+	}
 }
 
 func TestQuestionFactory_CreateQuestion_ExpertDifficulty(t *testing.T) {
@@ -183,7 +224,7 @@ func TestQuestionFactory_CreateQuestion_ExpertDifficulty(t *testing.T) {
 
 	factory := appquiz.NewQuestionFactory(mockRepo)
 
-	question, err := factory.CreateQuestion(context.Background(), quiz.ImageQuiz, quiz.Expert)
+	question, err := factory.CreateQuestion(context.Background(), quiz.ImageQuiz, quiz.Expert, quiz.Filter{})
 	if err != nil {
 		t.Fatalf("CreateQuestion() error = %v", err)
 	}
@@ -206,7 +247,7 @@ func TestQuestionFactory_CreateQuestion_SpeciesNoPhotos(t *testing.T) {
 
 	factory := appquiz.NewQuestionFactory(mockRepo)
 
-	_, err := factory.CreateQuestion(context.Background(), quiz.ImageQuiz, quiz.Beginner)
+	_, err := factory.CreateQuestion(context.Background(), quiz.ImageQuiz, quiz.Beginner, quiz.Filter{})
 	if err == nil {
 		t.Error("CreateQuestion() should return error when species has no photos")
 	}
@@ -232,7 +273,7 @@ func TestQuestionFactory_CreateQuestion_FallbackToRandom(t *testing.T) {
 
 	factory := appquiz.NewQuestionFactory(mockRepo)
 
-	question, err := factory.CreateQuestion(context.Background(), quiz.ImageQuiz, quiz.Beginner)
+	question, err := factory.CreateQuestion(context.Background(), quiz.ImageQuiz, quiz.Beginner, quiz.Filter{})
 	if err != nil {
 		t.Fatalf("CreateQuestion() should fall back to random, got error = %v", err)
 	}
@@ -241,3 +282,392 @@ func TestQuestionFactory_CreateQuestion_FallbackToRandom(t *testing.T) {
 		t.Fatal("CreateQuestion() returned nil")
 	}
 }
+
+// taxonomyRepository serves species of a small taxonomy and records the
+// filters of distractor draws. Species 1 is the correct answer; 2 shares its
+// genus 104, 3-4 its family 103, 5-8 its order 102 and 9-20 only its class 101.
+// Identifiers mostly confuse it with species 21, of another class.
+func taxonomyRepository() (*mockSpeciesRepository, *[]ports.SpeciesFilter) {
+	withAncestors := func(id int, ancestorIDs ...int) *species.Species {
+		sp := createMockSpecies(id, "Species")
+		sp.SetAncestorIDs(append(ancestorIDs, id))
+		return sp
+	}
+	correct := withAncestors(1, 101, 102, 103, 104)
+	pool := []*species.Species{withAncestors(2, 101, 102, 103, 104)}
+	for id := 3; id <= 4; id++ {
+		pool = append(pool, withAncestors(id, 101, 102, 103, 114))
+	}
+	for id := 5; id <= 8; id++ {
+		pool = append(pool, withAncestors(id, 101, 102, 113))
+	}
+	for id := 9; id <= 20; id++ {
+		pool = append(pool, withAncestors(id, 101, 112))
+	}
+	confused := withAncestors(21, 111)
+	ranks := map[int]string{
+		101: species.RankClass, 102: species.RankOrder, 103: species.RankFamily, 104: species.RankGenus,
+	}
+
+	var filters []ports.SpeciesFilter
+	repo := &mockSpeciesRepository{
+		getRandomFunc: func(_ context.Context, filter ports.SpeciesFilter) ([]*species.Species, error) {
+			if len(filter.ExcludeIDs) == 0 {
+				return []*species.Species{correct}, nil
+			}
+			filters = append(filters, filter)
+			var result []*species.Species
+			for _, sp := range pool {
+				if filter.TaxonID > 0 && !sp.HasAncestor(filter.TaxonID) {
+					continue
+				}
+				excluded := false
+				for _, id := range filter.ExcludeIDs {
+					excluded = excluded || sp.HasAncestor(id)
+				}
+				if !excluded && len(result) < filter.Limit {
+					result = append(result, sp)
+				}
+			}
+			return result, nil
+		},
+		getSimilarFunc: func(_ context.Context, _ int, _ int) ([]*species.Species, error) {
+			return []*species.Species{pool[len(pool)-1]}, nil // A lookalike of another order
+		},
+		lookalikesFunc: func(_ context.Context, _ int, _ int) ([]*species.Species, error) {
+			return []*species.Species{confused}, nil
+		},
+		getTaxaFunc: func(_ context.Context, ids []int) ([]species.Taxon, error) {
+			var taxa []species.Taxon
+			for _, id := range ids {
+				if rank, ok := ranks[id]; ok {
+					taxa = append(taxa, species.Taxon{ID: id, Name: "Taxon", Rank: rank})
+				}
+			}
+			return taxa, nil
+		},
+	}
+	return repo, &filters
+}
+
+func TestQuestionFactory_CreateQuestion_DistractorsByDifficulty(t *testing.T) {
+	tests := []struct {
+		name       string
+		difficulty quiz.Difficulty
+		taxonIDs   []int // TaxonID of each distractor draw
+		confused   bool  // Species 21 among the choices
+		check      func(sp *species.Species) bool
+	}{
+		{"beginner from other orders", quiz.Beginner, []int{0}, false,
+			func(sp *species.Species) bool { return !sp.HasAncestor(102) }},
+		{"intermediate from the same order", quiz.Intermediate, []int{102}, false,
+			func(sp *species.Species) bool { return sp.HasAncestor(102) }},
+		{"expert from confused species and family, then order", quiz.Expert, []int{103, 102}, true,
+			func(sp *species.Species) bool { return sp.HasAncestor(102) }},
+		{"master from confused species, lookalikes and genus, then family and order", quiz.Master,
+			[]int{104, 103, 102}, true,
+			func(sp *species.Species) bool { return sp.HasAncestor(101) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, filters := taxonomyRepository()
+			factory := appquiz.NewQuestionFactory(repo)
+
+			question, err := factory.CreateQuestion(context.Background(), quiz.ImageQuiz, tt.difficulty, quiz.Filter{})
+			if err != nil {
+				t.Fatalf("CreateQuestion() error = %v", err)
+			}
+
+			want := quiz.DefaultDifficultyConfigs()[tt.difficulty].ChoicesCount
+			if len(question.Choices()) != want {
+				t.Errorf("Choices count = %d, want %d", len(question.Choices()), want)
+			}
+			hasConfused := false
+			for _, choice := range question.Choices() {
+				if choice.Species.ID() == 21 {
+					hasConfused = true
+					continue
+				}
+				if !choice.IsCorrect && !tt.check(choice.Species) {
+					t.Errorf("distractor %d is at the wrong taxonomic distance", choice.Species.ID())
+				}
+			}
+			if hasConfused != tt.confused {
+				t.Errorf("confused species among choices = %v, want %v", hasConfused, tt.confused)
+			}
+
+			if len(*filters) != len(tt.taxonIDs) {
+				t.Fatalf("distractor draws = %+v, want taxa %v", *filters, tt.taxonIDs)
+			}
+			for i, filter := range *filters {
+				if filter.TaxonID != tt.taxonIDs[i] {
+					t.Errorf("draw %d TaxonID = %d, want %d", i, filter.TaxonID, tt.taxonIDs[i])
+				}
+			}
+			if tt.difficulty == quiz.Beginner && !slices.Contains((*filters)[0].ExcludeIDs, 102) {
+				t.Errorf("beginner draw ExcludeIDs = %v, want the order 102", (*filters)[0].ExcludeIDs)
+			}
+		})
+	}
+}
+
+func TestQuestionFactory_CreateQuestion_UnknownAncestry(t *testing.T) {
+	repo, filters := taxonomyRepository()
+	repo.getTaxaFunc = nil // Ancestry lookups fail
+
+	question, err := appquiz.NewQuestionFactory(repo).CreateQuestion(context.Background(), quiz.ImageQuiz, quiz.Expert, quiz.Filter{})
+	if err != nil {
+		t.Fatalf("CreateQuestion() error = %v", err)
+	}
+	if len(question.Choices()) != 8 {
+		t.Errorf("Choices count = %d, want 8", len(question.Choices()))
+	}
+	if len(*filters) != 1 || (*filters)[0].TaxonID != 0 || (*filters)[0].IconicTaxon != "Mammalia" {
+		t.Errorf("distractor draws = %+v, want a single draw from the iconic taxon", *filters)
+	}
+}
+
+func TestQuestionFactory_CreateQuestion_Area(t *testing.T) {
+	repo, filters := taxonomyRepository()
+	repo.lookalikesFunc = func(_ context.Context, _ int, _ int) ([]*species.Species, error) {
+		t.Error("GetLookalikes() called, want only species observed in the area")
+		return nil, nil
+	}
+	repo.getSimilarFunc = func(_ context.Context, _ int, _ int) ([]*species.Species, error) {
+		t.Error("GetSimilar() called, want only species observed in the area")
+		return nil, nil
+	}
+
+	area := quiz.Area{Lat: 45.83, Lng: 6.86, RadiusKm: 10}
+	question, err := appquiz.NewQuestionFactory(repo).
+		CreateQuestion(context.Background(), quiz.ImageQuiz, quiz.Master, quiz.Filter{Area: area})
+	if err != nil {
+		t.Fatalf("CreateQuestion() error = %v", err)
+	}
+	if len(question.Choices()) != 10 {
+		t.Errorf("Choices count = %d, want 10", len(question.Choices()))
+	}
+	if len(*filters) == 0 {
+		t.Fatal("no distractor draws")
+	}
+	for i, filter := range *filters {
+		if filter.Lat != 45.83 || filter.Lng != 6.86 || filter.Radius != 10 {
+			t.Errorf("draw %d area = %v, %v, %v km, want the session area", i, filter.Lat, filter.Lng, filter.Radius)
+		}
+	}
+}
+
+func TestQuestionFactory_CreateQuestion_SoundQuiz(t *testing.T) {
+	robin := createMockSpecies(1, "Erithacus rubecula")
+	robin.AddSound(species.Sound{ID: 5, FileURL: "https://example.com/song.mp3", FileContentType: "audio/mpeg"})
+
+	var correctFilter ports.SpeciesFilter
+	mockRepo := &mockSpeciesRepository{
+		getRandomFunc: func(_ context.Context, filter ports.SpeciesFilter) ([]*species.Species, error) {
+			if len(filter.ExcludeIDs) == 0 {
+				correctFilter = filter
+			}
+			return []*species.Species{robin}, nil
+		},
+		getSimilarFunc: func(_ context.Context, _ int, _ int) ([]*species.Species, error) {
+			return []*species.Species{createMockSpecies(2, "W1"), createMockSpecies(3, "W2"), createMockSpecies(4, "W3")}, nil
+		},
+	}
+
+	question, err := appquiz.NewQuestionFactory(mockRepo).CreateQuestion(context.Background(), quiz.SoundQuiz, quiz.Beginner, quiz.Filter{})
+	if err != nil {
+		t.Fatalf("CreateQuestion() error = %v", err)
+	}
+	if !correctFilter.HasSounds {
+		t.Error("correct species filter HasSounds = false, want true")
+	}
+	if question.MediaURL() != "https://example.com/song.mp3" {
+		t.Errorf("MediaURL = %s, want the recording", question.MediaURL())
+	}
+}
+
+func TestQuestionFactory_CreateQuestion_SoundQuizWithoutSounds(t *testing.T) {
+	mockRepo := &mockSpeciesRepository{
+		getRandomFunc: func(_ context.Context, _ ports.SpeciesFilter) ([]*species.Species, error) {
+			return []*species.Species{createMockSpecies(1, "Silent Species")}, nil
+		},
+	}
+
+	_, err := appquiz.NewQuestionFactory(mockRepo).CreateQuestion(context.Background(), quiz.SoundQuiz, quiz.Beginner, quiz.Filter{})
+	if err == nil {
+		t.Error("CreateQuestion() should return error when species has no sounds")
+	}
+}
+
+func TestQuestionFactory_CreateQuestion_SoundQuizLicenses(t *testing.T) {
+	robin := createMockSpecies(1, "Erithacus rubecula")
+	robin.AddSound(species.Sound{ID: 5, FileURL: "https://example.com/reserved.mp3"})
+	robin.AddSound(species.Sound{ID: 6, FileURL: "https://example.com/cc0.mp3", LicenseCode: "cc0"})
+
+	tests := []struct {
+		name     string
+		licenses []string
+		wantURL  string
+		wantErr  bool
+	}{
+		{name: "no restriction", wantURL: "https://example.com/reserved.mp3"},
+		{name: "first allowed", licenses: []string{"cc-by", "cc0"}, wantURL: "https://example.com/cc0.mp3"},
+		{name: "none allowed", licenses: []string{"cc-by"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockSpeciesRepository{
+				getRandomFunc: func(_ context.Context, _ ports.SpeciesFilter) ([]*species.Species, error) {
+					return []*species.Species{robin}, nil
+				},
+				getSimilarFunc: func(_ context.Context, _ int, _ int) ([]*species.Species, error) {
+					return []*species.Species{createMockSpecies(2, "W1"), createMockSpecies(3, "W2")}, nil
+				},
+			}
+			factory := appquiz.NewQuestionFactory(mockRepo, appquiz.WithAllowedLicenses(tt.licenses...))
+
+			question, err := factory.CreateQuestion(context.Background(), quiz.SoundQuiz, quiz.Beginner, quiz.Filter{})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("CreateQuestion() error = nil, want no licensed sound")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateQuestion() error = %v", err)
+			}
+			if question.MediaURL() != tt.wantURL {
+				t.Errorf("MediaURL = %s, want %s", question.MediaURL(), tt.wantURL)
+			}
+		})
+	}
+}
+
+// stubMediaDeriver records registered derivatives.
+type stubMediaDeriver struct {
+	registered []ports.ImageDerivative
+}
+
+func (d *stubMediaDeriver) Register(_ context.Context, derivative ports.ImageDerivative) (string, error) {
+	d.registered = append(d.registered, derivative)
+	return "media:derived", nil
+}
+
+func TestQuestionFactory_CreateQuestion_DerivedMedia(t *testing.T) {
+	tests := []struct {
+		name       string
+		quizType   quiz.QuizType
+		difficulty quiz.Difficulty
+		wantEffect ports.ImageEffect
+		wantRatio  float64
+	}{
+		{name: "partial beginner", quizType: quiz.PartialQuiz, difficulty: quiz.Beginner,
+			wantEffect: ports.EffectCrop, wantRatio: 0.6},
+		{name: "partial master", quizType: quiz.PartialQuiz, difficulty: quiz.Master,
+			wantEffect: ports.EffectCrop, wantRatio: 0.25},
+		{name: "silhouette", quizType: quiz.SilhouetteQuiz, difficulty: quiz.Beginner,
+			wantEffect: ports.EffectSilhouette},
+		{name: "image", quizType: quiz.ImageQuiz, difficulty: quiz.Beginner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockSpeciesRepository{
+				getRandomFunc: func(_ context.Context, _ ports.SpeciesFilter) ([]*species.Species, error) {
+					return []*species.Species{createMockSpecies(1, "Correct Species")}, nil
+				},
+				getSimilarFunc: func(_ context.Context, _ int, _ int) ([]*species.Species, error) {
+					return []*species.Species{createMockSpecies(2, "W1"), createMockSpecies(3, "W2")}, nil
+				},
+			}
+			deriver := &stubMediaDeriver{}
+			factory := appquiz.NewQuestionFactory(mockRepo, appquiz.WithMediaDeriver(deriver))
+
+			question, err := factory.CreateQuestion(context.Background(), tt.quizType, tt.difficulty, quiz.Filter{})
+			if err != nil {
+				t.Fatalf("CreateQuestion() error = %v", err)
+			}
+
+			if tt.wantEffect == "" {
+				if len(deriver.registered) != 0 || question.MediaURL() != "https://example.com/photo_large.jpg" {
+					t.Errorf("MediaURL = %s with %d derivatives, want the source photo",
+						question.MediaURL(), len(deriver.registered))
+				}
+				return
+			}
+			if len(deriver.registered) != 1 || question.MediaURL() != "media:derived" {
+				t.Fatalf("MediaURL = %s with %d derivatives, want one derivative",
+					question.MediaURL(), len(deriver.registered))
+			}
+			derivative := deriver.registered[0]
+			if derivative.Effect != tt.wantEffect || derivative.SourceURL != "https://example.com/photo_large.jpg" {
+				t.Errorf("derivative = %+v, want %s of the large photo", derivative, tt.wantEffect)
+			}
+			region := derivative.Region
+			if region.Width != tt.wantRatio || region.Height != tt.wantRatio {
+				t.Errorf("region size = %vx%v, want %v", region.Width, region.Height, tt.wantRatio)
+			}
+			if region.X < 0 || region.Y < 0 || region.X+region.Width > 1 || region.Y+region.Height > 1 {
+				t.Errorf("region = %+v, want it within the photo", region)
+			}
+		})
+	}
+}
+
+func TestQuestionFactory_CreateQuestion_AllowedLicenses(t *testing.T) {
+	fox, _ := species.New(1, "Vulpes vulpes", "Renard roux", "Mammalia")
+	fox.AddPhoto(species.Photo{ID: 11, LargeURL: "https://example.com/reserved.jpg"})
+	fox.AddPhoto(species.Photo{ID: 12, LargeURL: "https://example.com/nd.jpg", LicenseCode: "cc-by-nd"})
+	fox.AddPhoto(species.Photo{ID: 13, LargeURL: "https://example.com/by.jpg", LicenseCode: "cc-by"})
+
+	tests := []struct {
+		name        string
+		quizType    quiz.QuizType
+		licenses    []string
+		wantPhotoID int
+		wantErr     bool
+	}{
+		{name: "no restriction", quizType: quiz.ImageQuiz, wantPhotoID: 11},
+		{name: "first allowed", quizType: quiz.ImageQuiz, licenses: []string{"cc-by", "cc-by-nd"}, wantPhotoID: 12},
+		{name: "derivatives allowed", quizType: quiz.PartialQuiz, licenses: []string{"cc-by", "cc-by-nd"},
+			wantPhotoID: 13},
+		{name: "none allowed", quizType: quiz.ImageQuiz, licenses: []string{"cc0"}, wantErr: true},
+		{name: "no derivatives allowed", quizType: quiz.SilhouetteQuiz, licenses: []string{"cc-by-nd"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var correctFilter ports.SpeciesFilter
+			mockRepo := &mockSpeciesRepository{
+				getRandomFunc: func(_ context.Context, filter ports.SpeciesFilter) ([]*species.Species, error) {
+					if len(filter.ExcludeIDs) == 0 {
+						correctFilter = filter
+					}
+					return []*species.Species{fox}, nil
+				},
+				getSimilarFunc: func(_ context.Context, _ int, _ int) ([]*species.Species, error) {
+					return []*species.Species{createMockSpecies(2, "W1"), createMockSpecies(3, "W2")}, nil
+				},
+			}
+			factory := appquiz.NewQuestionFactory(mockRepo, appquiz.WithAllowedLicenses(tt.licenses...))
+
+			question, err := factory.CreateQuestion(context.Background(), tt.quizType, quiz.Beginner, quiz.Filter{})
+			if !slices.Equal(correctFilter.PhotoLicenses, tt.licenses) {
+				t.Errorf("PhotoLicenses filter = %v, want %v", correctFilter.PhotoLicenses, tt.licenses)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Error("CreateQuestion() error = nil, want no licensed photo")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateQuestion() error = %v", err)
+			}
+			if photo, ok := question.Photo(); !ok || photo.ID != tt.wantPhotoID {
+				t.Errorf("Photo() = %+v, %v, want photo %d", photo, ok, tt.wantPhotoID)
+			}
+		})
+	}
+}
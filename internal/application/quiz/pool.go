@@ -0,0 +1,346 @@
+package quiz
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// Default values for the question pool.
+const (
+	defaultPoolMinSize     = 5
+	defaultPoolMaxSize     = 30
+	defaultPoolMaxAge      = 30 * time.Minute
+	defaultPoolIdleTimeout = 2 * time.Hour
+	defaultPoolWorkers     = 1
+	defaultPoolRetryDelay  = 10 * time.Second
+	maxPoolRetryDelay      = 30 * time.Minute
+	poolSweepInterval      = time.Minute
+)
+
+// PoolKey identifies interchangeable questions: any question of a key can
+// serve any session asking for it.
+type PoolKey struct {
+	QuizType   quiz.QuizType
+	Difficulty quiz.Difficulty
+	Filter     quiz.Filter
+}
+
+// PoolStats describes the question pool for monitoring.
+type PoolStats struct {
+	Keys     int    `json:"keys"`     // Keys being kept topped up
+	Ready    int    `json:"ready"`    // Questions ready to be drawn
+	Target   int    `json:"target"`   // Questions wanted across keys
+	Hits     uint64 `json:"hits"`     // Questions drawn from the pool
+	Misses   uint64 `json:"misses"`   // Questions requested while the pool was empty
+	Discards uint64 `json:"discards"` // Stale questions thrown away
+}
+
+// QuestionPool prefetches questions in the background so sessions start
+// without waiting for the species source. Each key keeps a target number of
+// ready questions: misses raise it, keys without demand shrink to none and are
+// eventually dropped, and questions older than the maximum age are discarded.
+// A key whose questions fail is retried less and less often.
+type QuestionPool struct {
+	factory     QuestionFactory
+	minSize     int
+	maxSize     int
+	maxAge      time.Duration
+	idleTimeout time.Duration
+	workers     int
+	retryDelay  time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	buckets map[PoolKey]*poolBucket
+	stats   PoolStats
+	wake    chan struct{}
+}
+
+// poolBucket holds the ready questions of a key, oldest first.
+type poolBucket struct {
+	ready      []pooledQuestion
+	target     int
+	inflight   int
+	lastDemand time.Time
+	lastResize time.Time
+	failures   int       // Consecutive failed creations
+	retryAt    time.Time // No creation before, after failures
+}
+
+// pooledQuestion is a ready question and when it was created.
+type pooledQuestion struct {
+	question  *quiz.Question
+	createdAt time.Time
+}
+
+// QuestionPoolOption configures the question pool.
+type QuestionPoolOption func(*QuestionPool)
+
+// WithPoolSize bounds the number of ready questions kept per key.
+func WithPoolSize(minSize, maxSize int) QuestionPoolOption {
+	return func(p *QuestionPool) {
+		if minSize > 0 && maxSize >= minSize {
+			p.minSize = minSize
+			p.maxSize = maxSize
+		}
+	}
+}
+
+// WithPoolMaxAge sets how long a ready question stays fresh.
+func WithPoolMaxAge(d time.Duration) QuestionPoolOption {
+	return func(p *QuestionPool) {
+		if d > 0 {
+			p.maxAge = d
+		}
+	}
+}
+
+// WithPoolIdleTimeout sets how long a key is kept without demand.
+func WithPoolIdleTimeout(d time.Duration) QuestionPoolOption {
+	return func(p *QuestionPool) {
+		if d > 0 {
+			p.idleTimeout = d
+		}
+	}
+}
+
+// WithPoolWorkers sets how many questions are created concurrently.
+func WithPoolWorkers(n int) QuestionPoolOption {
+	return func(p *QuestionPool) {
+		if n > 0 {
+			p.workers = n
+		}
+	}
+}
+
+// WithPoolRetryDelay sets the pause of a key after a failed creation. It
+// doubles with each consecutive failure, up to 30 minutes.
+func WithPoolRetryDelay(d time.Duration) QuestionPoolOption {
+	return func(p *QuestionPool) {
+		if d >= 0 {
+			p.retryDelay = d
+		}
+	}
+}
+
+// WithPoolClock sets the time source, mainly for tests.
+func WithPoolClock(now func() time.Time) QuestionPoolOption {
+	return func(p *QuestionPool) {
+		p.now = now
+	}
+}
+
+// NewQuestionPool creates a pool filled by factory. Call Start to run the workers.
+func NewQuestionPool(factory QuestionFactory, opts ...QuestionPoolOption) *QuestionPool {
+	p := &QuestionPool{
+		factory:     factory,
+		minSize:     defaultPoolMinSize,
+		maxSize:     defaultPoolMaxSize,
+		maxAge:      defaultPoolMaxAge,
+		idleTimeout: defaultPoolIdleTimeout,
+		workers:     defaultPoolWorkers,
+		retryDelay:  defaultPoolRetryDelay,
+		now:         time.Now,
+		buckets:     make(map[PoolKey]*poolBucket),
+		wake:        make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Start runs the workers until ctx is cancelled. Their species lookups are
+// low priority, so they only use the request budget players leave spare.
+func (p *QuestionPool) Start(ctx context.Context) {
+	ctx = ports.WithPriority(ctx, ports.PriorityLow)
+	for i := 0; i < p.workers; i++ {
+		go p.work(ctx)
+	}
+}
+
+// Take draws a ready question of key. It returns false when none is ready,
+// and the key then gets more questions prefetched.
+func (p *QuestionPool) Take(key PoolKey) (*quiz.Question, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	defer p.notify()
+
+	now := p.now()
+	bucket, ok := p.buckets[key]
+	if !ok {
+		bucket = &poolBucket{target: p.minSize, lastResize: now}
+		p.buckets[key] = bucket
+	}
+	bucket.lastDemand = now
+	bucket.target = max(bucket.target, p.minSize)
+	p.discardStale(bucket, now)
+
+	if len(bucket.ready) == 0 {
+		p.stats.Misses++
+		bucket.target = min(bucket.target+1, p.maxSize)
+		return nil, false
+	}
+
+	p.stats.Hits++
+	question := bucket.ready[0].question
+	bucket.ready[0] = pooledQuestion{}
+	bucket.ready = bucket.ready[1:]
+	return question, true
+}
+
+// Stats returns the pool activity.
+func (p *QuestionPool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := p.stats
+	stats.Keys = len(p.buckets)
+	for _, bucket := range p.buckets {
+		stats.Ready += len(bucket.ready)
+		stats.Target += bucket.target
+	}
+	return stats
+}
+
+// work creates questions for the keys furthest below their target.
+func (p *QuestionPool) work(ctx context.Context) {
+	for {
+		key, retry, ok := p.next()
+		if ok {
+			question, err := p.factory.CreateQuestion(ctx, key.QuizType, key.Difficulty, key.Filter)
+			p.done(key, question, err)
+			continue
+		}
+
+		// Wait for demand, the next sweep or the next retry of a failing key
+		wait := poolSweepInterval
+		if retry > 0 {
+			wait = min(wait, retry)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-p.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// next reserves a question to create for the key with the largest deficit,
+// skipping the keys waiting to retry. Without any, it returns how long until
+// the first retry, 0 when no key waits.
+func (p *QuestionPool) next() (PoolKey, time.Duration, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	p.sweep(now)
+
+	var (
+		best    PoolKey
+		deficit int
+		retry   time.Duration
+	)
+	for key, bucket := range p.buckets {
+		d := bucket.target - len(bucket.ready) - bucket.inflight
+		if d <= 0 {
+			continue
+		}
+		if wait := bucket.retryAt.Sub(now); wait > 0 {
+			if retry == 0 || wait < retry {
+				retry = wait
+			}
+			continue
+		}
+		if d > deficit {
+			best, deficit = key, d
+		}
+	}
+	if deficit == 0 {
+		return PoolKey{}, retry, false
+	}
+
+	p.buckets[best].inflight++
+	if deficit > 1 {
+		p.notify() // Let another worker help
+	}
+	return best, 0, true
+}
+
+// done stores a created question.
+func (p *QuestionPool) done(key PoolKey, question *quiz.Question, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	bucket, ok := p.buckets[key]
+	if !ok {
+		return // Dropped while idle
+	}
+	bucket.inflight = max(bucket.inflight-1, 0)
+	if err != nil {
+		// Back off, e.g. while the request budget is exhausted or the filter has no species
+		bucket.failures++
+		bucket.retryAt = p.now().Add(p.retryBackoff(bucket.failures))
+		return
+	}
+	bucket.failures = 0
+	bucket.retryAt = time.Time{}
+	bucket.ready = append(bucket.ready, pooledQuestion{question: question, createdAt: p.now()})
+}
+
+// retryBackoff returns the pause of a key after its given number of
+// consecutive failures.
+func (p *QuestionPool) retryBackoff(failures int) time.Duration {
+	delay := p.retryDelay
+	for i := 1; i < failures && delay > 0 && delay < maxPoolRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxPoolRetryDelay)
+}
+
+// sweep discards stale questions, halves the target of keys without recent
+// demand, down to none below the minimum size, and drops idle keys.
+func (p *QuestionPool) sweep(now time.Time) {
+	for key, bucket := range p.buckets {
+		if now.Sub(bucket.lastDemand) >= p.idleTimeout {
+			p.stats.Discards += uint64(len(bucket.ready))
+			delete(p.buckets, key)
+			continue
+		}
+		if now.Sub(bucket.lastDemand) >= p.maxAge && now.Sub(bucket.lastResize) >= p.maxAge {
+			if bucket.target /= 2; bucket.target < p.minSize {
+				bucket.target = 0
+			}
+			bucket.lastResize = now
+		}
+		p.discardStale(bucket, now)
+	}
+}
+
+// discardStale drops the questions older than the maximum age.
+func (p *QuestionPool) discardStale(bucket *poolBucket, now time.Time) {
+	stale := 0
+	for stale < len(bucket.ready) && now.Sub(bucket.ready[stale].createdAt) >= p.maxAge {
+		stale++
+	}
+	if stale > 0 {
+		p.stats.Discards += uint64(stale)
+		bucket.ready = append(bucket.ready[:0], bucket.ready[stale:]...)
+	}
+}
+
+// notify wakes a waiting worker.
+func (p *QuestionPool) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
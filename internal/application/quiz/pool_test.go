@@ -0,0 +1,276 @@
+package quiz_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	appquiz "github.com/Naturieux-fr/Naturieux.fr/internal/application/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
+)
+
+// poolFactory is a concurrency-safe QuestionFactory numbering its questions.
+type poolFactory struct {
+	created    atomic.Int32
+	failing    atomic.Bool
+	failsFor   string       // Iconic taxon whose questions always fail
+	failures   atomic.Int32 // Failed creations
+	priorities chan ports.RequestPriority
+}
+
+func (f *poolFactory) CreateQuestion(
+	ctx context.Context,
+	quizType quiz.QuizType,
+	difficulty quiz.Difficulty,
+	filter quiz.Filter,
+) (*quiz.Question, error) {
+	if f.priorities != nil {
+		select {
+		case f.priorities <- ports.PriorityFrom(ctx):
+		default:
+		}
+	}
+	if f.failing.Load() || (f.failsFor != "" && filter.IconicTaxon == f.failsFor) {
+		f.failures.Add(1)
+		return nil, errors.New("species source down")
+	}
+
+	n := int(f.created.Add(1))
+	sp, _ := species.New(n, "Species", "Espece", "Aves")
+	wrong, _ := species.New(n+1000, "Wrong", "Faux", "Aves")
+	choices := []quiz.Choice{{Species: sp, IsCorrect: true}, {Species: wrong}}
+	return quiz.NewQuestion("q-"+strconv.Itoa(n), quizType, difficulty, sp, choices, "https://example.com/img.jpg")
+}
+
+// poolClock is a manually advanced time source.
+type poolClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *poolClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *poolClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// waitFor polls cond until it holds or the test times out.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+var birdImageKey = appquiz.PoolKey{
+	QuizType:   quiz.ImageQuiz,
+	Difficulty: quiz.Beginner,
+	Filter:     quiz.Filter{IconicTaxon: "Aves"},
+}
+
+func TestQuestionPool_PrefetchesOnDemand(t *testing.T) {
+	factory := &poolFactory{priorities: make(chan ports.RequestPriority, 1)}
+	pool := appquiz.NewQuestionPool(factory, appquiz.WithPoolSize(3, 10))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool.Start(ctx)
+
+	if _, ok := pool.Take(birdImageKey); ok {
+		t.Fatal("Take() on an unknown key = true, want a miss")
+	}
+	waitFor(t, "the pool to fill", func() bool { return pool.Stats().Ready == 4 })
+
+	question, ok := pool.Take(birdImageKey)
+	if !ok {
+		t.Fatal("Take() = false, want a prefetched question")
+	}
+	if question.QuizType() != quiz.ImageQuiz || question.Difficulty() != quiz.Beginner {
+		t.Errorf("Take() = %s/%s question, want image/beginner", question.QuizType(), question.Difficulty())
+	}
+	if priority := <-factory.priorities; priority != ports.PriorityLow {
+		t.Errorf("workers priority = %v, want PriorityLow", priority)
+	}
+
+	// Drawn questions are replaced
+	waitFor(t, "the pool to top up", func() bool { return pool.Stats().Ready == 4 })
+	stats := pool.Stats()
+	if stats.Keys != 1 || stats.Hits != 1 || stats.Misses != 1 || stats.Target != 4 {
+		t.Errorf("Stats() = %+v, want 1 key, 1 hit, 1 miss, target 4", stats)
+	}
+}
+
+func TestQuestionPool_TargetGrowsWithMisses(t *testing.T) {
+	pool := appquiz.NewQuestionPool(&poolFactory{}, appquiz.WithPoolSize(2, 5))
+
+	// Without workers every draw misses
+	for i := 0; i < 10; i++ {
+		pool.Take(birdImageKey)
+	}
+	if target := pool.Stats().Target; target != 5 {
+		t.Errorf("Target = %d, want the maximum 5", target)
+	}
+}
+
+func TestQuestionPool_DiscardsStaleAndIdle(t *testing.T) {
+	clock := &poolClock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	pool := appquiz.NewQuestionPool(&poolFactory{},
+		appquiz.WithPoolSize(2, 2),
+		appquiz.WithPoolMaxAge(time.Minute),
+		appquiz.WithPoolIdleTimeout(time.Hour),
+		appquiz.WithPoolClock(clock.Now),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool.Start(ctx)
+
+	pool.Take(birdImageKey)
+	waitFor(t, "the pool to fill", func() bool { return pool.Stats().Ready == 2 })
+	cancel() // Stop refilling to observe discards
+
+	clock.Advance(time.Minute)
+	if _, ok := pool.Take(birdImageKey); ok {
+		t.Error("Take() returned a stale question")
+	}
+	if discards := pool.Stats().Discards; discards != 2 {
+		t.Errorf("Discards = %d, want 2", discards)
+	}
+}
+
+func TestQuestionPool_DropsIdleKeys(t *testing.T) {
+	clock := &poolClock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	factory := &poolFactory{}
+	pool := appquiz.NewQuestionPool(factory,
+		appquiz.WithPoolSize(2, 2),
+		appquiz.WithPoolIdleTimeout(time.Hour),
+		appquiz.WithPoolClock(clock.Now),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool.Start(ctx)
+
+	pool.Take(birdImageKey)
+	waitFor(t, "the pool to fill", func() bool { return pool.Stats().Ready == 2 })
+
+	clock.Advance(time.Hour)
+	pool.Take(appquiz.PoolKey{QuizType: quiz.FlashQuiz, Difficulty: quiz.Expert}) // Wakes a worker to sweep
+	waitFor(t, "the idle key to be dropped", func() bool { return pool.Stats().Keys == 1 })
+}
+
+func TestQuestionPool_RetriesAfterFailures(t *testing.T) {
+	factory := &poolFactory{}
+	factory.failing.Store(true)
+	pool := appquiz.NewQuestionPool(factory, appquiz.WithPoolSize(1, 1), appquiz.WithPoolRetryDelay(time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool.Start(ctx)
+
+	pool.Take(birdImageKey)
+	time.Sleep(10 * time.Millisecond)
+	factory.failing.Store(false)
+	waitFor(t, "the pool to recover", func() bool { return pool.Stats().Ready == 1 })
+}
+
+func TestQuestionPool_BacksOffFailingKeys(t *testing.T) {
+	factory := &poolFactory{failsFor: "Fungi"}
+	pool := appquiz.NewQuestionPool(factory, appquiz.WithPoolSize(2, 10), appquiz.WithPoolRetryDelay(time.Hour))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool.Start(ctx)
+
+	// The failing key has the largest deficit, yet the other key still fills
+	fungiKey := appquiz.PoolKey{QuizType: quiz.ImageQuiz, Difficulty: quiz.Beginner, Filter: quiz.Filter{IconicTaxon: "Fungi"}}
+	for i := 0; i < 5; i++ {
+		pool.Take(fungiKey)
+	}
+	pool.Take(birdImageKey)
+	waitFor(t, "the other key to fill", func() bool { return pool.Stats().Ready == 3 })
+
+	if failures := factory.failures.Load(); failures != 1 {
+		t.Errorf("failing key tried %d times, want 1 before its retry delay", failures)
+	}
+}
+
+func TestQuestionPool_TargetDecaysWithoutDemand(t *testing.T) {
+	clock := &poolClock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	pool := appquiz.NewQuestionPool(&poolFactory{},
+		appquiz.WithPoolSize(2, 10),
+		appquiz.WithPoolMaxAge(time.Minute),
+		appquiz.WithPoolIdleTimeout(time.Hour),
+		appquiz.WithPoolClock(clock.Now),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool.Start(ctx)
+
+	pool.Take(birdImageKey)
+	waitFor(t, "the pool to fill", func() bool { return pool.Stats().Ready == 3 })
+
+	// A key used once is not refilled once its questions are stale
+	clock.Advance(time.Minute)
+	pool.Take(appquiz.PoolKey{QuizType: quiz.FlashQuiz, Difficulty: quiz.Expert}) // Wakes a worker to sweep
+	waitFor(t, "the other key to fill", func() bool {
+		stats := pool.Stats()
+		return stats.Discards == 3 && stats.Ready == 3
+	})
+	if stats := pool.Stats(); stats.Keys != 2 || stats.Target != 3 || stats.Discards != 3 {
+		t.Errorf("Stats() = %+v, want 2 keys, target 3 and 3 discards", stats)
+	}
+
+	// Demand restores the key
+	pool.Take(birdImageKey)
+	waitFor(t, "the key to refill", func() bool { return pool.Stats().Ready == 6 })
+}
+
+func TestService_StartSession_DrawsFromPool(t *testing.T) {
+	playerRepo := newMockPlayerRepository()
+	player, _ := gamification.NewPlayer("user1", "testuser")
+	playerRepo.Create(context.Background(), player)
+
+	pooled := &poolFactory{}
+	pool := appquiz.NewQuestionPool(pooled, appquiz.WithPoolSize(5, 5))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool.Start(ctx)
+
+	pool.Take(birdImageKey)
+	waitFor(t, "the pool to fill", func() bool { return pool.Stats().Ready == 5 })
+
+	fallback := newMockQuestionFactory()
+	service := appquiz.NewService(fallback, nil, playerRepo, nil, appquiz.WithQuestionPool(pool))
+	resp, err := service.StartSession(context.Background(), appquiz.StartSessionRequest{
+		UserID:        "user1",
+		Difficulty:    quiz.Beginner,
+		QuizTypes:     []quiz.QuizType{quiz.ImageQuiz},
+		Filter:        quiz.Filter{IconicTaxon: "Aves"},
+		QuestionCount: 8,
+	})
+	if err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+
+	if resp.TotalQuestions != 8 {
+		t.Errorf("TotalQuestions = %d, want 8", resp.TotalQuestions)
+	}
+	if fallback.index != 3 {
+		t.Errorf("factory created %d questions, want 3 beyond the 5 pooled", fallback.index)
+	}
+	if resp.FirstQuestion.ID() == "q-default" {
+		t.Error("first question was created on demand, want a pooled one")
+	}
+}
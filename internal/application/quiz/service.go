@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/Naturieux-fr/Naturieux.fr/internal/application/lock"
 	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
 	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
 	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
@@ -27,13 +29,73 @@ type Service struct {
 	sessionRepo     ports.QuizSessionRepository
 	playerRepo      ports.PlayerRepository
 	eventPublisher  GameEventPublisher
+	sessionRecorder SessionRecorder
+	questionPool    *QuestionPool
+	defaultPlaceID  int
+	speciesRepo     ports.SpeciesRepository // Checks taxon filters when set
+	places          ports.PlaceRepository   // Checks place filters when set
+	sessionLocks    lock.KeyedMutex         // Serializes answers and abandons per session
+	playerLocks     *lock.KeyedMutex        // Serializes player updates, shared with other services
+}
+
+// SessionRecorder receives completed sessions, e.g. to feed leaderboards.
+type SessionRecorder interface {
+	RecordSession(ctx context.Context, session *quiz.Session) error
+}
+
+// ServiceOption configures the quiz service.
+type ServiceOption func(*Service)
+
+// WithSessionRecorder records every completed session with the given recorder.
+func WithSessionRecorder(recorder SessionRecorder) ServiceOption {
+	return func(s *Service) {
+		s.sessionRecorder = recorder
+	}
+}
+
+// WithQuestionPool draws session questions from a prefetching pool, falling
+// back to the factory for questions the pool does not have ready.
+func WithQuestionPool(pool *QuestionPool) ServiceOption {
+	return func(s *Service) {
+		s.questionPool = pool
+	}
+}
+
+// WithDefaultPlace draws the questions of sessions without a place or area
+// filter from species observed in placeID, unless the player has a home place.
+func WithDefaultPlace(placeID int) ServiceOption {
+	return func(s *Service) {
+		s.defaultPlaceID = placeID
+	}
+}
+
+// WithSpecies resolves taxon names and rejects unknown taxa of session filters.
+func WithSpecies(repo ports.SpeciesRepository) ServiceOption {
+	return func(s *Service) {
+		s.speciesRepo = repo
+	}
+}
+
+// WithPlaces rejects unknown places of session filters.
+func WithPlaces(places ports.PlaceRepository) ServiceOption {
+	return func(s *Service) {
+		s.places = places
+	}
+}
+
+// WithPlayerLocks serializes progression updates with the other player updates
+// holding locks, such as home place changes.
+func WithPlayerLocks(locks *lock.KeyedMutex) ServiceOption {
+	return func(s *Service) {
+		s.playerLocks = locks
+	}
 }
 
 // GameEventPublisher publishes game events for gamification.
 type GameEventPublisher interface {
 	PublishSessionCompleted(session *quiz.Session, player *gamification.Player)
 	PublishLevelUp(player *gamification.Player, event gamification.LevelUpEvent)
-	PublishAchievementUnlocked(player *gamification.Player, achievement gamification.Achievement)
+	PublishAchievementUnlocked(player *gamification.Player, unlock gamification.AchievementUnlock)
 }
 
 // NewService creates a new quiz service.
@@ -42,13 +104,19 @@ func NewService(
 	sessionRepo ports.QuizSessionRepository,
 	playerRepo ports.PlayerRepository,
 	eventPublisher GameEventPublisher,
+	opts ...ServiceOption,
 ) *Service {
-	return &Service{
+	s := &Service{
 		questionFactory: factory,
 		sessionRepo:     sessionRepo,
 		playerRepo:      playerRepo,
 		eventPublisher:  eventPublisher,
+		playerLocks:     &lock.KeyedMutex{},
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // StartSessionRequest contains parameters for starting a new quiz session.
@@ -56,7 +124,8 @@ type StartSessionRequest struct {
 	UserID        string
 	Difficulty    quiz.Difficulty
 	QuizTypes     []quiz.QuizType
-	TaxonFilter   string
+	Filter        quiz.Filter // Species the questions are drawn from
+	TaxonName     string      // Taxon of any rank, such as Orchidaceae, instead of Filter.TaxonID
 	QuestionCount int
 }
 
@@ -86,10 +155,24 @@ func (s *Service) StartSession(ctx context.Context, req StartSessionRequest) (*S
 		return nil, errors.New("user ID is required")
 	}
 	req.normalize()
+	req.Filter.Area = req.Filter.Area.Rounded()
+	if err := req.Filter.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkFilter(ctx, &req); err != nil {
+		return nil, err
+	}
 
-	if _, err := s.playerRepo.GetByID(ctx, req.UserID); err != nil {
+	player, err := s.playerRepo.GetByID(ctx, req.UserID)
+	if err != nil {
 		return nil, fmt.Errorf("player not found: %w", err)
 	}
+	if req.Filter.PlaceID == 0 && req.Filter.Area.IsZero() {
+		req.Filter.PlaceID = s.defaultPlaceID
+		if player.HomePlaceID() != 0 {
+			req.Filter.PlaceID = player.HomePlaceID()
+		}
+	}
 
 	questions, err := s.generateQuestions(ctx, req)
 	if err != nil {
@@ -112,32 +195,106 @@ func (s *Service) StartSession(ctx context.Context, req StartSessionRequest) (*S
 	}, nil
 }
 
+// maxTaxonMatches is the number of taxa searched when resolving a taxon name.
+const maxTaxonMatches = 10
+
+// checkFilter resolves the taxon name of the request, then returns
+// ErrInvalidFilter for a taxon or place that does not exist, so sessions
+// never start on a filter no species can match.
+func (s *Service) checkFilter(ctx context.Context, req *StartSessionRequest) error {
+	switch {
+	case req.TaxonName != "" && req.Filter.TaxonID != 0:
+		return fmt.Errorf("%w: taxon name and taxon ID are exclusive", quiz.ErrInvalidFilter)
+	case req.TaxonName != "":
+		taxonID, err := s.findTaxon(ctx, req.TaxonName)
+		if err != nil {
+			return err
+		}
+		req.Filter.TaxonID = taxonID
+	case req.Filter.TaxonID > 0 && s.speciesRepo != nil:
+		taxa, err := s.speciesRepo.GetTaxa(ctx, []int{req.Filter.TaxonID})
+		if err != nil {
+			return fmt.Errorf("checking taxon: %w", err)
+		}
+		if len(taxa) == 0 {
+			return fmt.Errorf("%w: unknown taxon %d", quiz.ErrInvalidFilter, req.Filter.TaxonID)
+		}
+	}
+
+	if req.Filter.PlaceID > 0 && s.places != nil {
+		_, err := s.places.GetPlace(ctx, req.Filter.PlaceID)
+		if errors.Is(err, ports.ErrPlaceNotFound) {
+			return fmt.Errorf("%w: unknown place %d", quiz.ErrInvalidFilter, req.Filter.PlaceID)
+		}
+		if err != nil {
+			return fmt.Errorf("checking place: %w", err)
+		}
+	}
+	return nil
+}
+
+// findTaxon returns the ID of the taxon whose scientific or common name is
+// name, ignoring case.
+func (s *Service) findTaxon(ctx context.Context, name string) (int, error) {
+	if s.speciesRepo == nil {
+		return 0, fmt.Errorf("%w: taxon names are not supported", quiz.ErrInvalidFilter)
+	}
+	name = strings.TrimSpace(name)
+	taxa, err := s.speciesRepo.SearchTaxa(ctx, name, maxTaxonMatches)
+	if err != nil {
+		return 0, fmt.Errorf("searching taxon: %w", err)
+	}
+	for _, t := range taxa {
+		if strings.EqualFold(t.Name, name) || strings.EqualFold(t.CommonName, name) {
+			return t.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown taxon %q", quiz.ErrInvalidFilter, name)
+}
+
 // generateQuestions creates questions for the session.
 func (s *Service) generateQuestions(ctx context.Context, req StartSessionRequest) ([]*quiz.Question, error) {
 	questions := make([]*quiz.Question, 0, req.QuestionCount)
 
+	var lastErr error
 	for i := 0; i < req.QuestionCount; i++ {
 		quizType := req.QuizTypes[i%len(req.QuizTypes)]
-		question, err := s.questionFactory.CreateQuestion(ctx, quizType, req.Difficulty)
+		question, err := s.createQuestion(ctx, quizType, req)
 		if err != nil {
+			lastErr = err
 			continue
 		}
 		questions = append(questions, question)
 	}
 
 	if len(questions) == 0 {
-		return nil, errors.New("failed to generate any questions")
+		return nil, fmt.Errorf("failed to generate any questions: %w", lastErr)
 	}
 	return questions, nil
 }
 
+// createQuestion draws a question from the pool, or creates it when none is ready.
+func (s *Service) createQuestion(
+	ctx context.Context,
+	quizType quiz.QuizType,
+	req StartSessionRequest,
+) (*quiz.Question, error) {
+	if s.questionPool != nil {
+		key := PoolKey{QuizType: quizType, Difficulty: req.Difficulty, Filter: req.Filter}
+		if question, ok := s.questionPool.Take(key); ok {
+			return question, nil
+		}
+	}
+	return s.questionFactory.CreateQuestion(ctx, quizType, req.Difficulty, req.Filter)
+}
+
 // buildAndStartSession creates and starts a new session.
 func (s *Service) buildAndStartSession(req StartSessionRequest, questions []*quiz.Question) (*quiz.Session, error) {
 	session, err := quiz.NewSessionBuilder().
 		WithUserID(req.UserID).
 		WithDifficulty(req.Difficulty).
 		WithQuizTypes(req.QuizTypes...).
-		WithTaxonFilter(req.TaxonFilter).
+		WithFilter(req.Filter).
 		WithQuestions(questions).
 		Build()
 	if err != nil {
@@ -161,6 +318,18 @@ func (s *Service) saveSession(ctx context.Context, session *quiz.Session) error
 	return nil
 }
 
+// GetSession loads a session from the session repository.
+func (s *Service) GetSession(ctx context.Context, sessionID string) (*quiz.Session, error) {
+	if s.sessionRepo == nil {
+		return nil, errors.New("session repository not configured")
+	}
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("loading session: %w", err)
+	}
+	return session, nil
+}
+
 // SubmitAnswerRequest contains parameters for submitting an answer.
 type SubmitAnswerRequest struct {
 	SessionID string
@@ -174,6 +343,7 @@ type SubmitAnswerResponse struct {
 	Score            int
 	CorrectSpeciesID int
 	CorrectName      string
+	Credit           *quiz.MediaCredit // Attribution owed for the media of the answered question
 	CurrentStreak    int
 	NextQuestion     *quiz.Question
 	SessionComplete  bool
@@ -181,14 +351,15 @@ type SubmitAnswerResponse struct {
 	Accuracy         float64
 }
 
-// SubmitAnswer processes an answer submission.
-func (s *Service) SubmitAnswer(
-	ctx context.Context,
-	session *quiz.Session,
-	req SubmitAnswerRequest,
-) (*SubmitAnswerResponse, error) {
-	if session == nil {
-		return nil, errors.New("session is required")
+// SubmitAnswer processes an answer submission. Submissions to a session are
+// serialized from loading to saving it, so a session completes only once.
+func (s *Service) SubmitAnswer(ctx context.Context, req SubmitAnswerRequest) (*SubmitAnswerResponse, error) {
+	unlock := s.sessionLocks.Lock(req.SessionID)
+	defer unlock()
+
+	session, err := s.GetSession(ctx, req.SessionID)
+	if err != nil {
+		return nil, err
 	}
 
 	currentQuestion := session.CurrentQuestion()
@@ -222,7 +393,7 @@ func (s *Service) buildAnswerResponse(
 	question *quiz.Question,
 	answer *quiz.Answer,
 ) *SubmitAnswerResponse {
-	return &SubmitAnswerResponse{
+	response := &SubmitAnswerResponse{
 		IsCorrect:        answer.IsCorrect,
 		Score:            answer.Score,
 		CorrectSpeciesID: question.CorrectSpecies().ID(),
@@ -233,10 +404,18 @@ func (s *Service) buildAnswerResponse(
 		TotalScore:       session.TotalScore(),
 		Accuracy:         session.Accuracy(),
 	}
+	if credit, ok := question.Credit(); ok {
+		response.Credit = &credit
+	}
+	return response
 }
 
 // handleSessionComplete processes gamification when a session completes.
 func (s *Service) handleSessionComplete(ctx context.Context, session *quiz.Session) error {
+	// Players are read and written as copies: concurrent games must not overwrite each other
+	unlock := s.playerLocks.Lock(session.UserID())
+	defer unlock()
+
 	player, err := s.playerRepo.GetByID(ctx, session.UserID())
 	if err != nil {
 		return fmt.Errorf("getting player: %w", err)
@@ -250,6 +429,12 @@ func (s *Service) handleSessionComplete(ctx context.Context, session *quiz.Sessi
 		return fmt.Errorf("updating player: %w", err)
 	}
 
+	if s.sessionRecorder != nil {
+		if err := s.sessionRecorder.RecordSession(ctx, session); err != nil {
+			return fmt.Errorf("recording session: %w", err)
+		}
+	}
+
 	s.publishSessionCompleted(session, player)
 	return nil
 }
@@ -279,20 +464,15 @@ func (s *Service) processLevelUps(player *gamification.Player, xp int) {
 	}
 }
 
-// processAchievements handles achievement unlocks.
+// processAchievements handles achievement and tier unlocks.
 func (s *Service) processAchievements(_ context.Context, player *gamification.Player, session *quiz.Session) {
-	achievements := player.RecordGame(
-		session.CorrectCount(),
-		session.QuestionsCount(),
-		session.MaxStreak(),
-	)
+	unlocks := player.RecordGame(gamification.NewGameSummary(session))
 
-	for _, achievement := range achievements {
+	for _, unlock := range unlocks {
 		if s.eventPublisher != nil {
-			s.eventPublisher.PublishAchievementUnlocked(player, achievement)
+			s.eventPublisher.PublishAchievementUnlocked(player, unlock)
 		}
-		info := gamification.GetAchievementInfo(achievement)
-		player.AddXP(info.XPReward)
+		player.AddXP(unlock.XPReward)
 	}
 }
 
@@ -312,9 +492,13 @@ func (s *Service) GetSessionStats(ctx context.Context, userID string) (*ports.Us
 }
 
 // AbandonSession marks a session as abandoned.
-func (s *Service) AbandonSession(ctx context.Context, session *quiz.Session) error {
-	if session == nil {
-		return errors.New("session is required")
+func (s *Service) AbandonSession(ctx context.Context, sessionID string) error {
+	unlock := s.sessionLocks.Lock(sessionID)
+	defer unlock()
+
+	session, err := s.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
 	}
 
 	session.Abandon()
@@ -2,11 +2,16 @@ package quiz_test
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/Naturieux-fr/Naturieux.fr/internal/adapters/persistence/memory"
 	appquiz "github.com/Naturieux-fr/Naturieux.fr/internal/application/quiz"
 	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/place"
 	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
 	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
 	"github.com/Naturieux-fr/Naturieux.fr/internal/ports"
@@ -64,6 +69,7 @@ func (m *mockPlayerRepository) GetLeaderboard(ctx context.Context, limit int) ([
 type mockQuestionFactory struct {
 	questions []*quiz.Question
 	index     int
+	filters   []quiz.Filter // Filter of each created question
 }
 
 func newMockQuestionFactory() *mockQuestionFactory {
@@ -72,11 +78,24 @@ func newMockQuestionFactory() *mockQuestionFactory {
 	}
 }
 
-func (m *mockQuestionFactory) CreateQuestion(ctx context.Context, quizType quiz.QuizType, difficulty quiz.Difficulty) (*quiz.Question, error) {
+func (m *mockQuestionFactory) CreateQuestion(
+	ctx context.Context,
+	quizType quiz.QuizType,
+	difficulty quiz.Difficulty,
+	filter quiz.Filter,
+) (*quiz.Question, error) {
+	m.filters = append(m.filters, filter)
 	if m.index >= len(m.questions) {
 		// Create a default question
 		sp, _ := species.New(m.index+1, "Test Species", "Test Common", "Mammalia")
-		sp.AddPhoto(species.Photo{ID: 1, URL: "https://example.com/photo.jpg", MediumURL: "https://example.com/photo_medium.jpg"})
+		sp.AddPhoto(species.Photo{
+			ID:             1,
+			URL:            "https://example.com/photo.jpg",
+			MediumURL:      "https://example.com/photo_medium.jpg",
+			Attribution:    "(c) someone, some rights reserved (CC BY)",
+			LicenseCode:    "cc-by",
+			ObservationURL: "https://www.inaturalist.org/observations/7",
+		})
 
 		wrong, _ := species.New(m.index+100, "Wrong Species", "Wrong", "Mammalia")
 
@@ -86,6 +105,7 @@ func (m *mockQuestionFactory) CreateQuestion(ctx context.Context, quizType quiz.
 		}
 
 		q, _ := quiz.NewQuestion("q-default", quizType, difficulty, sp, choices, "https://example.com/img.jpg")
+		q.SetPhotoID(1)
 		m.index++
 		return q, nil
 	}
@@ -109,7 +129,7 @@ func (m *mockEventPublisher) PublishLevelUp(player *gamification.Player, event g
 	m.levelUpCount++
 }
 
-func (m *mockEventPublisher) PublishAchievementUnlocked(player *gamification.Player, achievement gamification.Achievement) {
+func (m *mockEventPublisher) PublishAchievementUnlocked(player *gamification.Player, unlock gamification.AchievementUnlock) {
 	m.achievementUnlockedCount++
 }
 
@@ -193,6 +213,170 @@ func TestService_StartSession_DefaultValues(t *testing.T) {
 	}
 }
 
+func TestService_StartSession_Filter(t *testing.T) {
+	tests := []struct {
+		name      string
+		homePlace int
+		filter    quiz.Filter
+		want      quiz.Filter
+		wantErr   bool
+	}{
+		{
+			name:   "default place",
+			filter: quiz.Filter{TaxonID: 47217, Months: quiz.Summer.Months()},
+			want:   quiz.Filter{TaxonID: 47217, PlaceID: 6753, Months: quiz.Summer.Months()},
+		},
+		{
+			name:      "home place",
+			homePlace: 6906,
+			filter:    quiz.Filter{IconicTaxon: "Aves"},
+			want:      quiz.Filter{IconicTaxon: "Aves", PlaceID: 6906},
+		},
+		{
+			name:      "session place over home place",
+			homePlace: 6906,
+			filter:    quiz.Filter{PlaceID: 1},
+			want:      quiz.Filter{PlaceID: 1},
+		},
+		{
+			name:   "session place",
+			filter: quiz.Filter{IconicTaxon: "Aves", PlaceID: 1},
+			want:   quiz.Filter{IconicTaxon: "Aves", PlaceID: 1},
+		},
+		{
+			name:   "rounded area without default place",
+			filter: quiz.Filter{Area: quiz.Area{Lat: 45.832622, Lng: 6.865174, RadiusKm: 5}},
+			want:   quiz.Filter{Area: quiz.Area{Lat: 45.83, Lng: 6.87, RadiusKm: 5}},
+		},
+		{name: "invalid", filter: quiz.Filter{IconicTaxon: "Dragons"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playerRepo := newMockPlayerRepository()
+			player, _ := gamification.NewPlayer("user1", "testuser")
+			player.SetHomePlace(tt.homePlace)
+			playerRepo.Create(context.Background(), player)
+			factory := newMockQuestionFactory()
+			sessionRepo := memory.NewSessionRepository()
+			service := appquiz.NewService(factory, sessionRepo, playerRepo, nil, appquiz.WithDefaultPlace(6753))
+
+			resp, err := service.StartSession(context.Background(), appquiz.StartSessionRequest{
+				UserID:        "user1",
+				Filter:        tt.filter,
+				QuestionCount: 2,
+			})
+			if tt.wantErr {
+				if !errors.Is(err, quiz.ErrInvalidFilter) {
+					t.Errorf("StartSession() error = %v, want ErrInvalidFilter", err)
+				}
+				if len(factory.filters) != 0 {
+					t.Errorf("factory created %d questions, want none", len(factory.filters))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("StartSession() error = %v", err)
+			}
+
+			for _, got := range factory.filters {
+				if got != tt.want {
+					t.Errorf("CreateQuestion() filter = %+v, want %+v", got, tt.want)
+				}
+			}
+			session, err := service.GetSession(context.Background(), resp.SessionID)
+			if err != nil {
+				t.Fatalf("GetSession() error = %v", err)
+			}
+			if session.Filter() != tt.want {
+				t.Errorf("session Filter() = %+v, want %+v", session.Filter(), tt.want)
+			}
+		})
+	}
+}
+
+// knownPlaces knows the Isere department only.
+type knownPlaces struct{}
+
+func (knownPlaces) SearchPlaces(_ context.Context, _ string, _ int) ([]place.Place, error) {
+	return []place.Place{{ID: 6906, Name: "Isère"}}, nil
+}
+
+func (knownPlaces) GetPlace(_ context.Context, id int) (*place.Place, error) {
+	if id != 6906 {
+		return nil, ports.ErrPlaceNotFound
+	}
+	return &place.Place{ID: 6906, Name: "Isère"}, nil
+}
+
+func TestService_StartSession_CheckedFilter(t *testing.T) {
+	orchids := species.Taxon{ID: 47217, Name: "Orchidaceae", CommonName: "Orchidées", Rank: "family"}
+	speciesRepo := &mockSpeciesRepository{
+		getTaxaFunc: func(_ context.Context, ids []int) ([]species.Taxon, error) {
+			if ids[0] != orchids.ID {
+				return []species.Taxon{}, nil
+			}
+			return []species.Taxon{orchids}, nil
+		},
+		searchTaxaFunc: func(_ context.Context, _ string, _ int) ([]species.Taxon, error) {
+			return []species.Taxon{{ID: 47218, Name: "Orchidales", Rank: "order"}, orchids}, nil
+		},
+	}
+
+	tests := []struct {
+		name      string
+		taxonName string
+		filter    quiz.Filter
+		want      quiz.Filter
+		wantErr   bool
+	}{
+		{name: "taxon name", taxonName: "orchidaceae", want: quiz.Filter{TaxonID: 47217, PlaceID: 6753}},
+		{name: "common name", taxonName: " Orchidées ", want: quiz.Filter{TaxonID: 47217, PlaceID: 6753}},
+		{name: "known taxon and place", filter: quiz.Filter{TaxonID: 47217, PlaceID: 6906},
+			want: quiz.Filter{TaxonID: 47217, PlaceID: 6906}},
+		{name: "unknown taxon name", taxonName: "Dragons", wantErr: true},
+		{name: "taxon name and ID", taxonName: "Orchidaceae", filter: quiz.Filter{TaxonID: 47217}, wantErr: true},
+		{name: "unknown taxon", filter: quiz.Filter{TaxonID: 999999}, wantErr: true},
+		{name: "unknown place", filter: quiz.Filter{PlaceID: 1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playerRepo := newMockPlayerRepository()
+			player, _ := gamification.NewPlayer("user1", "testuser")
+			playerRepo.Create(context.Background(), player)
+			factory := newMockQuestionFactory()
+			service := appquiz.NewService(factory, memory.NewSessionRepository(), playerRepo, nil,
+				appquiz.WithDefaultPlace(6753),
+				appquiz.WithSpecies(speciesRepo),
+				appquiz.WithPlaces(knownPlaces{}),
+			)
+
+			_, err := service.StartSession(context.Background(), appquiz.StartSessionRequest{
+				UserID:        "user1",
+				Filter:        tt.filter,
+				TaxonName:     tt.taxonName,
+				QuestionCount: 1,
+			})
+			if tt.wantErr {
+				if !errors.Is(err, quiz.ErrInvalidFilter) {
+					t.Errorf("StartSession() error = %v, want ErrInvalidFilter", err)
+				}
+				if len(factory.filters) != 0 {
+					t.Errorf("factory created %d questions, want none", len(factory.filters))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("StartSession() error = %v", err)
+			}
+			if len(factory.filters) != 1 || factory.filters[0] != tt.want {
+				t.Errorf("CreateQuestion() filters = %+v, want %+v", factory.filters, tt.want)
+			}
+		})
+	}
+}
+
 func TestService_SubmitAnswer(t *testing.T) {
 	playerRepo := newMockPlayerRepository()
 	player, _ := gamification.NewPlayer("user1", "testuser")
@@ -201,7 +385,8 @@ func TestService_SubmitAnswer(t *testing.T) {
 	factory := newMockQuestionFactory()
 	eventPub := &mockEventPublisher{}
 
-	service := appquiz.NewService(factory, nil, playerRepo, eventPub)
+	sessions := memory.NewSessionRepository()
+	service := appquiz.NewService(factory, sessions, playerRepo, eventPub)
 
 	// Start a session
 	req := appquiz.StartSessionRequest{
@@ -222,16 +407,17 @@ func TestService_SubmitAnswer(t *testing.T) {
 		WithQuestions([]*quiz.Question{startResp.FirstQuestion}).
 		Build()
 	session.Start()
+	sessions.Save(context.Background(), session)
 
 	// Submit correct answer
 	correctID := startResp.FirstQuestion.CorrectSpecies().ID()
 	submitReq := appquiz.SubmitAnswerRequest{
-		SessionID: startResp.SessionID,
+		SessionID: session.ID(),
 		SpeciesID: correctID,
 		TimeTaken: 5 * time.Second,
 	}
 
-	submitResp, err := service.SubmitAnswer(context.Background(), session, submitReq)
+	submitResp, err := service.SubmitAnswer(context.Background(), submitReq)
 	if err != nil {
 		t.Fatalf("SubmitAnswer() error = %v", err)
 	}
@@ -247,6 +433,11 @@ func TestService_SubmitAnswer(t *testing.T) {
 	if submitResp.CorrectName == "" {
 		t.Error("CorrectName should not be empty")
 	}
+
+	credit := submitResp.Credit
+	if credit == nil || credit.LicenseCode != "cc-by" || credit.Attribution == "" || credit.ObservationURL == "" {
+		t.Errorf("Credit = %+v, want the attribution, license and observation of the photo", credit)
+	}
 }
 
 func TestService_SubmitAnswer_Wrong(t *testing.T) {
@@ -255,7 +446,8 @@ func TestService_SubmitAnswer_Wrong(t *testing.T) {
 	playerRepo.Create(context.Background(), player)
 
 	factory := newMockQuestionFactory()
-	service := appquiz.NewService(factory, nil, playerRepo, nil)
+	sessions := memory.NewSessionRepository()
+	service := appquiz.NewService(factory, sessions, playerRepo, nil)
 
 	// Start session
 	req := appquiz.StartSessionRequest{
@@ -269,14 +461,16 @@ func TestService_SubmitAnswer_Wrong(t *testing.T) {
 		WithQuestions([]*quiz.Question{startResp.FirstQuestion}).
 		Build()
 	session.Start()
+	sessions.Save(context.Background(), session)
 
 	// Submit wrong answer
 	submitReq := appquiz.SubmitAnswerRequest{
+		SessionID: session.ID(),
 		SpeciesID: 99999, // Wrong ID
 		TimeTaken: 5 * time.Second,
 	}
 
-	submitResp, err := service.SubmitAnswer(context.Background(), session, submitReq)
+	submitResp, err := service.SubmitAnswer(context.Background(), submitReq)
 	if err != nil {
 		t.Fatalf("SubmitAnswer() error = %v", err)
 	}
@@ -297,7 +491,8 @@ func TestService_SubmitAnswer_SessionComplete(t *testing.T) {
 
 	factory := newMockQuestionFactory()
 	eventPub := &mockEventPublisher{}
-	service := appquiz.NewService(factory, nil, playerRepo, eventPub)
+	sessions := memory.NewSessionRepository()
+	service := appquiz.NewService(factory, sessions, playerRepo, eventPub)
 
 	// Start session with 1 question
 	req := appquiz.StartSessionRequest{
@@ -311,15 +506,17 @@ func TestService_SubmitAnswer_SessionComplete(t *testing.T) {
 		WithQuestions([]*quiz.Question{startResp.FirstQuestion}).
 		Build()
 	session.Start()
+	sessions.Save(context.Background(), session)
 
 	// Submit answer
 	correctID := startResp.FirstQuestion.CorrectSpecies().ID()
 	submitReq := appquiz.SubmitAnswerRequest{
+		SessionID: session.ID(),
 		SpeciesID: correctID,
 		TimeTaken: 5 * time.Second,
 	}
 
-	submitResp, err := service.SubmitAnswer(context.Background(), session, submitReq)
+	submitResp, err := service.SubmitAnswer(context.Background(), submitReq)
 	if err != nil {
 		t.Fatalf("SubmitAnswer() error = %v", err)
 	}
@@ -333,9 +530,132 @@ func TestService_SubmitAnswer_SessionComplete(t *testing.T) {
 	}
 }
 
+// slowPlayerRepository widens the window between reading and updating a player.
+type slowPlayerRepository struct {
+	*memory.PlayerRepository
+}
+
+func (r slowPlayerRepository) Update(ctx context.Context, player *gamification.Player) error {
+	time.Sleep(time.Millisecond)
+	return r.PlayerRepository.Update(ctx, player)
+}
+
+func TestService_SubmitAnswer_ConcurrentCompletions(t *testing.T) {
+	playerRepo := memory.NewPlayerRepository()
+	player, _ := gamification.NewPlayer("user1", "testuser")
+	playerRepo.Create(context.Background(), player)
+	service := appquiz.NewService(
+		newMockQuestionFactory(), memory.NewSessionRepository(), slowPlayerRepository{playerRepo}, nil)
+
+	const games = 20
+	started := make([]*appquiz.StartSessionResponse, games)
+	for i := range started {
+		var err error
+		started[i], err = service.StartSession(context.Background(), appquiz.StartSessionRequest{
+			UserID:        "user1",
+			QuestionCount: 1,
+		})
+		if err != nil {
+			t.Fatalf("StartSession() error = %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, resp := range started {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			service.SubmitAnswer(context.Background(), appquiz.SubmitAnswerRequest{
+				SessionID: resp.SessionID,
+				SpeciesID: resp.FirstQuestion.CorrectSpecies().ID(),
+				TimeTaken: 5 * time.Second,
+			})
+		}()
+	}
+	wg.Wait()
+
+	// Every completed game counts, none overwrites another
+	stored, _ := playerRepo.GetByID(context.Background(), "user1")
+	if stored.TotalGames() != games {
+		t.Errorf("TotalGames() = %d, want %d", stored.TotalGames(), games)
+	}
+}
+
+// slowSessionRepository widens the window between loading and saving a session.
+type slowSessionRepository struct {
+	*memory.SessionRepository
+}
+
+func (r slowSessionRepository) Save(ctx context.Context, session *quiz.Session) error {
+	time.Sleep(time.Millisecond)
+	return r.SessionRepository.Save(ctx, session)
+}
+
+func TestService_SubmitAnswer_ConcurrentFinalSubmits(t *testing.T) {
+	playerRepo := memory.NewPlayerRepository()
+	for _, id := range []string{"user1", "user2"} {
+		player, _ := gamification.NewPlayer(id, id)
+		playerRepo.Create(context.Background(), player)
+	}
+	recorder := &recordingSessionRecorder{}
+	service := appquiz.NewService(newMockQuestionFactory(), slowSessionRepository{memory.NewSessionRepository()},
+		slowPlayerRepository{playerRepo}, nil, appquiz.WithSessionRecorder(recorder))
+
+	finalSubmit := func(userID string) appquiz.SubmitAnswerRequest {
+		resp, err := service.StartSession(context.Background(), appquiz.StartSessionRequest{
+			UserID:        userID,
+			QuestionCount: 1,
+		})
+		if err != nil {
+			t.Fatalf("StartSession() error = %v", err)
+		}
+		return appquiz.SubmitAnswerRequest{
+			SessionID: resp.SessionID,
+			SpeciesID: resp.FirstQuestion.CorrectSpecies().ID(),
+			TimeTaken: 5 * time.Second,
+		}
+	}
+
+	// user2 completes the same game once, as the reference award
+	if _, err := service.SubmitAnswer(context.Background(), finalSubmit("user2")); err != nil {
+		t.Fatalf("SubmitAnswer() error = %v", err)
+	}
+	reference, _ := playerRepo.GetByID(context.Background(), "user2")
+
+	req := finalSubmit("user1")
+	const submits = 10
+	var wg sync.WaitGroup
+	var completions atomic.Int32
+	for range submits {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			answer, err := service.SubmitAnswer(context.Background(), req)
+			if err == nil && answer.SessionComplete {
+				completions.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The session completes once, so its XP is awarded once
+	if completions.Load() != 1 {
+		t.Errorf("completed %d times, want 1", completions.Load())
+	}
+	stored, _ := playerRepo.GetByID(context.Background(), "user1")
+	if stored.TotalGames() != 1 || stored.TotalXP() != reference.TotalXP() {
+		t.Errorf("TotalGames() = %d, TotalXP() = %d, want 1 game and %d XP",
+			stored.TotalGames(), stored.TotalXP(), reference.TotalXP())
+	}
+	if len(recorder.sessions) != 2 {
+		t.Errorf("recorded %d sessions, want 2", len(recorder.sessions))
+	}
+}
+
 func TestService_AbandonSession(t *testing.T) {
 	factory := newMockQuestionFactory()
-	service := appquiz.NewService(factory, nil, nil, nil)
+	sessions := memory.NewSessionRepository()
+	service := appquiz.NewService(factory, sessions, nil, nil)
 
 	sp, _ := species.New(1, "Test", "Test", "Mammalia")
 	sp.AddPhoto(species.Photo{ID: 1, URL: "https://example.com/photo.jpg"})
@@ -354,23 +674,25 @@ func TestService_AbandonSession(t *testing.T) {
 		WithQuestions([]*quiz.Question{q}).
 		Build()
 	session.Start()
+	sessions.Save(context.Background(), session)
 
-	err := service.AbandonSession(context.Background(), session)
+	err := service.AbandonSession(context.Background(), session.ID())
 	if err != nil {
 		t.Errorf("AbandonSession() error = %v", err)
 	}
 
-	if session.Status() != quiz.SessionAbandoned {
-		t.Errorf("Status = %v, want abandoned", session.Status())
+	stored, _ := service.GetSession(context.Background(), session.ID())
+	if stored.Status() != quiz.SessionAbandoned {
+		t.Errorf("Status = %v, want abandoned", stored.Status())
 	}
 }
 
-func TestService_AbandonSession_NilSession(t *testing.T) {
-	service := appquiz.NewService(nil, nil, nil, nil)
+func TestService_AbandonSession_UnknownSession(t *testing.T) {
+	service := appquiz.NewService(nil, memory.NewSessionRepository(), nil, nil)
 
-	err := service.AbandonSession(context.Background(), nil)
-	if err == nil {
-		t.Error("AbandonSession() should return error for nil session")
+	err := service.AbandonSession(context.Background(), "nonexistent")
+	if !errors.Is(err, ports.ErrSessionNotFound) {
+		t.Errorf("AbandonSession() error = %v, want ErrSessionNotFound", err)
 	}
 }
 
@@ -461,17 +783,18 @@ func TestService_GetSessionStats_NoRepository(t *testing.T) {
 	}
 }
 
-func TestService_SubmitAnswer_NilSession(t *testing.T) {
-	service := appquiz.NewService(nil, nil, nil, nil)
+func TestService_SubmitAnswer_UnknownSession(t *testing.T) {
+	service := appquiz.NewService(nil, memory.NewSessionRepository(), nil, nil)
 
 	req := appquiz.SubmitAnswerRequest{
+		SessionID: "nonexistent",
 		SpeciesID: 1,
 		TimeTaken: time.Second,
 	}
 
-	_, err := service.SubmitAnswer(context.Background(), nil, req)
-	if err == nil {
-		t.Error("SubmitAnswer() should return error for nil session")
+	_, err := service.SubmitAnswer(context.Background(), req)
+	if !errors.Is(err, ports.ErrSessionNotFound) {
+		t.Errorf("SubmitAnswer() error = %v, want ErrSessionNotFound", err)
 	}
 }
 
@@ -487,3 +810,159 @@ func TestService_StartSession_EmptyUserID(t *testing.T) {
 		t.Error("StartSession() should return error for empty user ID")
 	}
 }
+
+func TestService_GetSession_RoundTrip(t *testing.T) {
+	playerRepo := newMockPlayerRepository()
+	player, _ := gamification.NewPlayer("user1", "testuser")
+	playerRepo.Create(context.Background(), player)
+
+	service := appquiz.NewService(newMockQuestionFactory(), memory.NewSessionRepository(), playerRepo, nil)
+
+	startResp, err := service.StartSession(context.Background(), appquiz.StartSessionRequest{
+		UserID:        "user1",
+		QuestionCount: 2,
+	})
+	if err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+
+	_, err = service.GetSession(context.Background(), startResp.SessionID)
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+
+	submitResp, err := service.SubmitAnswer(context.Background(), appquiz.SubmitAnswerRequest{
+		SessionID: startResp.SessionID,
+		SpeciesID: startResp.FirstQuestion.CorrectSpecies().ID(),
+		TimeTaken: time.Second,
+	})
+	if err != nil {
+		t.Fatalf("SubmitAnswer() error = %v", err)
+	}
+	if submitResp.NextQuestion == nil {
+		t.Error("NextQuestion should be available for the second question")
+	}
+
+	reloaded, _ := service.GetSession(context.Background(), startResp.SessionID)
+	if reloaded.AnsweredCount() != 1 {
+		t.Errorf("AnsweredCount = %d, want 1 after reload", reloaded.AnsweredCount())
+	}
+}
+
+func TestService_GetSession_NotFound(t *testing.T) {
+	service := appquiz.NewService(nil, memory.NewSessionRepository(), nil, nil)
+
+	_, err := service.GetSession(context.Background(), "nonexistent")
+	if !errors.Is(err, ports.ErrSessionNotFound) {
+		t.Errorf("GetSession() error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+// recordingSessionRecorder collects the sessions it receives.
+type recordingSessionRecorder struct {
+	sessions []*quiz.Session
+}
+
+func (r *recordingSessionRecorder) RecordSession(_ context.Context, session *quiz.Session) error {
+	r.sessions = append(r.sessions, session)
+	return nil
+}
+
+func TestService_SubmitAnswer_RecordsCompletedSession(t *testing.T) {
+	playerRepo := newMockPlayerRepository()
+	player, _ := gamification.NewPlayer("user1", "testuser")
+	playerRepo.Create(context.Background(), player)
+
+	recorder := &recordingSessionRecorder{}
+	sessions := memory.NewSessionRepository()
+	service := appquiz.NewService(newMockQuestionFactory(), sessions, playerRepo, nil, appquiz.WithSessionRecorder(recorder))
+
+	startResp, _ := service.StartSession(context.Background(), appquiz.StartSessionRequest{
+		UserID:        "user1",
+		QuestionCount: 1,
+	})
+	session, _ := quiz.NewSessionBuilder().
+		WithUserID("user1").
+		WithQuestions([]*quiz.Question{startResp.FirstQuestion, startResp.FirstQuestion}).
+		Build()
+	session.Start()
+	sessions.Save(context.Background(), session)
+
+	submitReq := appquiz.SubmitAnswerRequest{
+		SessionID: session.ID(),
+		SpeciesID: startResp.FirstQuestion.CorrectSpecies().ID(),
+	}
+	service.SubmitAnswer(context.Background(), submitReq)
+	if len(recorder.sessions) != 0 {
+		t.Fatalf("recorded %d sessions before completion, want 0", len(recorder.sessions))
+	}
+
+	service.SubmitAnswer(context.Background(), submitReq)
+	if len(recorder.sessions) != 1 || recorder.sessions[0].ID() != session.ID() {
+		t.Errorf("recorded sessions = %v, want the completed session", recorder.sessions)
+	}
+}
+
+func TestService_SubmitAnswer_CountsCorrectIdentificationsByTaxon(t *testing.T) {
+	playerRepo := newMockPlayerRepository()
+	player, _ := gamification.NewPlayer("user1", "testuser")
+	playerRepo.Create(context.Background(), player)
+
+	sessions := memory.NewSessionRepository()
+	service := appquiz.NewService(newMockQuestionFactory(), sessions, playerRepo, nil)
+	startResp, _ := service.StartSession(context.Background(), appquiz.StartSessionRequest{
+		UserID:        "user1",
+		QuestionCount: 1,
+	})
+	question := startResp.FirstQuestion
+	session, _ := quiz.NewSessionBuilder().
+		WithUserID("user1").
+		WithQuestions([]*quiz.Question{question, question, question}).
+		Build()
+	session.Start()
+	sessions.Save(context.Background(), session)
+
+	for _, speciesID := range []int{question.CorrectSpecies().ID(), 99999, question.CorrectSpecies().ID()} {
+		service.SubmitAnswer(context.Background(), appquiz.SubmitAnswerRequest{SessionID: session.ID(), SpeciesID: speciesID})
+	}
+
+	if got := player.CorrectIdentifications("Mammalia"); got != 2 {
+		t.Errorf("CorrectIdentifications(Mammalia) = %d, want 2", got)
+	}
+}
+
+func TestService_SubmitAnswer_UnlocksDifficultyAchievements(t *testing.T) {
+	playerRepo := newMockPlayerRepository()
+	player, _ := gamification.NewPlayer("user1", "testuser")
+	playerRepo.Create(context.Background(), player)
+
+	sessions := memory.NewSessionRepository()
+	service := appquiz.NewService(newMockQuestionFactory(), sessions, playerRepo, nil)
+	startResp, _ := service.StartSession(context.Background(), appquiz.StartSessionRequest{
+		UserID:        "user1",
+		Difficulty:    quiz.Master,
+		QuestionCount: 1,
+	})
+	session, _ := quiz.NewSessionBuilder().
+		WithUserID("user1").
+		WithDifficulty(quiz.Master).
+		WithQuestions([]*quiz.Question{startResp.FirstQuestion}).
+		Build()
+	session.Start()
+	sessions.Save(context.Background(), session)
+
+	submitReq := appquiz.SubmitAnswerRequest{
+		SessionID: session.ID(),
+		SpeciesID: startResp.FirstQuestion.CorrectSpecies().ID(),
+	}
+	if _, err := service.SubmitAnswer(context.Background(), submitReq); err != nil {
+		t.Fatalf("SubmitAnswer() error = %v", err)
+	}
+
+	if !player.HasAchievement(gamification.MasterNatural) {
+		t.Error("a perfect master quiz should unlock master_natural")
+	}
+	if player.HasAchievement(gamification.ExpertMode) {
+		t.Error("a master quiz should not unlock expert_mode")
+	}
+}
@@ -3,6 +3,7 @@ package gamification
 // Achievement represents an unlockable achievement.
 type Achievement string
 
+// Achievements of the default catalog, see achievements.json.
 const (
 	// Game count achievements
 	FirstGame Achievement = "first_game" // Complete first game
@@ -17,17 +18,45 @@ const (
 	LevelTen   Achievement = "level_ten"   // Reach level 10
 	LevelFifty Achievement = "level_fifty" // Reach level 50
 
-	// Category achievements
-	MammalExpert Achievement = "mammal_expert" // 100 correct mammal identifications
-	BirdWatcher  Achievement = "bird_watcher"  // 100 correct bird identifications
-	BugHunter    Achievement = "bug_hunter"    // 100 correct insect identifications
-	Botanist     Achievement = "botanist"      // 100 correct plant identifications
+	// Category achievements, tiered at 10/100/1000 correct identifications
+	MammalExpert Achievement = "mammal_expert" // Correct mammal identifications
+	BirdWatcher  Achievement = "bird_watcher"  // Correct bird identifications
+	BugHunter    Achievement = "bug_hunter"    // Correct insect identifications
+	Botanist     Achievement = "botanist"      // Correct plant identifications
 
 	// Difficulty achievements
 	ExpertMode    Achievement = "expert_mode"    // Complete an expert quiz
 	MasterNatural Achievement = "master_natural" // Complete a master quiz with 80%+
 )
 
+// AllAchievements returns every achievement of the active catalog in display order.
+func AllAchievements() []Achievement {
+	definitions := ActiveCatalog().Definitions()
+	achievements := make([]Achievement, len(definitions))
+	for i, d := range definitions {
+		achievements[i] = d.ID
+	}
+	return achievements
+}
+
+// Tier is a level of a tiered achievement.
+type Tier string
+
+// Achievement tiers, from lowest to highest.
+const (
+	TierBronze Tier = "bronze"
+	TierSilver Tier = "silver"
+	TierGold   Tier = "gold"
+)
+
+// tierOrder lists the tiers from lowest to highest.
+var tierOrder = []Tier{TierBronze, TierSilver, TierGold}
+
+// Tiers returns every tier from lowest to highest.
+func Tiers() []Tier {
+	return append([]Tier(nil), tierOrder...)
+}
+
 // AchievementInfo contains display information for an achievement.
 type AchievementInfo struct {
 	ID          Achievement
@@ -35,106 +64,27 @@ type AchievementInfo struct {
 	Description string
 	Icon        string
 	XPReward    int
+	Tiers       []TierInfo // Empty for single-level achievements
+}
+
+// TierInfo describes one tier of a tiered achievement.
+type TierInfo struct {
+	Tier     Tier
+	Target   int
+	XPReward int
+}
+
+// AchievementUnlock is an achievement, or one of its tiers, newly reached by a player.
+type AchievementUnlock struct {
+	Achievement Achievement
+	Tier        Tier // Empty for single-level achievements
+	XPReward    int
 }
 
 // GetAchievementInfo returns display info for an achievement.
 func GetAchievementInfo(a Achievement) AchievementInfo {
-	info := map[Achievement]AchievementInfo{
-		FirstGame: {
-			ID:          FirstGame,
-			Name:        "Premier Pas",
-			Description: "Completez votre premiere partie",
-			Icon:        "🎮",
-			XPReward:    50,
-		},
-		Veteran: {
-			ID:          Veteran,
-			Name:        "Veteran",
-			Description: "Completez 100 parties",
-			Icon:        "🏆",
-			XPReward:    500,
-		},
-		Dedicated: {
-			ID:          Dedicated,
-			Name:        "Dedie",
-			Description: "Jouez 7 jours consecutifs",
-			Icon:        "📅",
-			XPReward:    200,
-		},
-		PerfectScore: {
-			ID:          PerfectScore,
-			Name:        "Sans Faute",
-			Description: "Obtenez 100% sur au moins 10 questions",
-			Icon:        "💯",
-			XPReward:    300,
-		},
-		StreakMaster: {
-			ID:          StreakMaster,
-			Name:        "Serie Parfaite",
-			Description: "10 bonnes reponses consecutives",
-			Icon:        "🔥",
-			XPReward:    150,
-		},
-		LevelTen: {
-			ID:          LevelTen,
-			Name:        "Naturaliste",
-			Description: "Atteignez le niveau 10",
-			Icon:        "🌿",
-			XPReward:    100,
-		},
-		LevelFifty: {
-			ID:          LevelFifty,
-			Name:        "Expert Nature",
-			Description: "Atteignez le niveau 50",
-			Icon:        "🌳",
-			XPReward:    1000,
-		},
-		MammalExpert: {
-			ID:          MammalExpert,
-			Name:        "Expert Mammiferes",
-			Description: "Identifiez 100 mammiferes correctement",
-			Icon:        "🦊",
-			XPReward:    250,
-		},
-		BirdWatcher: {
-			ID:          BirdWatcher,
-			Name:        "Ornithologue",
-			Description: "Identifiez 100 oiseaux correctement",
-			Icon:        "🦅",
-			XPReward:    250,
-		},
-		BugHunter: {
-			ID:          BugHunter,
-			Name:        "Entomologiste",
-			Description: "Identifiez 100 insectes correctement",
-			Icon:        "🦋",
-			XPReward:    250,
-		},
-		Botanist: {
-			ID:          Botanist,
-			Name:        "Botaniste",
-			Description: "Identifiez 100 plantes correctement",
-			Icon:        "🌸",
-			XPReward:    250,
-		},
-		ExpertMode: {
-			ID:          ExpertMode,
-			Name:        "Mode Expert",
-			Description: "Completez un quiz en difficulte Expert",
-			Icon:        "⭐",
-			XPReward:    200,
-		},
-		MasterNatural: {
-			ID:          MasterNatural,
-			Name:        "Maitre Naturaliste",
-			Description: "Completez un quiz Maitre avec 80%+",
-			Icon:        "👑",
-			XPReward:    500,
-		},
-	}
-
-	if i, ok := info[a]; ok {
-		return i
+	if d, ok := ActiveCatalog().Get(a); ok {
+		return d.Info()
 	}
 	return AchievementInfo{ID: a, Name: string(a)}
 }
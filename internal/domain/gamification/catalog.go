@@ -0,0 +1,227 @@
+package gamification
+
+import (
+	"bytes"
+	_ "embed" // Default achievement catalog
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// catalogVersion is the achievement catalog format this package reads.
+const catalogVersion = 1
+
+// ErrInvalidCatalog is returned when an achievement catalog cannot be used.
+var ErrInvalidCatalog = errors.New("invalid achievement catalog")
+
+//go:embed achievements.json
+var defaultCatalogJSON []byte
+
+// AchievementDefinition describes an achievement and its unlock condition.
+// A tiered achievement has a ">=" threshold condition without a value; each
+// tier sets the value and rewards reaching it.
+type AchievementDefinition struct {
+	ID          Achievement      `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Icon        string           `json:"icon"`
+	XPReward    int              `json:"xp_reward,omitempty"`
+	Condition   Condition        `json:"condition"`
+	Tiers       []TierDefinition `json:"tiers,omitempty"`
+}
+
+// TierDefinition sets the threshold and reward of one achievement tier.
+type TierDefinition struct {
+	Tier     Tier `json:"tier"`
+	Value    int  `json:"value"`
+	XPReward int  `json:"xp_reward"`
+}
+
+// Info returns the display information of the achievement.
+func (d AchievementDefinition) Info() AchievementInfo {
+	info := AchievementInfo{
+		ID:          d.ID,
+		Name:        d.Name,
+		Description: d.Description,
+		Icon:        d.Icon,
+		XPReward:    d.XPReward,
+	}
+	for _, t := range d.Tiers {
+		info.Tiers = append(info.Tiers, TierInfo{Tier: t.Tier, Target: t.Value, XPReward: t.XPReward})
+	}
+	return info
+}
+
+// validate checks the definition's display fields, condition and tiers.
+func (d AchievementDefinition) validate() error {
+	if d.Name == "" {
+		return errors.New("name is required")
+	}
+	if d.XPReward < 0 {
+		return errors.New("xp_reward must not be negative")
+	}
+	if err := d.Condition.Validate(); err != nil {
+		return err
+	}
+	if d.Tiers != nil {
+		return d.validateTiers()
+	}
+	return nil
+}
+
+// validateTiers checks that tiers follow bronze, silver, gold with rising values and rewards.
+func (d AchievementDefinition) validateTiers() error {
+	if len(d.Tiers) == 0 || len(d.Tiers) > len(tierOrder) {
+		return fmt.Errorf("between 1 and %d tiers are required", len(tierOrder))
+	}
+	if d.Condition.Metric == "" || d.Condition.Op != OpGreaterOrEqual || d.Condition.Value != 0 {
+		return errors.New(`tiers require a ">=" threshold condition without a value`)
+	}
+	if d.XPReward != 0 {
+		return errors.New("xp_reward is set per tier")
+	}
+	for i, t := range d.Tiers {
+		if t.Tier != tierOrder[i] {
+			return fmt.Errorf("tier %d is %q, want %q", i, t.Tier, tierOrder[i])
+		}
+		if t.Value <= 0 || t.XPReward < 0 {
+			return fmt.Errorf("tier %s needs a positive value and a non-negative xp_reward", t.Tier)
+		}
+		if i > 0 && (t.Value <= d.Tiers[i-1].Value || t.XPReward <= d.Tiers[i-1].XPReward) {
+			return fmt.Errorf("tier %s must raise the value and xp_reward of tier %s", t.Tier, d.Tiers[i-1].Tier)
+		}
+	}
+	return nil
+}
+
+// tierCondition returns the condition of a tier of the achievement.
+func (d AchievementDefinition) tierCondition(i int) Condition {
+	c := d.Condition
+	c.Value = float64(d.Tiers[i].Value)
+	return c
+}
+
+// tierIndex returns the position of a tier in the achievement, -1 when absent.
+func (d AchievementDefinition) tierIndex(tier Tier) int {
+	for i, t := range d.Tiers {
+		if t.Tier == tier {
+			return i
+		}
+	}
+	return -1
+}
+
+// reachedTiers counts the tiers whose threshold a player meets after a game.
+func (d AchievementDefinition) reachedTiers(p *Player, game GameSummary) int {
+	reached := 0
+	for i := range d.Tiers {
+		if !d.tierCondition(i).evaluate(p, game) {
+			break
+		}
+		reached++
+	}
+	return reached
+}
+
+// Catalog is a validated, ordered set of achievement definitions.
+type Catalog struct {
+	definitions []AchievementDefinition
+	byID        map[Achievement]int
+}
+
+// catalogFile is the JSON layout of an achievement catalog.
+type catalogFile struct {
+	Version      int                     `json:"version"`
+	Achievements []AchievementDefinition `json:"achievements"`
+}
+
+// ParseCatalog decodes and validates a JSON achievement catalog.
+func ParseCatalog(data []byte) (*Catalog, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var file catalogFile
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCatalog, err)
+	}
+	if file.Version != catalogVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidCatalog, file.Version)
+	}
+
+	catalog := &Catalog{
+		definitions: file.Achievements,
+		byID:        make(map[Achievement]int, len(file.Achievements)),
+	}
+	for i, d := range file.Achievements {
+		if d.ID == "" {
+			return nil, fmt.Errorf("%w: achievement %d has no id", ErrInvalidCatalog, i)
+		}
+		if _, ok := catalog.byID[d.ID]; ok {
+			return nil, fmt.Errorf("%w: duplicate achievement %s", ErrInvalidCatalog, d.ID)
+		}
+		if err := d.validate(); err != nil {
+			return nil, fmt.Errorf("%w: achievement %s: %w", ErrInvalidCatalog, d.ID, err)
+		}
+		catalog.byID[d.ID] = i
+	}
+	return catalog, nil
+}
+
+// LoadCatalog reads and validates an achievement catalog file.
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading achievement catalog: %w", err)
+	}
+	return ParseCatalog(data)
+}
+
+// defaultCatalog parses the embedded catalog once.
+var defaultCatalog = sync.OnceValue(func() *Catalog {
+	catalog, err := ParseCatalog(defaultCatalogJSON)
+	if err != nil {
+		panic(fmt.Sprintf("embedded achievement catalog: %v", err))
+	}
+	return catalog
+})
+
+// DefaultCatalog returns the achievement catalog shipped with the game.
+func DefaultCatalog() *Catalog {
+	return defaultCatalog()
+}
+
+// activeCatalog is the catalog used to unlock and describe achievements.
+var activeCatalog atomic.Pointer[Catalog]
+
+// UseCatalog sets the catalog used to unlock and describe achievements.
+// It is meant to be called once at startup; nil restores the default catalog.
+func UseCatalog(c *Catalog) {
+	activeCatalog.Store(c)
+}
+
+// ActiveCatalog returns the catalog in use.
+func ActiveCatalog() *Catalog {
+	if c := activeCatalog.Load(); c != nil {
+		return c
+	}
+	return DefaultCatalog()
+}
+
+// Definitions returns the achievement definitions in display order.
+func (c *Catalog) Definitions() []AchievementDefinition {
+	definitions := make([]AchievementDefinition, len(c.definitions))
+	copy(definitions, c.definitions)
+	return definitions
+}
+
+// Get returns the definition of an achievement.
+func (c *Catalog) Get(a Achievement) (AchievementDefinition, bool) {
+	i, ok := c.byID[a]
+	if !ok {
+		return AchievementDefinition{}, false
+	}
+	return c.definitions[i], true
+}
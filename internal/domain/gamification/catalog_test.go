@@ -0,0 +1,199 @@
+package gamification_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+)
+
+func TestDefaultCatalog(t *testing.T) {
+	want := map[gamification.Achievement]int{
+		gamification.FirstGame:     50,
+		gamification.Veteran:       500,
+		gamification.Dedicated:     200,
+		gamification.PerfectScore:  300,
+		gamification.StreakMaster:  150,
+		gamification.LevelTen:      100,
+		gamification.LevelFifty:    1000,
+		gamification.MammalExpert:  0, // Tiered
+		gamification.BirdWatcher:   0,
+		gamification.BugHunter:     0,
+		gamification.Botanist:      0,
+		gamification.ExpertMode:    200,
+		gamification.MasterNatural: 500,
+	}
+
+	definitions := gamification.DefaultCatalog().Definitions()
+	if len(definitions) != len(want) {
+		t.Fatalf("Definitions() len = %d, want %d", len(definitions), len(want))
+	}
+	for _, d := range definitions {
+		if d.XPReward != want[d.ID] {
+			t.Errorf("%s XPReward = %d, want %d", d.ID, d.XPReward, want[d.ID])
+		}
+		if d.Name == "" || d.Description == "" || d.Icon == "" {
+			t.Errorf("%s is missing display info", d.ID)
+		}
+	}
+
+	wantTiers := []gamification.TierInfo{
+		{Tier: gamification.TierBronze, Target: 10, XPReward: 50},
+		{Tier: gamification.TierSilver, Target: 100, XPReward: 250},
+		{Tier: gamification.TierGold, Target: 1000, XPReward: 1000},
+	}
+	if got := gamification.GetAchievementInfo(gamification.Botanist).Tiers; !slices.Equal(got, wantTiers) {
+		t.Errorf("botanist Tiers = %+v, want %+v", got, wantTiers)
+	}
+}
+
+func TestDefaultCatalog_Thresholds(t *testing.T) {
+	tests := []struct {
+		name        string
+		achievement gamification.Achievement
+		below       gamification.GameSummary
+		at          gamification.GameSummary
+	}{
+		{
+			name:        "streak master at 10",
+			achievement: gamification.StreakMaster,
+			below:       gamification.GameSummary{Correct: 9, Total: 10, MaxStreak: 9},
+			at:          gamification.GameSummary{Correct: 10, Total: 10, MaxStreak: 10},
+		},
+		{
+			name:        "perfect score needs 10 questions",
+			achievement: gamification.PerfectScore,
+			below:       gamification.GameSummary{Correct: 9, Total: 9, MaxStreak: 9},
+			at:          gamification.GameSummary{Correct: 10, Total: 10, MaxStreak: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			below, _ := gamification.NewPlayer("p1", "below")
+			below.RecordGame(tt.below)
+			if below.HasAchievement(tt.achievement) {
+				t.Errorf("%s unlocked below its threshold", tt.achievement)
+			}
+
+			at, _ := gamification.NewPlayer("p2", "at")
+			at.RecordGame(tt.at)
+			if !at.HasAchievement(tt.achievement) {
+				t.Errorf("%s locked at its threshold", tt.achievement)
+			}
+		})
+	}
+}
+
+func TestParseCatalog_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"malformed", `{`},
+		{"unknown field", `{"version": 1, "achievements": [], "extra": true}`},
+		{"unsupported version", `{"version": 2, "achievements": []}`},
+		{"missing id", `{"version": 1, "achievements": [
+			{"name": "A", "condition": {"metric": "level", "op": ">=", "value": 1}}]}`},
+		{"missing name", `{"version": 1, "achievements": [
+			{"id": "a", "condition": {"metric": "level", "op": ">=", "value": 1}}]}`},
+		{"negative reward", `{"version": 1, "achievements": [
+			{"id": "a", "name": "A", "xp_reward": -1, "condition": {"metric": "level", "op": ">=", "value": 1}}]}`},
+		{"duplicate id", `{"version": 1, "achievements": [
+			{"id": "a", "name": "A", "condition": {"metric": "level", "op": ">=", "value": 1}},
+			{"id": "a", "name": "B", "condition": {"metric": "level", "op": ">=", "value": 2}}]}`},
+		{"missing condition", `{"version": 1, "achievements": [{"id": "a", "name": "A"}]}`},
+		{"unknown metric", `{"version": 1, "achievements": [
+			{"id": "a", "name": "A", "condition": {"metric": "karma", "op": ">=", "value": 1}}]}`},
+		{"unknown operator", `{"version": 1, "achievements": [
+			{"id": "a", "name": "A", "condition": {"metric": "level", "op": "!=", "value": 1}}]}`},
+		{"negative value", `{"version": 1, "achievements": [
+			{"id": "a", "name": "A", "condition": {"metric": "level", "op": ">=", "value": -1}}]}`},
+		{"taxon metric without taxon", `{"version": 1, "achievements": [
+			{"id": "a", "name": "A", "condition": {"metric": "correct_by_taxon", "op": ">=", "value": 1}}]}`},
+		{"taxon on counter", `{"version": 1, "achievements": [
+			{"id": "a", "name": "A", "condition": {"metric": "level", "taxon": "Aves", "op": ">=", "value": 1}}]}`},
+		{"unknown taxon", `{"version": 1, "achievements": [{"id": "a", "name": "A",
+			"condition": {"metric": "correct_by_taxon", "taxon": "Dragons", "op": ">=", "value": 1}}]}`},
+		{"unknown difficulty", `{"version": 1, "achievements": [
+			{"id": "a", "name": "A", "condition": {"difficulty": "legendary"}}]}`},
+		{"two forms", `{"version": 1, "achievements": [{"id": "a", "name": "A",
+			"condition": {"difficulty": "expert", "metric": "level", "op": ">=", "value": 1}}]}`},
+		{"empty all", `{"version": 1, "achievements": [{"id": "a", "name": "A", "condition": {"all": []}}]}`},
+		{"invalid nested", `{"version": 1, "achievements": [
+			{"id": "a", "name": "A", "condition": {"any": [{"difficulty": "expert"}, {"metric": "karma"}]}}]}`},
+		{"empty tiers", `{"version": 1, "achievements": [
+			{"id": "a", "name": "A", "condition": {"metric": "level", "op": ">="}, "tiers": []}]}`},
+		{"tiers on combination", `{"version": 1, "achievements": [{"id": "a", "name": "A",
+			"condition": {"all": [{"metric": "level", "op": ">="}]},
+			"tiers": [{"tier": "bronze", "value": 1, "xp_reward": 1}]}]}`},
+		{"tiers with condition value", `{"version": 1, "achievements": [{"id": "a", "name": "A",
+			"condition": {"metric": "level", "op": ">=", "value": 5},
+			"tiers": [{"tier": "bronze", "value": 1, "xp_reward": 1}]}]}`},
+		{"tiers with xp reward", `{"version": 1, "achievements": [{"id": "a", "name": "A", "xp_reward": 5,
+			"condition": {"metric": "level", "op": ">="},
+			"tiers": [{"tier": "bronze", "value": 1, "xp_reward": 1}]}]}`},
+		{"tiers out of order", `{"version": 1, "achievements": [{"id": "a", "name": "A",
+			"condition": {"metric": "level", "op": ">="},
+			"tiers": [{"tier": "silver", "value": 1, "xp_reward": 1}]}]}`},
+		{"tier values not rising", `{"version": 1, "achievements": [{"id": "a", "name": "A",
+			"condition": {"metric": "level", "op": ">="},
+			"tiers": [{"tier": "bronze", "value": 5, "xp_reward": 1}, {"tier": "silver", "value": 5, "xp_reward": 2}]}]}`},
+		{"tier rewards not rising", `{"version": 1, "achievements": [{"id": "a", "name": "A",
+			"condition": {"metric": "level", "op": ">="},
+			"tiers": [{"tier": "bronze", "value": 5, "xp_reward": 2}, {"tier": "silver", "value": 6, "xp_reward": 2}]}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := gamification.ParseCatalog([]byte(tt.json)); !errors.Is(err, gamification.ErrInvalidCatalog) {
+				t.Errorf("ParseCatalog() error = %v, want ErrInvalidCatalog", err)
+			}
+		})
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "achievements.json")
+	content := `{"version": 1, "achievements": [
+		{"id": "owl_night", "name": "Nuit des Chouettes", "icon": "🦉", "xp_reward": 75,
+		 "condition": {"any": [
+			{"metric": "game_correct_by_taxon", "taxon": "Aves", "op": ">=", "value": 5},
+			{"all": [{"difficulty": "master"}, {"metric": "game_max_streak", "op": ">", "value": 3}]}
+		 ]}}
+	]}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	catalog, err := gamification.LoadCatalog(path)
+	if err != nil {
+		t.Fatalf("LoadCatalog() error = %v", err)
+	}
+	gamification.UseCatalog(catalog)
+	t.Cleanup(func() { gamification.UseCatalog(nil) })
+
+	if got := gamification.AllAchievements(); len(got) != 1 || got[0] != "owl_night" {
+		t.Fatalf("AllAchievements() = %v, want [owl_night]", got)
+	}
+	if info := gamification.GetAchievementInfo("owl_night"); info.XPReward != 75 || info.Icon != "🦉" {
+		t.Errorf("GetAchievementInfo() = %+v", info)
+	}
+
+	p, _ := gamification.NewPlayer("p1", "naturelover")
+	if unlocked := p.RecordGame(taxonGame(map[string]int{"Aves": 4})); len(unlocked) != 0 {
+		t.Errorf("RecordGame() = %v, want nothing unlocked", unlocked)
+	}
+	game := gamification.GameSummary{Difficulty: quiz.Master, Correct: 4, Total: 5, MaxStreak: 4}
+	if unlocked := p.RecordGame(game); len(unlocked) != 1 || unlocked[0].Achievement != "owl_night" {
+		t.Errorf("RecordGame() = %v, want [owl_night]", unlocked)
+	}
+
+	if _, err := gamification.LoadCatalog(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadCatalog() should fail on a missing file")
+	}
+}
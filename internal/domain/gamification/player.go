@@ -3,10 +3,14 @@ package gamification
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"time"
 )
 
+// maxLevel is the highest level a player can reach.
+const maxLevel = 100
+
 // Player represents a game player with progression.
 type Player struct {
 	id             string
@@ -17,9 +21,12 @@ type Player struct {
 	totalCorrect   int
 	totalQuestions int
 	bestStreak     int
+	correctByTaxon map[string]int
 	achievements   []Achievement
+	tiers          map[Achievement]Tier
 	dailyStreak    int
 	lastPlayedAt   *time.Time
+	homePlaceID    int
 	createdAt      time.Time
 }
 
@@ -33,11 +40,13 @@ func NewPlayer(id, username string) (*Player, error) {
 	}
 
 	return &Player{
-		id:           id,
-		username:     username,
-		level:        1,
-		achievements: make([]Achievement, 0),
-		createdAt:    time.Now(),
+		id:             id,
+		username:       username,
+		level:          1,
+		correctByTaxon: make(map[string]int),
+		achievements:   make([]Achievement, 0),
+		tiers:          make(map[Achievement]Tier),
+		createdAt:      time.Now(),
 	}, nil
 }
 
@@ -79,6 +88,20 @@ func (p *Player) BestStreak() int {
 	return p.bestStreak
 }
 
+// CorrectIdentifications returns the correct identifications of an iconic taxon.
+func (p *Player) CorrectIdentifications(iconicTaxon string) int {
+	return p.correctByTaxon[iconicTaxon]
+}
+
+// CorrectByTaxon returns correct identifications per iconic taxon.
+func (p *Player) CorrectByTaxon() map[string]int {
+	counts := make(map[string]int, len(p.correctByTaxon))
+	for taxon, n := range p.correctByTaxon {
+		counts[taxon] = n
+	}
+	return counts
+}
+
 // DailyStreak returns consecutive days played.
 func (p *Player) DailyStreak() int {
 	return p.dailyStreak
@@ -89,6 +112,45 @@ func (p *Player) Achievements() []Achievement {
 	return p.achievements
 }
 
+// HasAchievement checks if the achievement is unlocked.
+func (p *Player) HasAchievement(a Achievement) bool {
+	return p.hasAchievement(a)
+}
+
+// AchievementTier returns the highest tier reached of a tiered achievement,
+// empty when none is.
+func (p *Player) AchievementTier(a Achievement) Tier {
+	return p.tiers[a]
+}
+
+// AchievementTiers returns the highest tier reached per tiered achievement.
+func (p *Player) AchievementTiers() map[Achievement]Tier {
+	tiers := make(map[Achievement]Tier, len(p.tiers))
+	for a, t := range p.tiers {
+		tiers[a] = t
+	}
+	return tiers
+}
+
+// HomePlaceID returns the iNaturalist place sessions default to, 0 when unset.
+func (p *Player) HomePlaceID() int {
+	return p.homePlaceID
+}
+
+// SetHomePlace sets the place sessions default to. 0 unsets it.
+func (p *Player) SetHomePlace(placeID int) error {
+	if placeID < 0 {
+		return fmt.Errorf("invalid home place ID %d", placeID)
+	}
+	p.homePlaceID = placeID
+	return nil
+}
+
+// CreatedAt returns when the player was created.
+func (p *Player) CreatedAt() time.Time {
+	return p.createdAt
+}
+
 // XPForLevel calculates XP required for a given level.
 func XPForLevel(level int) int {
 	// Exponential growth: 100 * 1.5^(level-1)
@@ -141,7 +203,7 @@ func (p *Player) AddXP(xp int) []LevelUpEvent {
 			break
 		}
 		// Cap at level 100
-		if p.level >= 100 {
+		if p.level >= maxLevel {
 			break
 		}
 	}
@@ -149,14 +211,17 @@ func (p *Player) AddXP(xp int) []LevelUpEvent {
 	return events
 }
 
-// RecordGame records a completed game session.
-func (p *Player) RecordGame(correct, total, maxStreak int) []Achievement {
+// RecordGame records a completed game and returns newly unlocked achievements and tiers.
+func (p *Player) RecordGame(summary GameSummary) []AchievementUnlock {
 	p.totalGames++
-	p.totalCorrect += correct
-	p.totalQuestions += total
+	p.totalCorrect += summary.Correct
+	p.totalQuestions += summary.Total
 
-	if maxStreak > p.bestStreak {
-		p.bestStreak = maxStreak
+	if summary.MaxStreak > p.bestStreak {
+		p.bestStreak = summary.MaxStreak
+	}
+	for taxon, n := range summary.correctByTaxon() {
+		p.correctByTaxon[taxon] += n
 	}
 
 	// Update daily streak
@@ -174,33 +239,52 @@ func (p *Player) RecordGame(correct, total, maxStreak int) []Achievement {
 	p.lastPlayedAt = &now
 
 	// Check for new achievements
-	return p.checkAchievements()
+	return p.checkAchievements(summary)
 }
 
-func (p *Player) checkAchievements() []Achievement {
-	newAchievements := make([]Achievement, 0)
+// checkAchievements unlocks the active catalog's achievements whose condition now holds.
+func (p *Player) checkAchievements(game GameSummary) []AchievementUnlock {
+	unlocks := make([]AchievementUnlock, 0)
 
-	achievementChecks := []struct {
-		achievement Achievement
-		condition   func() bool
-	}{
-		{FirstGame, func() bool { return p.totalGames >= 1 }},
-		{Veteran, func() bool { return p.totalGames >= 100 }},
-		{StreakMaster, func() bool { return p.bestStreak >= 10 }},
-		{PerfectScore, func() bool { return p.Accuracy() == 100 && p.totalQuestions >= 10 }},
-		{Dedicated, func() bool { return p.dailyStreak >= 7 }},
-		{LevelTen, func() bool { return p.level >= 10 }},
-		{LevelFifty, func() bool { return p.level >= 50 }},
+	for _, d := range ActiveCatalog().definitions {
+		if len(d.Tiers) > 0 {
+			unlocks = append(unlocks, p.checkTiers(d, game)...)
+			continue
+		}
+		if !p.hasAchievement(d.ID) && d.Condition.evaluate(p, game) {
+			p.achievements = append(p.achievements, d.ID)
+			unlocks = append(unlocks, AchievementUnlock{Achievement: d.ID, XPReward: d.XPReward})
+		}
 	}
 
-	for _, check := range achievementChecks {
-		if check.condition() && !p.hasAchievement(check.achievement) {
-			p.achievements = append(p.achievements, check.achievement)
-			newAchievements = append(newAchievements, check.achievement)
+	return unlocks
+}
+
+// checkTiers unlocks the tiers of an achievement the player has newly reached.
+func (p *Player) checkTiers(d AchievementDefinition, game GameSummary) []AchievementUnlock {
+	reached := d.reachedTiers(p, game)
+	if p.hasAchievement(d.ID) && p.tiers[d.ID] == "" {
+		// Unlocked before the achievement had tiers: record the tier without rewarding it again
+		if reached > 0 {
+			p.tiers[d.ID] = d.Tiers[reached-1].Tier
 		}
+		return nil
 	}
 
-	return newAchievements
+	current := d.tierIndex(p.tiers[d.ID]) + 1
+	if reached <= current {
+		return nil
+	}
+
+	unlocks := make([]AchievementUnlock, 0, reached-current)
+	for _, t := range d.Tiers[current:reached] {
+		unlocks = append(unlocks, AchievementUnlock{Achievement: d.ID, Tier: t.Tier, XPReward: t.XPReward})
+	}
+	if !p.hasAchievement(d.ID) {
+		p.achievements = append(p.achievements, d.ID)
+	}
+	p.tiers[d.ID] = d.Tiers[reached-1].Tier
+	return unlocks
 }
 
 func (p *Player) hasAchievement(a Achievement) bool {
@@ -1,9 +1,13 @@
 package gamification_test
 
 import (
+	"errors"
+	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
 )
 
 func TestNewPlayer(t *testing.T) {
@@ -98,7 +102,7 @@ func TestPlayer_AddXP_MultipleLevelUps(t *testing.T) {
 func TestPlayer_RecordGame(t *testing.T) {
 	p, _ := gamification.NewPlayer("p1", "test")
 
-	achievements := p.RecordGame(8, 10, 5)
+	achievements := p.RecordGame(gamification.GameSummary{Correct: 8, Total: 10, MaxStreak: 5})
 
 	if p.TotalGames() != 1 {
 		t.Errorf("TotalGames = %d, want 1", p.TotalGames())
@@ -107,7 +111,7 @@ func TestPlayer_RecordGame(t *testing.T) {
 	// Should unlock FirstGame achievement
 	hasFirstGame := false
 	for _, a := range achievements {
-		if a == gamification.FirstGame {
+		if a.Achievement == gamification.FirstGame {
 			hasFirstGame = true
 			break
 		}
@@ -125,17 +129,17 @@ func TestPlayer_RecordGame(t *testing.T) {
 func TestPlayer_BestStreak(t *testing.T) {
 	p, _ := gamification.NewPlayer("p1", "test")
 
-	p.RecordGame(5, 10, 5)
+	p.RecordGame(gamification.GameSummary{Correct: 5, Total: 10, MaxStreak: 5})
 	if p.BestStreak() != 5 {
 		t.Errorf("BestStreak = %d, want 5", p.BestStreak())
 	}
 
-	p.RecordGame(3, 10, 3) // Lower streak
+	p.RecordGame(gamification.GameSummary{Correct: 3, Total: 10, MaxStreak: 3}) // Lower streak
 	if p.BestStreak() != 5 {
 		t.Errorf("BestStreak = %d, want 5 (should keep max)", p.BestStreak())
 	}
 
-	p.RecordGame(8, 10, 8) // Higher streak
+	p.RecordGame(gamification.GameSummary{Correct: 8, Total: 10, MaxStreak: 8}) // Higher streak
 	if p.BestStreak() != 8 {
 		t.Errorf("BestStreak = %d, want 8", p.BestStreak())
 	}
@@ -145,11 +149,11 @@ func TestPlayer_StreakMasterAchievement(t *testing.T) {
 	p, _ := gamification.NewPlayer("p1", "test")
 
 	// Record game with 10+ streak
-	achievements := p.RecordGame(10, 10, 10)
+	achievements := p.RecordGame(gamification.GameSummary{Correct: 10, Total: 10, MaxStreak: 10})
 
 	hasStreakMaster := false
 	for _, a := range achievements {
-		if a == gamification.StreakMaster {
+		if a.Achievement == gamification.StreakMaster {
 			hasStreakMaster = true
 			break
 		}
@@ -208,3 +212,264 @@ func TestGetAchievementInfo(t *testing.T) {
 		t.Error("Achievement XP reward should be positive")
 	}
 }
+
+func TestPlayer_CategoryAchievements(t *testing.T) {
+	tests := []struct {
+		achievement gamification.Achievement
+		taxon       string
+	}{
+		{gamification.MammalExpert, "Mammalia"},
+		{gamification.BirdWatcher, "Aves"},
+		{gamification.BugHunter, "Insecta"},
+		{gamification.Botanist, "Plantae"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.achievement), func(t *testing.T) {
+			p, _ := gamification.NewPlayer("p1", "naturelover")
+
+			unlocked := p.RecordGame(taxonGame(map[string]int{tt.taxon: 63, "Fungi": 200}))
+			want := gamification.AchievementUnlock{Achievement: tt.achievement, Tier: gamification.TierBronze, XPReward: 50}
+			if !slices.Contains(unlocked, want) {
+				t.Errorf("RecordGame() = %v, want %+v", unlocked, want)
+			}
+			if p.AchievementTier(tt.achievement) != gamification.TierBronze {
+				t.Fatalf("AchievementTier() = %q at 63 identifications, want bronze", p.AchievementTier(tt.achievement))
+			}
+
+			unlocked = p.RecordGame(taxonGame(map[string]int{tt.taxon: 37}))
+			want = gamification.AchievementUnlock{Achievement: tt.achievement, Tier: gamification.TierSilver, XPReward: 250}
+			if !slices.Contains(unlocked, want) {
+				t.Errorf("RecordGame() = %v, want %+v", unlocked, want)
+			}
+			if p.CorrectIdentifications(tt.taxon) != 100 {
+				t.Errorf("CorrectIdentifications() = %d, want 100", p.CorrectIdentifications(tt.taxon))
+			}
+			if !p.HasAchievement(tt.achievement) {
+				t.Errorf("%s should be unlocked", tt.achievement)
+			}
+		})
+	}
+}
+
+func TestPlayer_TieredAchievements_SkipTiers(t *testing.T) {
+	p, _ := gamification.NewPlayer("p1", "naturelover")
+
+	unlocked := p.RecordGame(taxonGame(map[string]int{"Aves": 1000}))
+	var tiers []gamification.Tier
+	xp := 0
+	for _, u := range unlocked {
+		if u.Achievement == gamification.BirdWatcher {
+			tiers = append(tiers, u.Tier)
+			xp += u.XPReward
+		}
+	}
+	if !slices.Equal(tiers, gamification.Tiers()) || xp != 1300 {
+		t.Errorf("RecordGame() bird_watcher tiers = %v (%d XP), want every tier for 1300 XP", tiers, xp)
+	}
+
+	unlocked = p.RecordGame(taxonGame(map[string]int{"Aves": 1}))
+	if containsAchievement(unlocked, gamification.BirdWatcher) {
+		t.Errorf("RecordGame() = %v, want no tier after gold", unlocked)
+	}
+}
+
+func TestPlayer_TieredAchievements_LegacyUnlock(t *testing.T) {
+	p, _ := gamification.NewPlayer("p1", "naturelover")
+	p.RecordGame(taxonGame(map[string]int{"Aves": 150}))
+
+	// A player who unlocked bird_watcher before it had tiers
+	snap := p.Snapshot()
+	snap.AchievementTiers = nil
+	legacy, err := gamification.RestorePlayer(snap)
+	if err != nil {
+		t.Fatalf("RestorePlayer() error = %v", err)
+	}
+
+	unlocked := legacy.RecordGame(taxonGame(map[string]int{"Aves": 1}))
+	if containsAchievement(unlocked, gamification.BirdWatcher) {
+		t.Errorf("RecordGame() = %v, want already earned tiers not rewarded again", unlocked)
+	}
+	if legacy.AchievementTier(gamification.BirdWatcher) != gamification.TierSilver {
+		t.Errorf("AchievementTier() = %q, want silver", legacy.AchievementTier(gamification.BirdWatcher))
+	}
+}
+
+func TestPlayer_TieredAchievements_LegacyUnlockBelowFirstTier(t *testing.T) {
+	p, _ := gamification.NewPlayer("p1", "naturelover")
+	p.RecordGame(taxonGame(map[string]int{"Aves": 3}))
+
+	// A player who unlocked bird_watcher under a lower threshold than bronze
+	snap := p.Snapshot()
+	snap.Achievements = append(snap.Achievements, gamification.BirdWatcher)
+	legacy, err := gamification.RestorePlayer(snap)
+	if err != nil {
+		t.Fatalf("RestorePlayer() error = %v", err)
+	}
+
+	legacy.RecordGame(taxonGame(map[string]int{"Aves": 1}))
+	if tier := legacy.AchievementTier(gamification.BirdWatcher); tier != "" {
+		t.Errorf("AchievementTier() = %q, want none below bronze", tier)
+	}
+}
+
+func TestPlayer_AchievementProgress(t *testing.T) {
+	p, _ := gamification.NewPlayer("p1", "naturelover")
+	p.RecordGame(taxonGame(map[string]int{"Aves": 63, "Mammalia": 1500, "": 3}))
+	p.RecordGame(gamification.GameSummary{Difficulty: quiz.Master, Correct: 2, Total: 10, MaxStreak: 2})
+
+	tests := []struct {
+		achievement gamification.Achievement
+		want        gamification.Progress
+		wantOK      bool
+	}{
+		{gamification.BirdWatcher, gamification.Progress{
+			Metric: gamification.MetricCorrectByTaxon, Taxon: "Aves", Current: 63, Target: 100}, true},
+		{gamification.MammalExpert, gamification.Progress{
+			Metric: gamification.MetricCorrectByTaxon, Taxon: "Mammalia", Current: 1000, Target: 1000}, true},
+		{gamification.Botanist, gamification.Progress{
+			Metric: gamification.MetricCorrectByTaxon, Taxon: "Plantae", Current: 0, Target: 10}, true},
+		{gamification.Veteran, gamification.Progress{
+			Metric: gamification.MetricTotalGames, Current: 2, Target: 100}, true},
+		{gamification.Dedicated, gamification.Progress{
+			Metric: gamification.MetricDailyStreak, Current: 1, Target: 7}, true},
+		{gamification.StreakMaster, gamification.Progress{
+			Metric: gamification.MetricBestStreak, Current: 2, Target: 10}, true},
+		{gamification.PerfectScore, gamification.Progress{}, false},
+		{gamification.MasterNatural, gamification.Progress{}, false},
+		{"unknown", gamification.Progress{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.achievement), func(t *testing.T) {
+			got, ok := p.AchievementProgress(tt.achievement)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("AchievementProgress() = %+v (%v), want %+v (%v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPlayer_DifficultyAchievements(t *testing.T) {
+	expert, master := gamification.ExpertMode, gamification.MasterNatural
+
+	tests := []struct {
+		name       string
+		difficulty quiz.Difficulty
+		correct    int
+		want       []gamification.Achievement
+		notWant    []gamification.Achievement
+	}{
+		{"beginner", quiz.Beginner, 10, nil, []gamification.Achievement{expert, master}},
+		{"expert", quiz.Expert, 2, []gamification.Achievement{expert}, []gamification.Achievement{master}},
+		{"master at 80%", quiz.Master, 8, []gamification.Achievement{master}, nil},
+		{"master below 80%", quiz.Master, 7, nil, []gamification.Achievement{master}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := gamification.NewPlayer("p1", "naturelover")
+			unlocked := p.RecordGame(gamification.GameSummary{Difficulty: tt.difficulty, Correct: tt.correct, Total: 10})
+
+			for _, a := range tt.want {
+				if !containsAchievement(unlocked, a) {
+					t.Errorf("RecordGame() = %v, want %s unlocked", unlocked, a)
+				}
+			}
+			for _, a := range tt.notWant {
+				if p.HasAchievement(a) {
+					t.Errorf("%s should stay locked", a)
+				}
+			}
+		})
+	}
+}
+
+// taxonGame returns a summary of a game with the given correct answers per iconic taxon.
+func taxonGame(correctByTaxon map[string]int) gamification.GameSummary {
+	game := gamification.GameSummary{Difficulty: quiz.Beginner}
+	for taxon, n := range correctByTaxon {
+		for i := 0; i < n; i++ {
+			game.Answers = append(game.Answers, gamification.AnswerSummary{IconicTaxon: taxon, Correct: true})
+		}
+		game.Correct += n
+		game.Total += n
+	}
+	return game
+}
+
+func containsAchievement(list []gamification.AchievementUnlock, a gamification.Achievement) bool {
+	for _, got := range list {
+		if got.Achievement == a {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPlayer_SnapshotRoundTrip(t *testing.T) {
+	p, _ := gamification.NewPlayer("p1", "naturelover")
+	p.AddXP(500)
+	p.RecordGame(taxonGame(map[string]int{"Aves": 5, "Plantae": 2}))
+	p.SetHomePlace(6906)
+
+	restored, err := gamification.RestorePlayer(p.Snapshot())
+	if err != nil {
+		t.Fatalf("RestorePlayer() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(restored.Snapshot(), p.Snapshot()) {
+		t.Errorf("RestorePlayer() = %+v, want %+v", restored.Snapshot(), p.Snapshot())
+	}
+}
+
+func TestPlayer_SetHomePlace(t *testing.T) {
+	p, _ := gamification.NewPlayer("p1", "naturelover")
+
+	if err := p.SetHomePlace(6906); err != nil || p.HomePlaceID() != 6906 {
+		t.Fatalf("SetHomePlace(6906) error = %v, HomePlaceID() = %d", err, p.HomePlaceID())
+	}
+	if err := p.SetHomePlace(-1); err == nil || p.HomePlaceID() != 6906 {
+		t.Errorf("SetHomePlace(-1) error = %v, HomePlaceID() = %d, want an error and no change", err, p.HomePlaceID())
+	}
+	if err := p.SetHomePlace(0); err != nil || p.HomePlaceID() != 0 {
+		t.Errorf("SetHomePlace(0) error = %v, HomePlaceID() = %d, want it unset", err, p.HomePlaceID())
+	}
+}
+
+func TestRestorePlayer_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(s *gamification.PlayerSnapshot)
+	}{
+		{"unsupported version", func(s *gamification.PlayerSnapshot) { s.Version = 0 }},
+		{"missing username", func(s *gamification.PlayerSnapshot) { s.Username = "" }},
+		{"negative xp", func(s *gamification.PlayerSnapshot) { s.TotalXP = -1 }},
+		{"negative home place", func(s *gamification.PlayerSnapshot) { s.HomePlaceID = -1 }},
+		{"more correct than questions", func(s *gamification.PlayerSnapshot) { s.TotalCorrect = 11 }},
+		{"level mismatch", func(s *gamification.PlayerSnapshot) { s.Level = 7 }},
+		{"negative taxon count", func(s *gamification.PlayerSnapshot) { s.CorrectByTaxon = map[string]int{"Aves": -1} }},
+		{"taxon counts exceed correct", func(s *gamification.PlayerSnapshot) {
+			s.CorrectByTaxon = map[string]int{"Aves": 9}
+		}},
+		{"tier of locked achievement", func(s *gamification.PlayerSnapshot) {
+			s.AchievementTiers = map[gamification.Achievement]gamification.Tier{gamification.Botanist: "bronze"}
+		}},
+		{"unknown tier", func(s *gamification.PlayerSnapshot) {
+			s.AchievementTiers = map[gamification.Achievement]gamification.Tier{gamification.FirstGame: "platinum"}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := gamification.NewPlayer("p1", "naturelover")
+			p.RecordGame(gamification.GameSummary{Correct: 8, Total: 10, MaxStreak: 5})
+			snap := p.Snapshot()
+			tt.mutate(&snap)
+
+			if _, err := gamification.RestorePlayer(snap); !errors.Is(err, gamification.ErrInvalidSnapshot) {
+				t.Errorf("RestorePlayer() error = %v, want ErrInvalidSnapshot", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package gamification
+
+import "math"
+
+// Progress is a player's count towards an achievement, or its next tier.
+type Progress struct {
+	Metric  Metric
+	Taxon   string // Set for per-taxon metrics
+	Current int    // Capped at Target
+	Target  int
+}
+
+// ratio returns how far the progress is towards its target.
+func (p Progress) ratio() float64 {
+	if p.Target == 0 {
+		return 1
+	}
+	return float64(p.Current) / float64(p.Target)
+}
+
+// AchievementProgress returns a player's progress towards an achievement of the
+// active catalog: its next tier for tiered achievements, the last one once all
+// are reached. ok is false for achievements not counted over player metrics,
+// such as game difficulty or a single game's accuracy.
+func (p *Player) AchievementProgress(a Achievement) (Progress, bool) {
+	d, ok := ActiveCatalog().Get(a)
+	if !ok {
+		return Progress{}, false
+	}
+	if len(d.Tiers) == 0 {
+		return d.Condition.progress(p)
+	}
+
+	next := min(d.tierIndex(p.tiers[a])+1, len(d.Tiers)-1)
+	return d.tierCondition(next).progress(p)
+}
+
+// progress returns a player's progress towards the condition. Only ">="
+// thresholds over player metrics, and combinations of them, have one: an "all"
+// reports its least advanced condition and an "any" its most advanced one.
+func (c Condition) progress(p *Player) (Progress, bool) {
+	switch {
+	case c.Metric != "":
+		if c.Op != OpGreaterOrEqual || gameMetrics[c.Metric] {
+			return Progress{}, false
+		}
+		target := int(math.Ceil(c.Value))
+		current := int(metricReaders[c.Metric](p, GameSummary{}, c.Taxon))
+		return Progress{Metric: c.Metric, Taxon: c.Taxon, Current: min(current, target), Target: target}, true
+	case c.All != nil:
+		return combinedProgress(p, c.All, func(a, b Progress) bool { return a.ratio() < b.ratio() })
+	case c.Any != nil:
+		return combinedProgress(p, c.Any, func(a, b Progress) bool { return a.ratio() > b.ratio() })
+	default:
+		return Progress{}, false
+	}
+}
+
+// combinedProgress picks the progress preferred by better among conditions that all have one.
+func combinedProgress(p *Player, conditions []Condition, better func(a, b Progress) bool) (Progress, bool) {
+	var picked Progress
+	for i, c := range conditions {
+		progress, ok := c.progress(p)
+		if !ok {
+			return Progress{}, false
+		}
+		if i == 0 || better(progress, picked) {
+			picked = progress
+		}
+	}
+	return picked, len(conditions) > 0
+}
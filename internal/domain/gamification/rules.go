@@ -0,0 +1,220 @@
+package gamification
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+)
+
+// ErrInvalidCondition is returned when an achievement condition is malformed.
+var ErrInvalidCondition = errors.New("invalid achievement condition")
+
+// Metric identifies a player or game value an achievement condition reads.
+type Metric string
+
+const (
+	// Player metrics, accumulated over every game
+	MetricTotalGames     Metric = "total_games"
+	MetricTotalCorrect   Metric = "total_correct"
+	MetricTotalQuestions Metric = "total_questions"
+	MetricAccuracy       Metric = "accuracy"
+	MetricBestStreak     Metric = "best_streak"
+	MetricDailyStreak    Metric = "daily_streak"
+	MetricLevel          Metric = "level"
+	MetricTotalXP        Metric = "total_xp"
+	MetricCorrectByTaxon Metric = "correct_by_taxon" // Requires a taxon
+
+	// Game metrics, read from the game just completed
+	MetricGameCorrect        Metric = "game_correct"
+	MetricGameTotal          Metric = "game_total"
+	MetricGameAccuracy       Metric = "game_accuracy"
+	MetricGameMaxStreak      Metric = "game_max_streak"
+	MetricGameDuration       Metric = "game_duration_seconds"
+	MetricGameCorrectByTaxon Metric = "game_correct_by_taxon" // Requires a taxon
+)
+
+// metricReaders reads each metric from a player and the game just completed.
+var metricReaders = map[Metric]func(p *Player, game GameSummary, taxon string) float64{
+	MetricTotalGames:     func(p *Player, _ GameSummary, _ string) float64 { return float64(p.totalGames) },
+	MetricTotalCorrect:   func(p *Player, _ GameSummary, _ string) float64 { return float64(p.totalCorrect) },
+	MetricTotalQuestions: func(p *Player, _ GameSummary, _ string) float64 { return float64(p.totalQuestions) },
+	MetricAccuracy:       func(p *Player, _ GameSummary, _ string) float64 { return p.Accuracy() },
+	MetricBestStreak:     func(p *Player, _ GameSummary, _ string) float64 { return float64(p.bestStreak) },
+	MetricDailyStreak:    func(p *Player, _ GameSummary, _ string) float64 { return float64(p.dailyStreak) },
+	MetricLevel:          func(p *Player, _ GameSummary, _ string) float64 { return float64(p.level) },
+	MetricTotalXP:        func(p *Player, _ GameSummary, _ string) float64 { return float64(p.totalXP) },
+	MetricCorrectByTaxon: func(p *Player, _ GameSummary, t string) float64 { return float64(p.correctByTaxon[t]) },
+
+	MetricGameCorrect:   func(_ *Player, g GameSummary, _ string) float64 { return float64(g.Correct) },
+	MetricGameTotal:     func(_ *Player, g GameSummary, _ string) float64 { return float64(g.Total) },
+	MetricGameAccuracy:  func(_ *Player, g GameSummary, _ string) float64 { return g.Accuracy() },
+	MetricGameMaxStreak: func(_ *Player, g GameSummary, _ string) float64 { return float64(g.MaxStreak) },
+	MetricGameDuration:  func(_ *Player, g GameSummary, _ string) float64 { return g.Duration.Seconds() },
+	MetricGameCorrectByTaxon: func(_ *Player, g GameSummary, t string) float64 {
+		return float64(g.correctByTaxon()[t])
+	},
+}
+
+// taxonMetrics lists the metrics counted per iconic taxon.
+var taxonMetrics = map[Metric]bool{
+	MetricCorrectByTaxon:     true,
+	MetricGameCorrectByTaxon: true,
+}
+
+// gameMetrics lists the metrics read from the game just completed.
+var gameMetrics = map[Metric]bool{
+	MetricGameCorrect:        true,
+	MetricGameTotal:          true,
+	MetricGameAccuracy:       true,
+	MetricGameMaxStreak:      true,
+	MetricGameDuration:       true,
+	MetricGameCorrectByTaxon: true,
+}
+
+// Operator compares a metric to a condition's value.
+type Operator string
+
+const (
+	OpGreaterOrEqual Operator = ">="
+	OpGreater        Operator = ">"
+	OpEqual          Operator = "=="
+	OpLessOrEqual    Operator = "<="
+	OpLess           Operator = "<"
+)
+
+// isValid reports whether the operator is supported.
+func (o Operator) isValid() bool {
+	switch o {
+	case OpGreaterOrEqual, OpGreater, OpEqual, OpLessOrEqual, OpLess:
+		return true
+	default:
+		return false
+	}
+}
+
+// compare applies the operator to a metric value and a threshold.
+func (o Operator) compare(got, want float64) bool {
+	switch o {
+	case OpGreaterOrEqual:
+		return got >= want
+	case OpGreater:
+		return got > want
+	case OpEqual:
+		return got == want
+	case OpLessOrEqual:
+		return got <= want
+	case OpLess:
+		return got < want
+	default:
+		return false
+	}
+}
+
+// Condition is an achievement unlock rule. Exactly one form is set:
+//   - a threshold: {"metric": "best_streak", "op": ">=", "value": 10}, with
+//     "taxon" for per-taxon metrics;
+//   - a difficulty: {"difficulty": "expert"}, matching the game just completed;
+//   - a combination: {"all": [...]} or {"any": [...]}.
+type Condition struct {
+	Metric     Metric          `json:"metric,omitempty"`
+	Op         Operator        `json:"op,omitempty"`
+	Value      float64         `json:"value,omitempty"`
+	Taxon      string          `json:"taxon,omitempty"`
+	Difficulty quiz.Difficulty `json:"difficulty,omitempty"`
+	All        []Condition     `json:"all,omitempty"`
+	Any        []Condition     `json:"any,omitempty"`
+}
+
+// Validate checks that the condition and its children are well formed.
+func (c Condition) Validate() error {
+	forms := 0
+	if c.Metric != "" {
+		forms++
+	}
+	if c.Difficulty != "" {
+		forms++
+	}
+	if c.All != nil {
+		forms++
+	}
+	if c.Any != nil {
+		forms++
+	}
+	if forms != 1 {
+		return fmt.Errorf("%w: exactly one of metric, difficulty, all or any is required", ErrInvalidCondition)
+	}
+
+	switch {
+	case c.Metric != "":
+		return c.validateThreshold()
+	case c.Difficulty != "":
+		if !quiz.IsValidDifficulty(c.Difficulty) {
+			return fmt.Errorf("%w: unknown difficulty %q", ErrInvalidCondition, c.Difficulty)
+		}
+		return nil
+	case c.All != nil:
+		return validateConditions("all", c.All)
+	default:
+		return validateConditions("any", c.Any)
+	}
+}
+
+// validateThreshold checks a metric comparison.
+func (c Condition) validateThreshold() error {
+	if _, ok := metricReaders[c.Metric]; !ok {
+		return fmt.Errorf("%w: unknown metric %q", ErrInvalidCondition, c.Metric)
+	}
+	if !c.Op.isValid() {
+		return fmt.Errorf("%w: unknown operator %q", ErrInvalidCondition, c.Op)
+	}
+	if c.Value < 0 {
+		return fmt.Errorf("%w: negative value for %s", ErrInvalidCondition, c.Metric)
+	}
+	if taxonMetrics[c.Metric] != (c.Taxon != "") {
+		return fmt.Errorf("%w: taxon is required by and only allowed with per-taxon metrics", ErrInvalidCondition)
+	}
+	if c.Taxon != "" && !species.IsValidIconicTaxon(c.Taxon) {
+		return fmt.Errorf("%w: unknown taxon %q", ErrInvalidCondition, c.Taxon)
+	}
+	return nil
+}
+
+// validateConditions checks the children of an all/any condition.
+func validateConditions(form string, conditions []Condition) error {
+	if len(conditions) == 0 {
+		return fmt.Errorf("%w: %s requires at least one condition", ErrInvalidCondition, form)
+	}
+	for i, child := range conditions {
+		if err := child.Validate(); err != nil {
+			return fmt.Errorf("%s[%d]: %w", form, i, err)
+		}
+	}
+	return nil
+}
+
+// evaluate reports whether the condition holds for a player after a game.
+func (c Condition) evaluate(p *Player, game GameSummary) bool {
+	switch {
+	case c.Metric != "":
+		read, ok := metricReaders[c.Metric]
+		return ok && c.Op.compare(read(p, game, c.Taxon), c.Value)
+	case c.Difficulty != "":
+		return game.Difficulty == c.Difficulty
+	case c.All != nil:
+		for _, child := range c.All {
+			if !child.evaluate(p, game) {
+				return false
+			}
+		}
+		return true
+	default:
+		for _, child := range c.Any {
+			if child.evaluate(p, game) {
+				return true
+			}
+		}
+		return false
+	}
+}
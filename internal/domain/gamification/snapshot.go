@@ -0,0 +1,161 @@
+package gamification
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// PlayerSnapshotVersion is the current schema version of PlayerSnapshot.
+const PlayerSnapshotVersion = 1
+
+// ErrInvalidSnapshot is returned when a snapshot cannot be restored.
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// PlayerSnapshot is a serializable representation of a Player.
+type PlayerSnapshot struct {
+	Version        int            `json:"version"`
+	ID             string         `json:"id"`
+	Username       string         `json:"username"`
+	TotalXP        int            `json:"total_xp"`
+	Level          int            `json:"level"`
+	TotalGames     int            `json:"total_games"`
+	TotalCorrect   int            `json:"total_correct"`
+	TotalQuestions int            `json:"total_questions"`
+	BestStreak     int            `json:"best_streak"`
+	CorrectByTaxon map[string]int `json:"correct_by_taxon,omitempty"`
+	Achievements   []Achievement  `json:"achievements"`
+	DailyStreak    int            `json:"daily_streak"`
+	LastPlayedAt   *time.Time     `json:"last_played_at,omitempty"`
+	HomePlaceID    int            `json:"home_place_id,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+
+	AchievementTiers map[Achievement]Tier `json:"achievement_tiers,omitempty"`
+}
+
+// Snapshot captures the full state of the player.
+func (p *Player) Snapshot() PlayerSnapshot {
+	var lastPlayedAt *time.Time
+	if p.lastPlayedAt != nil {
+		t := *p.lastPlayedAt
+		lastPlayedAt = &t
+	}
+
+	return PlayerSnapshot{
+		Version:        PlayerSnapshotVersion,
+		ID:             p.id,
+		Username:       p.username,
+		TotalXP:        p.totalXP,
+		Level:          p.level,
+		TotalGames:     p.totalGames,
+		TotalCorrect:   p.totalCorrect,
+		TotalQuestions: p.totalQuestions,
+		BestStreak:     p.bestStreak,
+		CorrectByTaxon: p.CorrectByTaxon(),
+		Achievements:   append([]Achievement(nil), p.achievements...),
+		DailyStreak:    p.dailyStreak,
+		LastPlayedAt:   lastPlayedAt,
+		HomePlaceID:    p.homePlaceID,
+		CreatedAt:      p.createdAt,
+
+		AchievementTiers: p.AchievementTiers(),
+	}
+}
+
+// RestorePlayer rebuilds a Player from a snapshot.
+func RestorePlayer(snap PlayerSnapshot) (*Player, error) {
+	if snap.Version != PlayerSnapshotVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, snap.Version)
+	}
+
+	p, err := NewPlayer(snap.ID, snap.Username)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+
+	if snap.TotalXP < 0 || snap.TotalGames < 0 || snap.BestStreak < 0 || snap.DailyStreak < 0 ||
+		snap.HomePlaceID < 0 {
+		return nil, fmt.Errorf("%w: counters must not be negative", ErrInvalidSnapshot)
+	}
+	if snap.TotalCorrect < 0 || snap.TotalCorrect > snap.TotalQuestions {
+		return nil, fmt.Errorf("%w: %d correct out of %d questions", ErrInvalidSnapshot,
+			snap.TotalCorrect, snap.TotalQuestions)
+	}
+	if err := validateCorrectByTaxon(snap.CorrectByTaxon, snap.TotalCorrect); err != nil {
+		return nil, err
+	}
+	if err := validateAchievementTiers(snap.AchievementTiers, snap.Achievements); err != nil {
+		return nil, err
+	}
+	if want := levelForXP(snap.TotalXP); snap.Level != want {
+		return nil, fmt.Errorf("%w: level %d does not match %d XP (want %d)", ErrInvalidSnapshot,
+			snap.Level, snap.TotalXP, want)
+	}
+
+	p.totalXP = snap.TotalXP
+	p.level = snap.Level
+	p.totalGames = snap.TotalGames
+	p.totalCorrect = snap.TotalCorrect
+	p.totalQuestions = snap.TotalQuestions
+	p.bestStreak = snap.BestStreak
+	for taxon, n := range snap.CorrectByTaxon {
+		p.correctByTaxon[taxon] = n
+	}
+	p.achievements = append(p.achievements, snap.Achievements...)
+	for a, t := range snap.AchievementTiers {
+		p.tiers[a] = t
+	}
+	p.dailyStreak = snap.DailyStreak
+	p.homePlaceID = snap.HomePlaceID
+	p.createdAt = snap.CreatedAt
+	if snap.LastPlayedAt != nil {
+		t := *snap.LastPlayedAt
+		p.lastPlayedAt = &t
+	}
+	return p, nil
+}
+
+// validateCorrectByTaxon checks per-taxon counts against the total of correct answers.
+func validateCorrectByTaxon(correctByTaxon map[string]int, totalCorrect int) error {
+	sum := 0
+	for taxon, n := range correctByTaxon {
+		if taxon == "" || n < 0 {
+			return fmt.Errorf("%w: invalid correct count %d for taxon %q", ErrInvalidSnapshot, n, taxon)
+		}
+		sum += n
+	}
+	if sum > totalCorrect {
+		return fmt.Errorf("%w: %d correct identifications by taxon exceed %d correct answers",
+			ErrInvalidSnapshot, sum, totalCorrect)
+	}
+	return nil
+}
+
+// validateAchievementTiers checks that tiers are known and belong to unlocked achievements.
+func validateAchievementTiers(tiers map[Achievement]Tier, achievements []Achievement) error {
+	unlocked := make(map[Achievement]bool, len(achievements))
+	for _, a := range achievements {
+		unlocked[a] = true
+	}
+	for a, t := range tiers {
+		if !unlocked[a] {
+			return fmt.Errorf("%w: tier %q of locked achievement %s", ErrInvalidSnapshot, t, a)
+		}
+		if !slices.Contains(tierOrder, t) {
+			return fmt.Errorf("%w: unknown tier %q of achievement %s", ErrInvalidSnapshot, t, a)
+		}
+	}
+	return nil
+}
+
+// levelForXP returns the level reached with the given total XP, as AddXP computes it.
+func levelForXP(totalXP int) int {
+	level := 1
+	required := XPForLevel(level)
+	for totalXP >= required && level < maxLevel {
+		level++
+		required += XPForLevel(level)
+	}
+	return level
+}
@@ -0,0 +1,72 @@
+package gamification
+
+import (
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+)
+
+// GameSummary describes a completed game for progression and achievement
+// rules. New session-based rules read it rather than extending RecordGame.
+type GameSummary struct {
+	Difficulty quiz.Difficulty
+	QuizTypes  []quiz.QuizType
+	Correct    int
+	Total      int
+	MaxStreak  int
+	Duration   time.Duration
+	Answers    []AnswerSummary
+}
+
+// AnswerSummary describes one answered question of a game.
+type AnswerSummary struct {
+	QuizType    quiz.QuizType
+	IconicTaxon string
+	Correct     bool
+	Score       int
+	TimeTaken   time.Duration
+}
+
+// NewGameSummary summarizes a quiz session.
+func NewGameSummary(session *quiz.Session) GameSummary {
+	answered := session.AnsweredQuestions()
+	answers := make([]AnswerSummary, len(answered))
+	for i, aq := range answered {
+		answers[i] = AnswerSummary{
+			QuizType:    aq.Question.QuizType(),
+			IconicTaxon: aq.Question.CorrectSpecies().IconicTaxon(),
+			Correct:     aq.Answer.IsCorrect,
+			Score:       aq.Answer.Score,
+			TimeTaken:   aq.Answer.TimeTaken,
+		}
+	}
+
+	return GameSummary{
+		Difficulty: session.Difficulty(),
+		QuizTypes:  session.QuizTypes(),
+		Correct:    session.CorrectCount(),
+		Total:      session.QuestionsCount(),
+		MaxStreak:  session.MaxStreak(),
+		Duration:   session.Duration(),
+		Answers:    answers,
+	}
+}
+
+// Accuracy returns the game's accuracy percentage.
+func (s GameSummary) Accuracy() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Correct) / float64(s.Total) * 100
+}
+
+// correctByTaxon counts correct answers per iconic taxon.
+func (s GameSummary) correctByTaxon() map[string]int {
+	counts := make(map[string]int)
+	for _, a := range s.Answers {
+		if a.Correct && a.IconicTaxon != "" {
+			counts[a.IconicTaxon]++
+		}
+	}
+	return counts
+}
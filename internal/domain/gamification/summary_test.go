@@ -0,0 +1,63 @@
+package gamification_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+)
+
+func TestNewGameSummary(t *testing.T) {
+	bird, _ := species.New(1, "Erithacus rubecula", "Rougegorge", "Aves")
+	fox, _ := species.New(2, "Vulpes vulpes", "Renard roux", "Mammalia")
+	wrong, _ := species.New(3, "Vulpes zerda", "Fennec", "Mammalia")
+
+	q1, _ := quiz.NewQuestion("q1", quiz.ImageQuiz, quiz.Expert, bird,
+		[]quiz.Choice{{Species: bird, IsCorrect: true}, {Species: wrong}}, "https://example.com/1.jpg")
+	q2, _ := quiz.NewQuestion("q2", quiz.SoundQuiz, quiz.Expert, fox,
+		[]quiz.Choice{{Species: fox, IsCorrect: true}, {Species: wrong}}, "https://example.com/2.jpg")
+
+	session, _ := quiz.NewSessionBuilder().
+		WithUserID("p1").
+		WithDifficulty(quiz.Expert).
+		WithQuizTypes(quiz.ImageQuiz, quiz.SoundQuiz).
+		WithQuestions([]*quiz.Question{q1, q2}).
+		Build()
+	session.Start()
+	session.SubmitAnswer(1, 2*time.Second)
+	session.SubmitAnswer(3, 4*time.Second)
+
+	summary := gamification.NewGameSummary(session)
+
+	if summary.Difficulty != quiz.Expert || len(summary.QuizTypes) != 2 {
+		t.Errorf("summary difficulty/types = %s/%v", summary.Difficulty, summary.QuizTypes)
+	}
+	if summary.Correct != 1 || summary.Total != 2 || summary.MaxStreak != 1 || summary.Accuracy() != 50 {
+		t.Errorf("summary counts = %+v, accuracy %.0f", summary, summary.Accuracy())
+	}
+	if summary.Duration <= 0 {
+		t.Errorf("summary Duration = %v, want positive", summary.Duration)
+	}
+
+	firstScore := session.Answers()[0].Score
+	want := []gamification.AnswerSummary{
+		{QuizType: quiz.ImageQuiz, IconicTaxon: "Aves", Correct: true, Score: firstScore, TimeTaken: 2 * time.Second},
+		{QuizType: quiz.SoundQuiz, IconicTaxon: "Mammalia", Correct: false, Score: 0, TimeTaken: 4 * time.Second},
+	}
+	if len(summary.Answers) != len(want) {
+		t.Fatalf("summary Answers len = %d, want %d", len(summary.Answers), len(want))
+	}
+	for i := range want {
+		if summary.Answers[i] != want[i] {
+			t.Errorf("Answers[%d] = %+v, want %+v", i, summary.Answers[i], want[i])
+		}
+	}
+}
+
+func TestGameSummary_Accuracy_Empty(t *testing.T) {
+	if got := (gamification.GameSummary{}).Accuracy(); got != 0 {
+		t.Errorf("Accuracy() = %f, want 0", got)
+	}
+}
@@ -0,0 +1,49 @@
+// Package place contains domain entities for the places species are observed in.
+package place
+
+// Level is the rank of a place in the administrative hierarchy.
+type Level string
+
+const (
+	Country    Level = "country"    // Such as France
+	Region     Level = "region"     // Region, or state elsewhere
+	Department Level = "department" // Departement, or county elsewhere
+	Town       Level = "town"       // Commune
+)
+
+// Place is a geographic area observations can be searched in, such as a
+// French departement.
+type Place struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	DisplayName string  `json:"display_name,omitempty"` // Name with its country, such as "Isere, FR"
+	Level       Level   `json:"level,omitempty"`        // Empty outside the administrative hierarchy, such as a park
+	Ancestors   []Place `json:"ancestors,omitempty"`    // Enclosing places, from the largest down
+}
+
+// Ancestor returns the enclosing place at level, or the place itself when it
+// is at that level.
+func (p Place) Ancestor(level Level) (Place, bool) {
+	if p.Level == level {
+		return p, true
+	}
+	for _, ancestor := range p.Ancestors {
+		if ancestor.Level == level {
+			return ancestor, true
+		}
+	}
+	return Place{}, false
+}
+
+// Within reports whether the place is the place id or lies within it.
+func (p Place) Within(id int) bool {
+	if p.ID == id {
+		return true
+	}
+	for _, ancestor := range p.Ancestors {
+		if ancestor.ID == id {
+			return true
+		}
+	}
+	return false
+}
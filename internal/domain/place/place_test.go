@@ -0,0 +1,62 @@
+package place_test
+
+import (
+	"testing"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/place"
+)
+
+// isere is a French departement with its ancestry.
+var isere = place.Place{
+	ID:    6906,
+	Name:  "Isère",
+	Level: place.Department,
+	Ancestors: []place.Place{
+		{ID: 97391, Name: "Europe"},
+		{ID: 6753, Name: "France", Level: place.Country},
+		{ID: 190498, Name: "Auvergne-Rhône-Alpes", Level: place.Region},
+	},
+}
+
+func TestPlace_Ancestor(t *testing.T) {
+	tests := []struct {
+		level  place.Level
+		wantID int
+		wantOK bool
+	}{
+		{place.Country, 6753, true},
+		{place.Region, 190498, true},
+		{place.Department, 6906, true},
+		{place.Town, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.level), func(t *testing.T) {
+			got, ok := isere.Ancestor(tt.level)
+			if ok != tt.wantOK || got.ID != tt.wantID {
+				t.Errorf("Ancestor(%s) = %d, %v, want %d, %v", tt.level, got.ID, ok, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPlace_Within(t *testing.T) {
+	tests := []struct {
+		name string
+		id   int
+		want bool
+	}{
+		{"itself", 6906, true},
+		{"its region", 190498, true},
+		{"its continent", 97391, true},
+		{"another departement", 6910, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isere.Within(tt.id); got != tt.want {
+				t.Errorf("Within(%d) = %v, want %v", tt.id, got, tt.want)
+			}
+		})
+	}
+}
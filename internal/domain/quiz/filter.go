@@ -0,0 +1,168 @@
+package quiz
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+)
+
+// ErrInvalidFilter is returned for session filters no species can match.
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Season is a quarter of the year, as in the northern hemisphere.
+type Season string
+
+const (
+	Spring Season = "spring" // March to May
+	Summer Season = "summer" // June to August
+	Autumn Season = "autumn" // September to November
+	Winter Season = "winter" // December to February
+)
+
+// IsValidSeason checks if a season is valid.
+func IsValidSeason(s Season) bool {
+	switch s {
+	case Spring, Summer, Autumn, Winter:
+		return true
+	}
+	return false
+}
+
+// Months returns the months of the season, none for an invalid season.
+func (s Season) Months() Months {
+	switch s {
+	case Spring:
+		return NewMonths(time.March, time.April, time.May)
+	case Summer:
+		return NewMonths(time.June, time.July, time.August)
+	case Autumn:
+		return NewMonths(time.September, time.October, time.November)
+	case Winter:
+		return NewMonths(time.December, time.January, time.February)
+	}
+	return 0
+}
+
+// Months is a set of months of the year. The zero value is the empty set.
+type Months uint16
+
+// allMonths is the set of January to December.
+const allMonths Months = 0x1ffe
+
+// NewMonths returns the set of months. Months outside January to December
+// make the set invalid.
+func NewMonths(months ...time.Month) Months {
+	var m Months
+	for _, month := range months {
+		if month < time.January || month > time.December {
+			m |= 1 // Not a month: Valid reports false
+			continue
+		}
+		m |= 1 << month
+	}
+	return m
+}
+
+// Valid reports whether the set only holds months of the year.
+func (m Months) Valid() bool {
+	return m&^allMonths == 0
+}
+
+// Has reports whether the set contains month.
+func (m Months) Has(month time.Month) bool {
+	return month >= time.January && month <= time.December && m&(1<<month) != 0
+}
+
+// List returns the months of the set, in calendar order.
+func (m Months) List() []time.Month {
+	var list []time.Month
+	for month := time.January; month <= time.December; month++ {
+		if m.Has(month) {
+			list = append(list, month)
+		}
+	}
+	return list
+}
+
+// Area bounds, in kilometres.
+const (
+	DefaultAreaRadiusKm = 10
+	MinAreaRadiusKm     = 1 // Above the error of rounded coordinates
+	MaxAreaRadiusKm     = 50
+)
+
+// areaPrecision rounds coordinates to a hundredth of a degree, about a kilometre.
+const areaPrecision = 100
+
+// Area is a circle on the map, such as the surroundings of a trailhead. The
+// zero value is no area.
+type Area struct {
+	Lat      float64 // Degrees, -90 to 90
+	Lng      float64 // Degrees, -180 to 180
+	RadiusKm float64
+}
+
+// IsZero reports whether no area is set.
+func (a Area) IsZero() bool {
+	return a == Area{}
+}
+
+// Rounded returns the area with its centre rounded to about a kilometre, so
+// sessions never record where exactly a player stands.
+func (a Area) Rounded() Area {
+	a.Lat = math.Round(a.Lat*areaPrecision) / areaPrecision
+	a.Lng = math.Round(a.Lng*areaPrecision) / areaPrecision
+	return a
+}
+
+// validate returns ErrInvalidFilter for an area off the map or of an
+// unsupported radius.
+func (a Area) validate() error {
+	if a.IsZero() {
+		return nil
+	}
+	if math.IsNaN(a.Lat) || a.Lat < -90 || a.Lat > 90 {
+		return fmt.Errorf("%w: latitude %v out of range", ErrInvalidFilter, a.Lat)
+	}
+	if math.IsNaN(a.Lng) || a.Lng < -180 || a.Lng > 180 {
+		return fmt.Errorf("%w: longitude %v out of range", ErrInvalidFilter, a.Lng)
+	}
+	if math.IsNaN(a.RadiusKm) || a.RadiusKm < MinAreaRadiusKm || a.RadiusKm > MaxAreaRadiusKm {
+		return fmt.Errorf("%w: radius must be between %d and %d km", ErrInvalidFilter,
+			MinAreaRadiusKm, MaxAreaRadiusKm)
+	}
+	return nil
+}
+
+// Filter restricts the species a session draws its questions from. The zero
+// value matches all species.
+type Filter struct {
+	IconicTaxon string // Such as "Aves"
+	TaxonID     int    // Only descendants of this taxon, of any rank (e.g., Orchidaceae)
+	PlaceID     int    // Only species observed in this place
+	Area        Area   // Only species observed in this area, choices included
+	Months      Months // Only species observed in these months, any month when empty
+}
+
+// Validate returns ErrInvalidFilter when a criterion is malformed.
+func (f Filter) Validate() error {
+	if f.IconicTaxon != "" && !species.IsValidIconicTaxon(f.IconicTaxon) {
+		return fmt.Errorf("%w: unknown iconic taxon %q", ErrInvalidFilter, f.IconicTaxon)
+	}
+	if f.TaxonID < 0 {
+		return fmt.Errorf("%w: negative taxon ID %d", ErrInvalidFilter, f.TaxonID)
+	}
+	if f.PlaceID < 0 {
+		return fmt.Errorf("%w: negative place ID %d", ErrInvalidFilter, f.PlaceID)
+	}
+	if err := f.Area.validate(); err != nil {
+		return err
+	}
+	if !f.Months.Valid() {
+		return fmt.Errorf("%w: months must be between 1 and 12", ErrInvalidFilter)
+	}
+	return nil
+}
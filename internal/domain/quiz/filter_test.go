@@ -0,0 +1,83 @@
+package quiz_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+)
+
+func TestFilter_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  quiz.Filter
+		wantErr bool
+	}{
+		{name: "zero value", filter: quiz.Filter{}},
+		{
+			name: "all criteria",
+			filter: quiz.Filter{
+				IconicTaxon: "Plantae",
+				TaxonID:     47217,
+				PlaceID:     6753,
+				Months:      quiz.NewMonths(time.May, time.June),
+			},
+		},
+		{name: "unknown iconic taxon", filter: quiz.Filter{IconicTaxon: "Dragons"}, wantErr: true},
+		{name: "negative taxon", filter: quiz.Filter{TaxonID: -1}, wantErr: true},
+		{name: "negative place", filter: quiz.Filter{PlaceID: -6753}, wantErr: true},
+		{name: "area", filter: quiz.Filter{Area: quiz.Area{Lat: -33.9, Lng: 18.4, RadiusKm: 5}}},
+		{name: "latitude off the map", filter: quiz.Filter{Area: quiz.Area{Lat: 91, RadiusKm: 5}}, wantErr: true},
+		{name: "longitude off the map", filter: quiz.Filter{Area: quiz.Area{Lng: -181, RadiusKm: 5}}, wantErr: true},
+		{name: "area without radius", filter: quiz.Filter{Area: quiz.Area{Lat: 45, Lng: 6}}, wantErr: true},
+		{name: "radius too large", filter: quiz.Filter{Area: quiz.Area{Lat: 45, Lng: 6, RadiusKm: 500}}, wantErr: true},
+		{name: "month 13", filter: quiz.Filter{Months: quiz.NewMonths(time.May, 13)}, wantErr: true},
+		{name: "month 0", filter: quiz.Filter{Months: quiz.NewMonths(0)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, quiz.ErrInvalidFilter) {
+				t.Errorf("Validate() error = %v, want ErrInvalidFilter", err)
+			}
+		})
+	}
+}
+
+func TestArea_Rounded(t *testing.T) {
+	area := quiz.Area{Lat: 45.832622, Lng: -6.865174, RadiusKm: 10}
+	want := quiz.Area{Lat: 45.83, Lng: -6.87, RadiusKm: 10}
+	if got := area.Rounded(); got != want {
+		t.Errorf("Rounded() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSeason_Months(t *testing.T) {
+	tests := []struct {
+		season quiz.Season
+		want   []time.Month
+	}{
+		{quiz.Spring, []time.Month{time.March, time.April, time.May}},
+		{quiz.Summer, []time.Month{time.June, time.July, time.August}},
+		{quiz.Autumn, []time.Month{time.September, time.October, time.November}},
+		{quiz.Winter, []time.Month{time.January, time.February, time.December}},
+		{"monsoon", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.season), func(t *testing.T) {
+			if got := tt.season.Months().List(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Months().List() = %v, want %v", got, tt.want)
+			}
+			if got, want := quiz.IsValidSeason(tt.season), tt.want != nil; got != want {
+				t.Errorf("IsValidSeason(%s) = %v, want %v", tt.season, got, want)
+			}
+		})
+	}
+}
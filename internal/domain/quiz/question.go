@@ -21,6 +21,7 @@ type Question struct {
 	correctSpecies *species.Species
 	choices        []Choice
 	mediaURL       string
+	photoID        int // Photo of the correct species shown, 0 when unknown
 	timeLimit      time.Duration
 	flashDuration  time.Duration
 	createdAt      time.Time
@@ -111,6 +112,65 @@ func (q *Question) MediaURL() string {
 	return q.mediaURL
 }
 
+// Sound returns the recording played by a SoundQuiz question.
+func (q *Question) Sound() (species.Sound, bool) {
+	if q.quizType != SoundQuiz {
+		return species.Sound{}, false
+	}
+	for _, sound := range q.correctSpecies.Sounds() {
+		if sound.FileURL == q.mediaURL {
+			return sound, true
+		}
+	}
+	return species.Sound{}, false
+}
+
+// SetPhotoID records which photo of the correct species the media shows,
+// which a derived media URL does not tell.
+func (q *Question) SetPhotoID(id int) {
+	q.photoID = id
+}
+
+// Photo returns the photo of the correct species shown by the question.
+func (q *Question) Photo() (species.Photo, bool) {
+	if q.photoID == 0 {
+		return species.Photo{}, false
+	}
+	for _, photo := range q.correctSpecies.Photos() {
+		if photo.ID == q.photoID {
+			return photo, true
+		}
+	}
+	return species.Photo{}, false
+}
+
+// MediaCredit is the attribution owed for the media of a question.
+type MediaCredit struct {
+	Attribution    string
+	LicenseCode    string
+	ObservationURL string
+}
+
+// Credit returns the attribution, license and source observation of the
+// media of the question, when known.
+func (q *Question) Credit() (MediaCredit, bool) {
+	if sound, ok := q.Sound(); ok {
+		return MediaCredit{
+			Attribution:    sound.Attribution,
+			LicenseCode:    sound.LicenseCode,
+			ObservationURL: sound.ObservationURL,
+		}, true
+	}
+	if photo, ok := q.Photo(); ok {
+		return MediaCredit{
+			Attribution:    photo.Attribution,
+			LicenseCode:    photo.LicenseCode,
+			ObservationURL: photo.ObservationURL,
+		}, true
+	}
+	return MediaCredit{}, false
+}
+
 // TimeLimit returns the time limit for answering.
 func (q *Question) TimeLimit() time.Duration {
 	return q.timeLimit
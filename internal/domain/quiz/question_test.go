@@ -137,6 +137,76 @@ func TestQuestion_CheckAnswer(t *testing.T) {
 	}
 }
 
+func TestQuestion_Sound(t *testing.T) {
+	correct := createTestSpecies(1, "Erithacus rubecula")
+	correct.AddSound(species.Sound{ID: 5, FileURL: "https://example.com/song.mp3", FileContentType: "audio/mpeg"})
+	choices := []quiz.Choice{
+		{Species: correct, IsCorrect: true},
+		{Species: createTestSpecies(2, "Troglodytes troglodytes"), IsCorrect: false},
+	}
+
+	q, _ := quiz.NewQuestion("q1", quiz.SoundQuiz, quiz.Beginner, correct, choices, "https://example.com/song.mp3")
+	if sound, ok := q.Sound(); !ok || sound.ID != 5 {
+		t.Errorf("Sound() = %+v, %v, want the played recording", sound, ok)
+	}
+
+	image, _ := quiz.NewQuestion("q2", quiz.ImageQuiz, quiz.Beginner, correct, choices, "https://example.com/photo.jpg")
+	if _, ok := image.Sound(); ok {
+		t.Error("Sound() ok = true for an image question")
+	}
+}
+
+func TestQuestion_Credit(t *testing.T) {
+	correct := createTestSpecies(1, "Erithacus rubecula")
+	correct.AddPhoto(species.Photo{
+		ID:             2,
+		URL:            "https://example.com/robin.jpg",
+		Attribution:    "(c) someone, some rights reserved (CC BY-NC)",
+		LicenseCode:    "cc-by-nc",
+		ObservationURL: "https://www.inaturalist.org/observations/7",
+	})
+	correct.AddSound(species.Sound{
+		ID:          5,
+		FileURL:     "https://example.com/song.mp3",
+		Attribution: "(c) someone else, some rights reserved (CC BY)",
+		LicenseCode: "cc-by",
+	})
+	choices := []quiz.Choice{
+		{Species: correct, IsCorrect: true},
+		{Species: createTestSpecies(2, "Troglodytes troglodytes"), IsCorrect: false},
+	}
+
+	tests := []struct {
+		name        string
+		quizType    quiz.QuizType
+		mediaURL    string
+		photoID     int
+		wantLicense string
+		wantOK      bool
+	}{
+		{name: "shown photo", quizType: quiz.PartialQuiz, mediaURL: "media:abc", photoID: 2,
+			wantLicense: "cc-by-nc", wantOK: true},
+		{name: "played sound", quizType: quiz.SoundQuiz, mediaURL: "https://example.com/song.mp3",
+			wantLicense: "cc-by", wantOK: true},
+		{name: "unknown photo", quizType: quiz.ImageQuiz, mediaURL: "https://example.com/robin.jpg"},
+		{name: "photo of another species", quizType: quiz.ImageQuiz, mediaURL: "https://example.com/x.jpg", photoID: 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := quiz.NewQuestion("q1", tt.quizType, quiz.Beginner, correct, choices, tt.mediaURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			q.SetPhotoID(tt.photoID)
+
+			credit, ok := q.Credit()
+			if ok != tt.wantOK || credit.LicenseCode != tt.wantLicense {
+				t.Errorf("Credit() = %+v, %v, want license %q, %v", credit, ok, tt.wantLicense, tt.wantOK)
+			}
+		})
+	}
+}
+
 func TestQuestion_CalculateScore(t *testing.T) {
 	correct := createTestSpecies(1, "Vulpes vulpes")
 	wrong := createTestSpecies(2, "Vulpes zerda")
@@ -33,7 +33,7 @@ type Session struct {
 	userID       string
 	difficulty   Difficulty
 	quizTypes    []QuizType
-	taxonFilter  string
+	filter       Filter
 	questions    []*Question
 	answers      []Answer
 	currentIndex int
@@ -47,11 +47,11 @@ type Session struct {
 
 // SessionBuilder helps construct quiz sessions.
 type SessionBuilder struct {
-	userID      string
-	difficulty  Difficulty
-	quizTypes   []QuizType
-	taxonFilter string
-	questions   []*Question
+	userID     string
+	difficulty Difficulty
+	quizTypes  []QuizType
+	filter     Filter
+	questions  []*Question
 }
 
 // NewSessionBuilder creates a new session builder.
@@ -80,9 +80,9 @@ func (b *SessionBuilder) WithQuizTypes(types ...QuizType) *SessionBuilder {
 	return b
 }
 
-// WithTaxonFilter sets the taxon filter.
-func (b *SessionBuilder) WithTaxonFilter(taxon string) *SessionBuilder {
-	b.taxonFilter = taxon
+// WithFilter sets the filter the questions were drawn with.
+func (b *SessionBuilder) WithFilter(filter Filter) *SessionBuilder {
+	b.filter = filter
 	return b
 }
 
@@ -106,7 +106,7 @@ func (b *SessionBuilder) Build() (*Session, error) {
 		userID:       b.userID,
 		difficulty:   b.difficulty,
 		quizTypes:    b.quizTypes,
-		taxonFilter:  b.taxonFilter,
+		filter:       b.filter,
 		questions:    b.questions,
 		answers:      make([]Answer, 0, len(b.questions)),
 		currentIndex: 0,
@@ -129,6 +129,16 @@ func (s *Session) Difficulty() Difficulty {
 	return s.difficulty
 }
 
+// QuizTypes returns the quiz types the session draws from.
+func (s *Session) QuizTypes() []QuizType {
+	return s.quizTypes
+}
+
+// Filter returns the filter the questions were drawn with.
+func (s *Session) Filter() Filter {
+	return s.filter
+}
+
 // Status returns the current status.
 func (s *Session) Status() SessionStatus {
 	return s.status
@@ -170,6 +180,11 @@ func (s *Session) CorrectCount() int {
 	return count
 }
 
+// Questions returns all questions of the session.
+func (s *Session) Questions() []*Question {
+	return s.questions
+}
+
 // CurrentQuestion returns the current question or nil if finished.
 func (s *Session) CurrentQuestion() *Question {
 	if s.currentIndex >= len(s.questions) {
@@ -264,6 +279,42 @@ func (s *Session) Answers() []Answer {
 	return s.answers
 }
 
+// AnsweredQuestion is an answer with the question it answers.
+type AnsweredQuestion struct {
+	Question *Question
+	Answer   Answer
+}
+
+// AnsweredQuestions returns the answers with their questions, matched by
+// question ID. Answers to unknown questions are left out.
+func (s *Session) AnsweredQuestions() []AnsweredQuestion {
+	byID := make(map[string]*Question, len(s.questions))
+	for _, q := range s.questions {
+		byID[q.ID()] = q
+	}
+
+	answered := make([]AnsweredQuestion, 0, len(s.answers))
+	for _, a := range s.answers {
+		if q, ok := byID[a.QuestionID]; ok {
+			answered = append(answered, AnsweredQuestion{Question: q, Answer: a})
+		}
+	}
+	return answered
+}
+
+// StartedAt returns when the session was started (zero if pending).
+func (s *Session) StartedAt() time.Time {
+	return s.startedAt
+}
+
+// CompletedAt returns when the session ended (zero while running).
+func (s *Session) CompletedAt() time.Time {
+	if s.completedAt == nil {
+		return time.Time{}
+	}
+	return *s.completedAt
+}
+
 // Duration returns the session duration.
 func (s *Session) Duration() time.Duration {
 	if s.startedAt.IsZero() {
@@ -10,7 +10,14 @@ import (
 
 func createTestQuestion(id string, correctID int) *quiz.Question {
 	correct, _ := species.New(correctID, "Correct Species", "Correct", "Mammalia")
-	correct.AddPhoto(species.Photo{ID: 1, URL: "https://example.com/photo.jpg"})
+	correct.AddPhoto(species.Photo{
+		ID:             1,
+		URL:            "https://example.com/photo.jpg",
+		Attribution:    "(c) someone, some rights reserved (CC BY)",
+		LicenseCode:    "cc-by",
+		ObservationID:  7,
+		ObservationURL: "https://www.inaturalist.org/observations/7",
+	})
 
 	wrong, _ := species.New(correctID+100, "Wrong Species", "Wrong", "Mammalia")
 
@@ -20,6 +27,7 @@ func createTestQuestion(id string, correctID int) *quiz.Question {
 	}
 
 	q, _ := quiz.NewQuestion(id, quiz.ImageQuiz, quiz.Beginner, correct, choices, "https://example.com/img.jpg")
+	q.SetPhotoID(1)
 	return q
 }
 
@@ -207,6 +215,32 @@ func TestSession_Accuracy(t *testing.T) {
 	}
 }
 
+func TestSession_AnsweredQuestions(t *testing.T) {
+	questions := []*quiz.Question{createTestQuestion("q1", 1), createTestQuestion("q2", 2), createTestQuestion("q3", 3)}
+	session, _ := quiz.NewSessionBuilder().
+		WithUserID("user1").
+		WithQuestions(questions).
+		Build()
+	session.Start()
+
+	session.SubmitAnswer(1, 5*time.Second)
+	session.SubmitAnswer(999, 5*time.Second)
+
+	answered := session.AnsweredQuestions()
+	if len(answered) != 2 {
+		t.Fatalf("AnsweredQuestions() len = %d, want 2", len(answered))
+	}
+	for i, aq := range answered {
+		if aq.Question != questions[i] || aq.Answer.QuestionID != questions[i].ID() {
+			t.Errorf("AnsweredQuestions()[%d] = %s answered for %s", i, aq.Question.ID(), aq.Answer.QuestionID)
+		}
+	}
+	if !answered[0].Answer.IsCorrect || answered[1].Answer.IsCorrect {
+		t.Errorf("AnsweredQuestions() correctness = %v, %v, want true, false",
+			answered[0].Answer.IsCorrect, answered[1].Answer.IsCorrect)
+	}
+}
+
 func TestSession_Abandon(t *testing.T) {
 	q := createTestQuestion("q1", 1)
 	session, _ := quiz.NewSessionBuilder().
@@ -0,0 +1,295 @@
+package quiz
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
+)
+
+// SessionSnapshotVersion is the current schema version of SessionSnapshot.
+// Bump it whenever the snapshot layout changes incompatibly.
+const SessionSnapshotVersion = 1
+
+// ErrInvalidSnapshot is returned when a snapshot cannot be restored.
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// SessionSnapshot is a serializable representation of a Session.
+type SessionSnapshot struct {
+	Version      int                `json:"version"`
+	ID           string             `json:"id"`
+	UserID       string             `json:"user_id"`
+	Difficulty   Difficulty         `json:"difficulty"`
+	QuizTypes    []QuizType         `json:"quiz_types"`
+	TaxonFilter  string             `json:"taxon_filter,omitempty"` // Iconic taxon of the filter
+	TaxonID      int                `json:"taxon_id,omitempty"`
+	PlaceID      int                `json:"place_id,omitempty"`
+	Lat          float64            `json:"lat,omitempty"` // Rounded centre of the area filter
+	Lng          float64            `json:"lng,omitempty"`
+	RadiusKm     float64            `json:"radius_km,omitempty"`
+	Months       []time.Month       `json:"months,omitempty"`
+	Questions    []QuestionSnapshot `json:"questions"`
+	Answers      []AnswerSnapshot   `json:"answers"`
+	CurrentIndex int                `json:"current_index"`
+	TotalScore   int                `json:"total_score"`
+	Streak       int                `json:"streak"`
+	MaxStreak    int                `json:"max_streak"`
+	Status       SessionStatus      `json:"status"`
+	StartedAt    time.Time          `json:"started_at"`
+	CompletedAt  *time.Time         `json:"completed_at,omitempty"`
+}
+
+// QuestionSnapshot is a serializable representation of a Question.
+// The correct species is the choice flagged IsCorrect.
+type QuestionSnapshot struct {
+	ID            string           `json:"id"`
+	QuizType      QuizType         `json:"quiz_type"`
+	Difficulty    Difficulty       `json:"difficulty"`
+	Choices       []ChoiceSnapshot `json:"choices"`
+	MediaURL      string           `json:"media_url"`
+	PhotoID       int              `json:"photo_id,omitempty"`
+	TimeLimit     time.Duration    `json:"time_limit"`
+	FlashDuration time.Duration    `json:"flash_duration"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+// ChoiceSnapshot is a serializable representation of a Choice.
+type ChoiceSnapshot struct {
+	Species   species.Snapshot `json:"species"`
+	IsCorrect bool             `json:"is_correct"`
+}
+
+// AnswerSnapshot is a serializable representation of an Answer.
+type AnswerSnapshot struct {
+	QuestionID string        `json:"question_id"`
+	SpeciesID  int           `json:"species_id"`
+	TimeTaken  time.Duration `json:"time_taken"`
+	IsCorrect  bool          `json:"is_correct"`
+	Score      int           `json:"score"`
+	AnsweredAt time.Time     `json:"answered_at"`
+}
+
+// Snapshot captures the full state of the session.
+func (s *Session) Snapshot() SessionSnapshot {
+	questions := make([]QuestionSnapshot, len(s.questions))
+	for i, q := range s.questions {
+		questions[i] = q.Snapshot()
+	}
+
+	answers := make([]AnswerSnapshot, len(s.answers))
+	for i, a := range s.answers {
+		answers[i] = AnswerSnapshot(a)
+	}
+
+	var completedAt *time.Time
+	if s.completedAt != nil {
+		t := *s.completedAt
+		completedAt = &t
+	}
+
+	return SessionSnapshot{
+		Version:      SessionSnapshotVersion,
+		ID:           s.id,
+		UserID:       s.userID,
+		Difficulty:   s.difficulty,
+		QuizTypes:    append([]QuizType(nil), s.quizTypes...),
+		TaxonFilter:  s.filter.IconicTaxon,
+		TaxonID:      s.filter.TaxonID,
+		PlaceID:      s.filter.PlaceID,
+		Lat:          s.filter.Area.Lat,
+		Lng:          s.filter.Area.Lng,
+		RadiusKm:     s.filter.Area.RadiusKm,
+		Months:       s.filter.Months.List(),
+		Questions:    questions,
+		Answers:      answers,
+		CurrentIndex: s.currentIndex,
+		TotalScore:   s.totalScore,
+		Streak:       s.streak,
+		MaxStreak:    s.maxStreak,
+		Status:       s.status,
+		StartedAt:    s.startedAt,
+		CompletedAt:  completedAt,
+	}
+}
+
+// Snapshot captures the full state of the question.
+func (q *Question) Snapshot() QuestionSnapshot {
+	choices := make([]ChoiceSnapshot, len(q.choices))
+	for i, c := range q.choices {
+		choices[i] = ChoiceSnapshot{
+			Species:   c.Species.Snapshot(),
+			IsCorrect: c.IsCorrect,
+		}
+	}
+
+	return QuestionSnapshot{
+		ID:            q.id,
+		QuizType:      q.quizType,
+		Difficulty:    q.difficulty,
+		Choices:       choices,
+		MediaURL:      q.mediaURL,
+		PhotoID:       q.photoID,
+		TimeLimit:     q.timeLimit,
+		FlashDuration: q.flashDuration,
+		CreatedAt:     q.createdAt,
+	}
+}
+
+// RestoreQuestion rebuilds a Question from a snapshot.
+func RestoreQuestion(snap QuestionSnapshot) (*Question, error) {
+	choices := make([]Choice, len(snap.Choices))
+	var correct *species.Species
+	for i, c := range snap.Choices {
+		sp, err := species.Restore(c.Species)
+		if err != nil {
+			return nil, fmt.Errorf("%w: question %s choice %d: %v", ErrInvalidSnapshot, snap.ID, i, err)
+		}
+		if c.IsCorrect {
+			if correct != nil {
+				return nil, fmt.Errorf("%w: question %s has several correct choices", ErrInvalidSnapshot, snap.ID)
+			}
+			correct = sp
+		}
+		choices[i] = Choice{Species: sp, IsCorrect: c.IsCorrect}
+	}
+
+	q, err := NewQuestion(snap.ID, snap.QuizType, snap.Difficulty, correct, choices, snap.MediaURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: question %s: %v", ErrInvalidSnapshot, snap.ID, err)
+	}
+	if snap.TimeLimit <= 0 {
+		return nil, fmt.Errorf("%w: question %s: time limit must be positive", ErrInvalidSnapshot, snap.ID)
+	}
+
+	q.photoID = snap.PhotoID
+	q.timeLimit = snap.TimeLimit
+	q.flashDuration = snap.FlashDuration
+	q.createdAt = snap.CreatedAt
+	return q, nil
+}
+
+// RestoreSession rebuilds a Session from a snapshot, preserving its ID and progress.
+func RestoreSession(snap SessionSnapshot) (*Session, error) {
+	if err := snap.validateHeader(); err != nil {
+		return nil, err
+	}
+
+	questions := make([]*Question, len(snap.Questions))
+	for i, qs := range snap.Questions {
+		q, err := RestoreQuestion(qs)
+		if err != nil {
+			return nil, err
+		}
+		questions[i] = q
+	}
+
+	answers := make([]Answer, len(snap.Answers))
+	for i, a := range snap.Answers {
+		answers[i] = Answer(a)
+	}
+
+	if err := snap.validateProgress(questions, answers); err != nil {
+		return nil, err
+	}
+
+	var completedAt *time.Time
+	if snap.CompletedAt != nil {
+		t := *snap.CompletedAt
+		completedAt = &t
+	}
+
+	return &Session{
+		id:         snap.ID,
+		userID:     snap.UserID,
+		difficulty: snap.Difficulty,
+		quizTypes:  append([]QuizType(nil), snap.QuizTypes...),
+		filter: Filter{
+			IconicTaxon: snap.TaxonFilter,
+			TaxonID:     snap.TaxonID,
+			PlaceID:     snap.PlaceID,
+			Area:        Area{Lat: snap.Lat, Lng: snap.Lng, RadiusKm: snap.RadiusKm},
+			Months:      NewMonths(snap.Months...),
+		},
+		questions:    questions,
+		answers:      answers,
+		currentIndex: snap.CurrentIndex,
+		totalScore:   snap.TotalScore,
+		streak:       snap.Streak,
+		maxStreak:    snap.MaxStreak,
+		status:       snap.Status,
+		startedAt:    snap.StartedAt,
+		completedAt:  completedAt,
+	}, nil
+}
+
+// validateHeader checks the session-level fields of the snapshot.
+func (snap *SessionSnapshot) validateHeader() error {
+	if snap.Version != SessionSnapshotVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, snap.Version)
+	}
+	if snap.ID == "" {
+		return fmt.Errorf("%w: session id is required", ErrInvalidSnapshot)
+	}
+	if snap.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidSnapshot)
+	}
+	if !IsValidDifficulty(snap.Difficulty) {
+		return fmt.Errorf("%w: invalid difficulty %q", ErrInvalidSnapshot, snap.Difficulty)
+	}
+	for _, qt := range snap.QuizTypes {
+		if !IsValidQuizType(qt) {
+			return fmt.Errorf("%w: invalid quiz type %q", ErrInvalidSnapshot, qt)
+		}
+	}
+	if len(snap.Questions) == 0 {
+		return fmt.Errorf("%w: at least one question is required", ErrInvalidSnapshot)
+	}
+	return nil
+}
+
+// validateProgress checks that answers, counters and status are consistent.
+func (snap *SessionSnapshot) validateProgress(questions []*Question, answers []Answer) error {
+	if snap.CurrentIndex != len(answers) || snap.CurrentIndex > len(questions) {
+		return fmt.Errorf("%w: current index %d does not match %d answers", ErrInvalidSnapshot,
+			snap.CurrentIndex, len(answers))
+	}
+
+	score := 0
+	for i, a := range answers {
+		if a.QuestionID != questions[i].ID() {
+			return fmt.Errorf("%w: answer %d does not match question %s", ErrInvalidSnapshot, i, questions[i].ID())
+		}
+		score += a.Score
+	}
+	if score != snap.TotalScore {
+		return fmt.Errorf("%w: total score %d does not match answers (%d)", ErrInvalidSnapshot, snap.TotalScore, score)
+	}
+
+	if snap.Streak < 0 || snap.Streak > snap.MaxStreak || snap.MaxStreak > len(answers) {
+		return fmt.Errorf("%w: inconsistent streak %d/%d", ErrInvalidSnapshot, snap.Streak, snap.MaxStreak)
+	}
+
+	return snap.validateStatus(len(questions))
+}
+
+// validateStatus checks that the status matches progress and timestamps.
+func (snap *SessionSnapshot) validateStatus(questionCount int) error {
+	switch snap.Status {
+	case SessionPending:
+		if snap.CurrentIndex > 0 || !snap.StartedAt.IsZero() || snap.CompletedAt != nil {
+			return fmt.Errorf("%w: pending session has progress", ErrInvalidSnapshot)
+		}
+	case SessionInProgress:
+		if snap.StartedAt.IsZero() || snap.CompletedAt != nil || snap.CurrentIndex >= questionCount {
+			return fmt.Errorf("%w: inconsistent in-progress session", ErrInvalidSnapshot)
+		}
+	case SessionCompleted, SessionAbandoned:
+		if snap.CompletedAt == nil {
+			return fmt.Errorf("%w: %s session requires completion time", ErrInvalidSnapshot, snap.Status)
+		}
+	default:
+		return fmt.Errorf("%w: invalid status %q", ErrInvalidSnapshot, snap.Status)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package quiz_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+)
+
+func createPlayedSession(t *testing.T) *quiz.Session {
+	t.Helper()
+	session, err := quiz.NewSessionBuilder().
+		WithUserID("user1").
+		WithDifficulty(quiz.Expert).
+		WithQuizTypes(quiz.ImageQuiz, quiz.FlashQuiz).
+		WithFilter(quiz.Filter{
+			IconicTaxon: "Aves",
+			PlaceID:     6753,
+			Area:        quiz.Area{Lat: 45.83, Lng: 6.86, RadiusKm: 10},
+			Months:      quiz.Spring.Months(),
+		}).
+		WithQuestions([]*quiz.Question{
+			createTestQuestion("q1", 1),
+			createTestQuestion("q2", 2),
+			createTestQuestion("q3", 3),
+		}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	session.Start()
+	session.SubmitAnswer(1, 2*time.Second)
+	session.SubmitAnswer(2, 3*time.Second)
+	return session
+}
+
+func TestSession_SnapshotRoundTrip(t *testing.T) {
+	session := createPlayedSession(t)
+
+	data, err := json.Marshal(session.Snapshot())
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var snap quiz.SessionSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	restored, err := quiz.RestoreSession(snap)
+	if err != nil {
+		t.Fatalf("RestoreSession() error = %v", err)
+	}
+
+	if restored.ID() != session.ID() {
+		t.Errorf("ID = %s, want %s", restored.ID(), session.ID())
+	}
+	if restored.Status() != quiz.SessionInProgress {
+		t.Errorf("Status = %s, want in_progress", restored.Status())
+	}
+	if restored.CurrentStreak() != 2 || restored.MaxStreak() != 2 {
+		t.Errorf("Streak = %d/%d, want 2/2", restored.CurrentStreak(), restored.MaxStreak())
+	}
+	if restored.TotalScore() != session.TotalScore() {
+		t.Errorf("TotalScore = %d, want %d", restored.TotalScore(), session.TotalScore())
+	}
+	if !restored.StartedAt().Equal(session.StartedAt()) {
+		t.Errorf("StartedAt = %v, want %v", restored.StartedAt(), session.StartedAt())
+	}
+	if restored.CurrentQuestion().ID() != "q3" {
+		t.Errorf("CurrentQuestion = %s, want q3", restored.CurrentQuestion().ID())
+	}
+	if restored.Filter() != session.Filter() {
+		t.Errorf("Filter = %+v, want %+v", restored.Filter(), session.Filter())
+	}
+	if credit, ok := restored.CurrentQuestion().Credit(); !ok || credit.LicenseCode != "cc-by" {
+		t.Errorf("Credit() = %+v, %v, want the credit of the shown photo", credit, ok)
+	}
+
+	// Re-snapshotting must be lossless
+	again, _ := json.Marshal(restored.Snapshot())
+	if string(again) != string(data) {
+		t.Errorf("snapshot not lossless:\n got %s\nwant %s", again, data)
+	}
+
+	// Restored sessions keep playing normally
+	if _, err := restored.SubmitAnswer(3, time.Second); err != nil {
+		t.Fatalf("SubmitAnswer() on restored session error = %v", err)
+	}
+	if restored.Status() != quiz.SessionCompleted {
+		t.Errorf("Status = %s, want completed", restored.Status())
+	}
+}
+
+func TestSession_SnapshotIsDetached(t *testing.T) {
+	session := createPlayedSession(t)
+	snap := session.Snapshot()
+
+	session.SubmitAnswer(3, time.Second)
+
+	restored, err := quiz.RestoreSession(snap)
+	if err != nil {
+		t.Fatalf("RestoreSession() error = %v", err)
+	}
+	if restored.AnsweredCount() != 2 {
+		t.Errorf("AnsweredCount = %d, want 2", restored.AnsweredCount())
+	}
+	if !reflect.DeepEqual(restored.Snapshot(), snap) {
+		t.Error("restored snapshot differs from original")
+	}
+}
+
+func TestRestoreSession_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(s *quiz.SessionSnapshot)
+	}{
+		{"unsupported version", func(s *quiz.SessionSnapshot) { s.Version = 99 }},
+		{"missing id", func(s *quiz.SessionSnapshot) { s.ID = "" }},
+		{"missing user id", func(s *quiz.SessionSnapshot) { s.UserID = "" }},
+		{"invalid difficulty", func(s *quiz.SessionSnapshot) { s.Difficulty = "legendary" }},
+		{"invalid quiz type", func(s *quiz.SessionSnapshot) { s.QuizTypes = []quiz.QuizType{"smell"} }},
+		{"no questions", func(s *quiz.SessionSnapshot) { s.Questions = nil }},
+		{"index mismatch", func(s *quiz.SessionSnapshot) { s.CurrentIndex = 1 }},
+		{"answer mismatch", func(s *quiz.SessionSnapshot) { s.Answers[0].QuestionID = "other" }},
+		{"score mismatch", func(s *quiz.SessionSnapshot) { s.TotalScore++ }},
+		{"streak above max", func(s *quiz.SessionSnapshot) { s.Streak = 3 }},
+		{"invalid status", func(s *quiz.SessionSnapshot) { s.Status = "paused" }},
+		{"completed without time", func(s *quiz.SessionSnapshot) { s.Status = quiz.SessionCompleted }},
+		{"no correct choice", func(s *quiz.SessionSnapshot) { s.Questions[0].Choices[0].IsCorrect = false }},
+		{"several correct choices", func(s *quiz.SessionSnapshot) { s.Questions[0].Choices[1].IsCorrect = true }},
+		{"invalid species", func(s *quiz.SessionSnapshot) { s.Questions[1].Choices[0].Species.ID = 0 }},
+		{"missing time limit", func(s *quiz.SessionSnapshot) { s.Questions[2].TimeLimit = 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap := createPlayedSession(t).Snapshot()
+			tt.mutate(&snap)
+
+			_, err := quiz.RestoreSession(snap)
+			if !errors.Is(err, quiz.ErrInvalidSnapshot) {
+				t.Errorf("RestoreSession() error = %v, want ErrInvalidSnapshot", err)
+			}
+		})
+	}
+}
@@ -33,6 +33,7 @@ type DifficultyConfig struct {
 	TimeLimit       time.Duration
 	ScoreMultiplier float64
 	FlashDuration   time.Duration // For FlashQuiz
+	CropRatio       float64       // For PartialQuiz: visible fraction of the photo width and height
 }
 
 // DefaultDifficultyConfigs returns the default configurations.
@@ -44,6 +45,7 @@ func DefaultDifficultyConfigs() map[Difficulty]DifficultyConfig {
 			TimeLimit:       30 * time.Second,
 			ScoreMultiplier: 1.0,
 			FlashDuration:   5 * time.Second,
+			CropRatio:       0.6,
 		},
 		Intermediate: {
 			Difficulty:      Intermediate,
@@ -51,6 +53,7 @@ func DefaultDifficultyConfigs() map[Difficulty]DifficultyConfig {
 			TimeLimit:       20 * time.Second,
 			ScoreMultiplier: 1.5,
 			FlashDuration:   3 * time.Second,
+			CropRatio:       0.45,
 		},
 		Expert: {
 			Difficulty:      Expert,
@@ -58,6 +61,7 @@ func DefaultDifficultyConfigs() map[Difficulty]DifficultyConfig {
 			TimeLimit:       15 * time.Second,
 			ScoreMultiplier: 2.0,
 			FlashDuration:   2 * time.Second,
+			CropRatio:       0.35,
 		},
 		Master: {
 			Difficulty:      Master,
@@ -65,6 +69,7 @@ func DefaultDifficultyConfigs() map[Difficulty]DifficultyConfig {
 			TimeLimit:       10 * time.Second,
 			ScoreMultiplier: 3.0,
 			FlashDuration:   1 * time.Second,
+			CropRatio:       0.25,
 		},
 	}
 }
@@ -0,0 +1,40 @@
+package species
+
+// Snapshot is a serializable representation of a Species.
+type Snapshot struct {
+	ID             int     `json:"id"`
+	ScientificName string  `json:"scientific_name"`
+	CommonName     string  `json:"common_name,omitempty"`
+	IconicTaxon    string  `json:"iconic_taxon,omitempty"`
+	Rank           string  `json:"rank,omitempty"`
+	AncestorIDs    []int   `json:"ancestor_ids,omitempty"`
+	Photos         []Photo `json:"photos,omitempty"`
+	Sounds         []Sound `json:"sounds,omitempty"`
+}
+
+// Snapshot captures the full state of the species.
+func (s *Species) Snapshot() Snapshot {
+	return Snapshot{
+		ID:             s.id,
+		ScientificName: s.scientificName,
+		CommonName:     s.commonName,
+		IconicTaxon:    s.iconicTaxon,
+		Rank:           s.rank,
+		AncestorIDs:    append([]int(nil), s.ancestorIDs...),
+		Photos:         append([]Photo(nil), s.photos...),
+		Sounds:         append([]Sound(nil), s.sounds...),
+	}
+}
+
+// Restore rebuilds a Species from a snapshot with the same validation as New.
+func Restore(snap Snapshot) (*Species, error) {
+	sp, err := New(snap.ID, snap.ScientificName, snap.CommonName, snap.IconicTaxon)
+	if err != nil {
+		return nil, err
+	}
+	sp.rank = snap.Rank
+	sp.ancestorIDs = append([]int(nil), snap.AncestorIDs...)
+	sp.photos = append(sp.photos, snap.Photos...)
+	sp.sounds = append([]Sound(nil), snap.Sounds...)
+	return sp, nil
+}
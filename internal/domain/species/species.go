@@ -3,6 +3,8 @@ package species
 
 import (
 	"errors"
+	"slices"
+	"strings"
 )
 
 // IconicTaxon represents the major taxonomic groups.
@@ -26,13 +28,52 @@ func IsValidIconicTaxon(taxon string) bool {
 
 // Photo represents a species photo from iNaturalist.
 type Photo struct {
-	ID          int
-	URL         string
-	MediumURL   string
-	LargeURL    string
-	OriginalURL string
-	SquareURL   string
-	Attribution string
+	ID             int    `json:"id"`
+	URL            string `json:"url,omitempty"`
+	MediumURL      string `json:"medium_url,omitempty"`
+	LargeURL       string `json:"large_url,omitempty"`
+	OriginalURL    string `json:"original_url,omitempty"`
+	SquareURL      string `json:"square_url,omitempty"`
+	Attribution    string `json:"attribution,omitempty"`
+	LicenseCode    string `json:"license_code,omitempty"`    // Such as cc-by-nc, empty when all rights are reserved
+	ObservationID  int    `json:"observation_id,omitempty"`  // Observation the photo comes from, if any
+	ObservationURL string `json:"observation_url,omitempty"` // Page of that observation
+}
+
+// Sound represents a recording of a species from an iNaturalist observation.
+type Sound struct {
+	ID              int    `json:"id"`
+	FileURL         string `json:"file_url"`
+	FileContentType string `json:"file_content_type,omitempty"` // MIME type, such as audio/mpeg
+	Attribution     string `json:"attribution,omitempty"`
+	LicenseCode     string `json:"license_code,omitempty"`
+	ObservationID   int    `json:"observation_id,omitempty"`
+	ObservationURL  string `json:"observation_url,omitempty"`
+}
+
+// HasLicense reports whether the photo is under one of the license codes,
+// compared case-insensitively.
+func (p Photo) HasLicense(codes []string) bool {
+	return hasLicense(p.LicenseCode, codes)
+}
+
+// AllowsDerivatives reports whether the license of the photo allows
+// publishing modified versions, such as crops.
+func (p Photo) AllowsDerivatives() bool {
+	return p.LicenseCode != "" && !strings.Contains(strings.ToLower(p.LicenseCode), "-nd")
+}
+
+// HasLicense reports whether the sound is under one of the license codes,
+// compared case-insensitively.
+func (s Sound) HasLicense(codes []string) bool {
+	return hasLicense(s.LicenseCode, codes)
+}
+
+// hasLicense reports whether license is one of the license codes.
+func hasLicense(license string, codes []string) bool {
+	return license != "" && slices.ContainsFunc(codes, func(code string) bool {
+		return strings.EqualFold(code, license)
+	})
 }
 
 // Species represents a biological species entity.
@@ -42,6 +83,7 @@ type Species struct {
 	commonName     string
 	iconicTaxon    string
 	photos         []Photo
+	sounds         []Sound
 	ancestorIDs    []int
 	rank           string
 }
@@ -102,11 +144,56 @@ func (s *Species) AddPhoto(photo Photo) {
 	s.photos = append(s.photos, photo)
 }
 
+// LicensedPhotos returns the photos under one of the license codes, or all
+// of them when codes is empty.
+func (s *Species) LicensedPhotos(codes []string) []Photo {
+	if len(codes) == 0 {
+		return s.photos
+	}
+	licensed := make([]Photo, 0, len(s.photos))
+	for _, photo := range s.photos {
+		if photo.HasLicense(codes) {
+			licensed = append(licensed, photo)
+		}
+	}
+	return licensed
+}
+
 // HasPhotos checks if the species has any photos.
 func (s *Species) HasPhotos() bool {
 	return len(s.photos) > 0
 }
 
+// Sounds returns all sound recordings for this species.
+func (s *Species) Sounds() []Sound {
+	return s.sounds
+}
+
+// AddSound adds a sound recording to the species.
+func (s *Species) AddSound(sound Sound) {
+	s.sounds = append(s.sounds, sound)
+}
+
+// LicensedSounds returns the sound recordings under one of the license codes,
+// or all of them when codes is empty.
+func (s *Species) LicensedSounds(codes []string) []Sound {
+	if len(codes) == 0 {
+		return s.sounds
+	}
+	licensed := make([]Sound, 0, len(s.sounds))
+	for _, sound := range s.sounds {
+		if sound.HasLicense(codes) {
+			licensed = append(licensed, sound)
+		}
+	}
+	return licensed
+}
+
+// HasSounds checks if the species has any sound recording.
+func (s *Species) HasSounds() bool {
+	return len(s.sounds) > 0
+}
+
 // SetAncestorIDs sets the taxonomic ancestor IDs.
 func (s *Species) SetAncestorIDs(ids []int) {
 	s.ancestorIDs = ids
@@ -126,3 +213,15 @@ func (s *Species) SetRank(rank string) {
 func (s *Species) Rank() string {
 	return s.rank
 }
+
+// ParentID returns the ID of the closest ancestor, such as the genus of a
+// species, or 0 when ancestors are unknown. iNaturalist lists a taxon as its
+// own last ancestor, which is skipped.
+func (s *Species) ParentID() int {
+	for i := len(s.ancestorIDs) - 1; i >= 0; i-- {
+		if s.ancestorIDs[i] != s.id {
+			return s.ancestorIDs[i]
+		}
+	}
+	return 0
+}
@@ -1,6 +1,8 @@
 package species_test
 
 import (
+	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
@@ -90,6 +92,20 @@ func TestSpecies_HasPhotos(t *testing.T) {
 	}
 }
 
+func TestSpecies_HasSounds(t *testing.T) {
+	s, _ := species.New(1, "Erithacus rubecula", "Rougegorge familier", "Aves")
+
+	if s.HasSounds() {
+		t.Error("HasSounds() = true, want false for new species")
+	}
+
+	s.AddSound(species.Sound{ID: 5, FileURL: "https://example.com/song.mp3", FileContentType: "audio/mpeg"})
+
+	if !s.HasSounds() || s.Sounds()[0].ID != 5 {
+		t.Errorf("Sounds() = %+v, want the added recording", s.Sounds())
+	}
+}
+
 func TestSpecies_DisplayName(t *testing.T) {
 	s, _ := species.New(1, "Vulpes vulpes", "Renard roux", "Mammalia")
 
@@ -162,6 +178,29 @@ func TestSpecies_AncestorIDs(t *testing.T) {
 	}
 }
 
+func TestSpecies_ParentID(t *testing.T) {
+	tests := []struct {
+		name      string
+		ancestors []int
+		want      int
+	}{
+		{"no ancestors", nil, 0},
+		{"ancestors only", []int{1, 2, 3}, 3},
+		{"self listed last", []int{1, 2, 3, 42}, 3},
+		{"only self", []int{42}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := species.New(42, "Test", "Test", "Mammalia")
+			s.SetAncestorIDs(tt.ancestors)
+			if got := s.ParentID(); got != tt.want {
+				t.Errorf("ParentID() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSpecies_Rank(t *testing.T) {
 	s, _ := species.New(1, "Test", "Test", "Mammalia")
 
@@ -196,3 +235,128 @@ func TestSpecies_MultiplePhotos(t *testing.T) {
 		t.Errorf("Photos() count = %d, want 3", len(s.Photos()))
 	}
 }
+
+func TestSpecies_SnapshotRoundTrip(t *testing.T) {
+	s, _ := species.New(42069, "Vulpes vulpes", "Renard roux", "Mammalia")
+	s.SetRank("species")
+	s.SetAncestorIDs([]int{1, 2, 3})
+	s.AddPhoto(species.Photo{ID: 7, MediumURL: "https://example.com/m.jpg", Attribution: "(c) someone"})
+	s.AddSound(species.Sound{ID: 8, FileURL: "https://example.com/s.mp3", FileContentType: "audio/mpeg"})
+
+	restored, err := species.Restore(s.Snapshot())
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(restored.Snapshot(), s.Snapshot()) {
+		t.Errorf("Restore() = %+v, want %+v", restored.Snapshot(), s.Snapshot())
+	}
+
+	if _, err := species.Restore(species.Snapshot{ID: 1}); err == nil {
+		t.Error("Restore() should validate the scientific name")
+	}
+}
+
+func TestFindRank(t *testing.T) {
+	taxa := []species.Taxon{
+		{ID: 40151, Name: "Mammalia", Rank: species.RankClass},
+		{ID: 848317, Name: "Carnivora", Rank: species.RankOrder},
+		{ID: 42043, Name: "Vulpes", Rank: species.RankGenus},
+	}
+
+	if got, ok := species.FindRank(taxa, species.RankOrder); !ok || got.ID != 848317 {
+		t.Errorf("FindRank(order) = %+v, %v, want Carnivora", got, ok)
+	}
+	if _, ok := species.FindRank(taxa, species.RankFamily); ok {
+		t.Error("FindRank(family) found a taxon, want none")
+	}
+}
+
+func TestSpecies_HasAncestor(t *testing.T) {
+	s, _ := species.New(42, "Vulpes vulpes", "Renard roux", "Mammalia")
+	s.SetAncestorIDs([]int{1, 2, 3})
+
+	for _, tt := range []struct {
+		id   int
+		want bool
+	}{{2, true}, {42, true}, {4, false}} {
+		if got := s.HasAncestor(tt.id); got != tt.want {
+			t.Errorf("HasAncestor(%d) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestSpecies_LicensedPhotos(t *testing.T) {
+	sp, _ := species.New(1, "Vulpes vulpes", "Renard roux", "Mammalia")
+	sp.AddPhoto(species.Photo{ID: 1, LicenseCode: "cc-by"})
+	sp.AddPhoto(species.Photo{ID: 2}) // All rights reserved
+	sp.AddPhoto(species.Photo{ID: 3, LicenseCode: "cc-by-nc-nd"})
+
+	tests := []struct {
+		name  string
+		codes []string
+		want  []int
+	}{
+		{name: "no restriction", codes: nil, want: []int{1, 2, 3}},
+		{name: "case insensitive", codes: []string{"CC-BY"}, want: []int{1}},
+		{name: "several licenses", codes: []string{"cc-by", "cc-by-nc-nd"}, want: []int{1, 3}},
+		{name: "none allowed", codes: []string{"cc0"}, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []int{}
+			for _, photo := range sp.LicensedPhotos(tt.codes) {
+				got = append(got, photo.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("LicensedPhotos(%v) = %v, want %v", tt.codes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpecies_LicensedSounds(t *testing.T) {
+	sp, _ := species.New(1, "Erithacus rubecula", "Rougegorge familier", "Aves")
+	sp.AddSound(species.Sound{ID: 1, LicenseCode: "cc0"})
+	sp.AddSound(species.Sound{ID: 2}) // All rights reserved
+	sp.AddSound(species.Sound{ID: 3, LicenseCode: "cc-by-nc"})
+
+	tests := []struct {
+		name  string
+		codes []string
+		want  []int
+	}{
+		{name: "no restriction", codes: nil, want: []int{1, 2, 3}},
+		{name: "case insensitive", codes: []string{"CC0"}, want: []int{1}},
+		{name: "none allowed", codes: []string{"cc-by"}, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []int{}
+			for _, sound := range sp.LicensedSounds(tt.codes) {
+				got = append(got, sound.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("LicensedSounds(%v) = %v, want %v", tt.codes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhoto_AllowsDerivatives(t *testing.T) {
+	tests := []struct {
+		license string
+		want    bool
+	}{
+		{license: "cc0", want: true},
+		{license: "cc-by-sa", want: true},
+		{license: "cc-by-nd", want: false},
+		{license: "CC-BY-NC-ND", want: false},
+		{license: "", want: false},
+	}
+	for _, tt := range tests {
+		if got := (species.Photo{LicenseCode: tt.license}).AllowsDerivatives(); got != tt.want {
+			t.Errorf("AllowsDerivatives(%q) = %v, want %v", tt.license, got, tt.want)
+		}
+	}
+}
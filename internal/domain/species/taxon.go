@@ -0,0 +1,41 @@
+package species
+
+// Taxonomic ranks used to compare species.
+const (
+	RankSpecies = "species"
+	RankGenus   = "genus"
+	RankFamily  = "family"
+	RankOrder   = "order"
+	RankClass   = "class"
+)
+
+// Taxon is a taxon of any rank, such as the genus or order of a species.
+type Taxon struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	CommonName string `json:"common_name,omitempty"`
+	Rank       string `json:"rank,omitempty"`
+}
+
+// FindRank returns the taxon of the given rank among taxa.
+func FindRank(taxa []Taxon, rank string) (Taxon, bool) {
+	for _, t := range taxa {
+		if t.Rank == rank {
+			return t, true
+		}
+	}
+	return Taxon{}, false
+}
+
+// HasAncestor reports whether the species is the taxon id or descends from it.
+func (s *Species) HasAncestor(id int) bool {
+	if s.id == id {
+		return true
+	}
+	for _, ancestor := range s.ancestorIDs {
+		if ancestor == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package ports
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// LeaderboardEntry is a ranked player on a leaderboard.
+type LeaderboardEntry struct {
+	Rank     int
+	PlayerID string
+	Username string
+	Level    int
+	Score    int
+}
+
+// PageRequest selects a leaderboard page, either by offset or by cursor.
+// When Cursor is set, Offset is ignored.
+type PageRequest struct {
+	Offset int
+	Cursor string
+	Limit  int
+}
+
+// LeaderboardPage is a page of ranked entries.
+type LeaderboardPage struct {
+	Entries    []LeaderboardEntry
+	Total      int
+	NextCursor string // Empty on the last page
+}
+
+// LeaderboardStanding is a player's rank with the entries around it.
+type LeaderboardStanding struct {
+	Player    LeaderboardEntry
+	Neighbors []LeaderboardEntry // Includes the player, in rank order
+}
+
+// XPLeaderboard ranks players by total XP, ties broken by player ID.
+type XPLeaderboard interface {
+	// GetXPLeaderboard retrieves a page of the all-time XP leaderboard.
+	GetXPLeaderboard(ctx context.Context, page PageRequest) (*LeaderboardPage, error)
+
+	// GetXPStanding retrieves a player's rank and up to radius entries on each side.
+	// Returns ErrPlayerNotFound if the player is not ranked.
+	GetXPStanding(ctx context.Context, playerID string, radius int) (*LeaderboardStanding, error)
+}
+
+// TimeWindow is the period a scoped leaderboard accumulates points over.
+type TimeWindow string
+
+// Supported time windows. Periods are calendar days, ISO weeks and calendar
+// months in UTC.
+const (
+	AllTime TimeWindow = "all_time"
+	Daily   TimeWindow = "daily"
+	Weekly  TimeWindow = "weekly"
+	Monthly TimeWindow = "monthly"
+)
+
+// TimeWindows returns every supported time window.
+func TimeWindows() []TimeWindow {
+	return []TimeWindow{AllTime, Daily, Weekly, Monthly}
+}
+
+// IsValidTimeWindow checks if a time window is supported.
+func IsValidTimeWindow(w TimeWindow) bool {
+	switch w {
+	case AllTime, Daily, Weekly, Monthly:
+		return true
+	}
+	return false
+}
+
+// Period returns the identifier of the period containing t, e.g. "2024-W07".
+func (w TimeWindow) Period(t time.Time) string {
+	t = t.UTC()
+	switch w {
+	case Daily:
+		return t.Format("2006-01-02")
+	case Weekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case Monthly:
+		return t.Format("2006-01")
+	default:
+		return "all"
+	}
+}
+
+// LeaderboardScope selects a scoped points leaderboard. Empty IconicTaxon and
+// Difficulty mean all taxa and all difficulties.
+type LeaderboardScope struct {
+	Window      TimeWindow
+	IconicTaxon string
+	Difficulty  quiz.Difficulty
+}
+
+// Board returns the key of the scope's leaderboard for the period containing t.
+func (s LeaderboardScope) Board(t time.Time) string {
+	return fmt.Sprintf("%s:%s|taxon:%s|difficulty:%s", s.Window, s.Window.Period(t), s.IconicTaxon, s.Difficulty)
+}
+
+// ScoreLeaderboard ranks players by quiz points within a scope, ties broken by
+// player ID. Queries address the period containing the given time.
+type ScoreLeaderboard interface {
+	// AddScores adds points earned at the given time to each scope's leaderboard.
+	AddScores(ctx context.Context, playerID string, at time.Time, scores map[LeaderboardScope]int) error
+
+	// GetScoreLeaderboard retrieves a page of a scoped leaderboard.
+	GetScoreLeaderboard(
+		ctx context.Context,
+		scope LeaderboardScope,
+		at time.Time,
+		page PageRequest,
+	) (*LeaderboardPage, error)
+
+	// GetScoreStanding retrieves a player's rank and up to radius entries on each side.
+	// Returns ErrPlayerNotFound if the player is not ranked in the scope.
+	GetScoreStanding(
+		ctx context.Context,
+		scope LeaderboardScope,
+		at time.Time,
+		playerID string,
+		radius int,
+	) (*LeaderboardStanding, error)
+}
+
+// LeaderboardCursor identifies the last entry of a page. Pages resume strictly
+// after it, so they stay consistent when scores change between requests.
+type LeaderboardCursor struct {
+	Score    int
+	PlayerID string
+}
+
+// Encode returns the opaque string form of the cursor.
+func (c LeaderboardCursor) Encode() string {
+	raw := strconv.Itoa(c.Score) + ":" + c.PlayerID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseLeaderboardCursor decodes a cursor produced by Encode.
+func ParseLeaderboardCursor(s string) (LeaderboardCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return LeaderboardCursor{}, ErrInvalidCursor
+	}
+
+	scoreStr, playerID, ok := strings.Cut(string(raw), ":")
+	score, err := strconv.Atoi(scoreStr)
+	if !ok || err != nil || playerID == "" {
+		return LeaderboardCursor{}, ErrInvalidCursor
+	}
+	return LeaderboardCursor{Score: score, PlayerID: playerID}, nil
+}
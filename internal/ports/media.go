@@ -0,0 +1,86 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
+)
+
+// Media errors.
+var (
+	ErrMediaNotFound           = errors.New("media not found")
+	ErrMediaBandwidthExhausted = errors.New("upstream media bandwidth exhausted")
+	ErrInvalidMediaToken       = errors.New("invalid media token")
+)
+
+// CatalogMediaScheme prefixes references to media files stored with an
+// offline catalog, followed by the file name: a 32 character hexadecimal ID
+// and an extension.
+const CatalogMediaScheme = "catalog:"
+
+// ImageEffect is a transformation applied on the server to a question photo.
+type ImageEffect string
+
+// Image effects.
+const (
+	EffectCrop       ImageEffect = "crop"       // Only a region of the photo
+	EffectSilhouette ImageEffect = "silhouette" // Subject in black on white
+)
+
+// CropRegion is a region of an image, in fractions of its width and height.
+type CropRegion struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// ImageDerivative describes an image derived from a source photo.
+type ImageDerivative struct {
+	SourceURL string      `json:"source_url"`
+	Effect    ImageEffect `json:"effect"`
+	Region    CropRegion  `json:"region,omitempty"` // For EffectCrop
+}
+
+// Media is the content of a served media file.
+type Media struct {
+	ContentType string
+	Data        []byte
+}
+
+// MediaDeriver registers image derivatives, so clients never see the source photo.
+type MediaDeriver interface {
+	// Register records a derivative and returns the media reference of questions showing it.
+	Register(ctx context.Context, derivative ImageDerivative) (string, error)
+}
+
+// MediaServer serves the media of questions.
+type MediaServer interface {
+	// Open returns the media at a source URL or derivative reference, producing it when needed.
+	Open(ctx context.Context, ref string) (*Media, error)
+}
+
+// MediaGrant is the access to the media of a question given by a token.
+type MediaGrant struct {
+	SessionID  string
+	QuestionID string
+	ExpiresAt  time.Time
+	SingleUse  bool // Redeemed once only, for FlashQuiz
+}
+
+// MediaTokens issues the opaque tokens question media is served under, so
+// clients never learn the source of a question.
+type MediaTokens interface {
+	// Issue returns a token granting access to the media of a question of a session.
+	Issue(sessionID string, question *quiz.Question) string
+
+	// Redeem returns the grant of a token, or ErrInvalidMediaToken when it is
+	// forged, expired or already used.
+	Redeem(token string) (MediaGrant, error)
+
+	// Release forgets the redemption of a single-use token whose media could
+	// not be served.
+	Release(token string)
+}
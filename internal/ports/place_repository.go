@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/place"
+)
+
+// ErrPlaceNotFound is returned for unknown places.
+var ErrPlaceNotFound = errors.New("place not found")
+
+// PlaceRepository finds the places sessions can draw species from.
+type PlaceRepository interface {
+	// SearchPlaces returns the places whose name matches query, best matches
+	// first, with their ancestors.
+	SearchPlaces(ctx context.Context, query string, limit int) ([]place.Place, error)
+
+	// GetPlace retrieves a place with its ancestors, or ErrPlaceNotFound.
+	GetPlace(ctx context.Context, id int) (*place.Place, error)
+}
@@ -2,19 +2,26 @@ package ports
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/gamification"
 )
 
+// Errors returned by player repositories.
+var (
+	ErrPlayerNotFound = errors.New("player not found")
+	ErrUsernameTaken  = errors.New("username already taken")
+)
+
 // PlayerRepository defines the interface for player data persistence.
 type PlayerRepository interface {
-	// Create creates a new player.
+	// Create creates a new player, returning ErrUsernameTaken if the username is in use.
 	Create(ctx context.Context, player *gamification.Player) error
 
-	// GetByID retrieves a player by ID.
+	// GetByID retrieves a player by ID, returning ErrPlayerNotFound if missing.
 	GetByID(ctx context.Context, id string) (*gamification.Player, error)
 
-	// GetByUsername retrieves a player by username.
+	// GetByUsername retrieves a player by username, returning ErrPlayerNotFound if missing.
 	GetByUsername(ctx context.Context, username string) (*gamification.Player, error)
 
 	// Update updates a player's data.
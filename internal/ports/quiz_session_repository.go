@@ -2,19 +2,23 @@ package ports
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/quiz"
 )
 
+// ErrSessionNotFound is returned when a session does not exist or has expired.
+var ErrSessionNotFound = errors.New("session not found")
+
 // QuizSessionRepository defines the interface for quiz session persistence.
 type QuizSessionRepository interface {
 	// Save persists a quiz session.
 	Save(ctx context.Context, session *quiz.Session) error
 
-	// GetByID retrieves a session by ID.
+	// GetByID retrieves a session by ID, returning ErrSessionNotFound if missing.
 	GetByID(ctx context.Context, id string) (*quiz.Session, error)
 
-	// GetByUserID retrieves sessions for a user.
+	// GetByUserID retrieves sessions for a user, most recent first.
 	GetByUserID(ctx context.Context, userID string, limit int) ([]*quiz.Session, error)
 
 	// GetStats retrieves aggregated stats for a user.
@@ -3,6 +3,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/Naturieux-fr/Naturieux.fr/internal/domain/species"
 )
@@ -10,11 +11,20 @@ import (
 // SpeciesFilter defines filtering options for species queries.
 type SpeciesFilter struct {
 	IconicTaxon string // Filter by iconic taxon (e.g., "Mammalia")
+	TaxonID     int    // Only descendants of this taxon (e.g., a family)
 	PlaceID     int    // Filter by geographic location
 	Limit       int    // Maximum number of results
 	HasPhotos   bool   // Only species with photos
+	HasSounds   bool   // Only species with sound recordings
 	Quality     string // Quality grade (research, needs_id, casual)
-	ExcludeIDs  []int  // Species IDs to exclude
+	ExcludeIDs  []int  // Taxon IDs to exclude, with their descendants
+
+	Lat    float64 // Centre of the area species were observed in, in degrees
+	Lng    float64
+	Radius float64 // Kilometres around Lat, Lng; no area when 0
+
+	PhotoLicenses []string     // Only species with photos under one of these license codes (e.g., "cc-by")
+	Months        []time.Month // Only species observed in one of these months
 }
 
 // SpeciesRepository defines the interface for species data access.
@@ -30,4 +40,41 @@ type SpeciesRepository interface {
 
 	// Search searches for species by name.
 	Search(ctx context.Context, query string, limit int) ([]*species.Species, error)
+
+	// GetTaxa retrieves taxa of any rank by ID, such as the ancestors of a species.
+	// Unknown IDs are left out.
+	GetTaxa(ctx context.Context, ids []int) ([]species.Taxon, error)
+
+	// SearchTaxa searches for taxa of any rank by name, such as a family.
+	SearchTaxa(ctx context.Context, query string, limit int) ([]species.Taxon, error)
+
+	// GetLookalikes retrieves the species most often confused with the given
+	// one by identifiers, whatever their taxonomic distance, most confused first.
+	GetLookalikes(ctx context.Context, speciesID int, limit int) ([]*species.Species, error)
+}
+
+// RequestPriority ranks species lookups when an upstream request budget runs low.
+type RequestPriority int
+
+const (
+	// PriorityHigh serves a player waiting for a response. It is the default.
+	PriorityHigh RequestPriority = iota
+	// PriorityLow is background work, such as prefetching, deferred or refused first.
+	PriorityLow
+)
+
+// priorityKey is the context key of the request priority.
+type priorityKey struct{}
+
+// WithPriority returns a context whose species lookups run at the given priority.
+func WithPriority(ctx context.Context, priority RequestPriority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFrom returns the priority of species lookups made with ctx.
+func PriorityFrom(ctx context.Context) RequestPriority {
+	if priority, ok := ctx.Value(priorityKey{}).(RequestPriority); ok {
+		return priority
+	}
+	return PriorityHigh
 }